@@ -0,0 +1,124 @@
+// Calibrate fits logistic scorer coefficients from labelled incidents.
+//
+// The input is JSON incidents, either a GET /v1/incidents response
+// ({"incidents": [...]}) or a plain array. There is no separate label file:
+// each incident is labelled by its own fields.
+//   - "status" "confirmed" or "resolved": positive
+//   - "status" "suppressed", or "suppressed": true: negative
+//   - any other status (e.g. "draft"): skipped
+//
+// Ticket sync resolves incidents whose tickets were fixed and suppresses
+// those closed as won't fix or invalid. Features come from "scoreFeatures",
+// recorded at detection time, or are rebuilt from "triggeringSignals",
+// "signalDetails", "severityType" and "timestamp" when it is missing.
+//
+// GET /v1/incidents returns only the incidents of the project whose SDK key
+// makes the request (--api-key for "default", or its --project-keys key), so
+// fit one project at a time:
+//
+//	curl -H "X-API-Key: $PROJECT_KEY" "http://localhost:8080/v1/incidents?limit=10000" > incidents.json
+//	go run ./cmd/calibrate --input incidents.json --output model.json
+//	go run ./cmd/hawkeye --scorer logistic --scorer-model model.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/ufse/scoring"
+)
+
+func main() {
+	input := flag.String("input", "-", "Incident export JSON file (- for stdin)")
+	output := flag.String("output", "model.json", "Where to write the fitted model")
+	iterations := flag.Int("iterations", scoring.DefaultFitOptions().Iterations, "Gradient descent iterations")
+	learningRate := flag.Float64("learning-rate", scoring.DefaultFitOptions().LearningRate, "Gradient descent learning rate")
+	l2 := flag.Float64("l2", scoring.DefaultFitOptions().L2, "L2 regularization strength")
+	flag.Parse()
+
+	incidents, err := readIncidents(*input)
+	if err != nil {
+		log.Fatalf("read incidents: %v", err)
+	}
+
+	samples, skipped := labelIncidents(incidents)
+	log.Printf("%d labelled samples (%d incidents skipped: not confirmed, resolved or suppressed)", len(samples), skipped)
+
+	model, err := scoring.FitLogistic(samples, scoring.FitOptions{
+		LearningRate: *learningRate,
+		Iterations:   *iterations,
+		L2:           *l2,
+	})
+	if err != nil {
+		log.Fatalf("fit: %v", err)
+	}
+	if err := model.Save(*output); err != nil {
+		log.Fatalf("write model: %v", err)
+	}
+
+	r := model.Report
+	fmt.Printf("Fitted %s on %d samples (%d confirmed, %d suppressed)\n", model.Version, model.Samples, r.Positives, r.Negatives)
+	fmt.Printf("  log loss: %.4f  brier: %.4f  accuracy: %.2f%%\n", r.LogLoss, r.Brier, r.Accuracy*100)
+	fmt.Printf("  intercept: %+.4f\n", model.Intercept)
+	for _, name := range scoring.FeatureNames() {
+		fmt.Printf("  %-24s %+.4f\n", name, model.Coefficients[name])
+	}
+	fmt.Printf("Model written to %s\n", *output)
+}
+
+// readIncidents decodes a query response or a plain incident array.
+func readIncidents(path string) ([]types.Incident, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var incidents []types.Incident
+	if err := json.Unmarshal(data, &incidents); err == nil {
+		return incidents, nil
+	}
+	var response struct {
+		Incidents []types.Incident `json:"incidents"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("expected an incident array or query response: %w", err)
+	}
+	return response.Incidents, nil
+}
+
+// labelIncidents turns confirmed, resolved and suppressed incidents into samples,
+// preferring the feature vector recorded at detection time.
+func labelIncidents(incidents []types.Incident) ([]scoring.Sample, int) {
+	samples := make([]scoring.Sample, 0, len(incidents))
+	skipped := 0
+	for _, incident := range incidents {
+		var label bool
+		switch {
+		case incident.Suppressed || incident.Status == "suppressed":
+			label = false
+		case incident.Status == "confirmed" || incident.Status == "resolved":
+			label = true
+		default:
+			skipped++
+			continue
+		}
+
+		features := scoring.Features(incident.ScoreFeatures)
+		if len(features) == 0 {
+			features = scoring.FeaturesFromIncident(incident)
+		}
+		samples = append(samples, scoring.Sample{Features: features, Label: label})
+	}
+	return samples, skipped
+}
@@ -27,7 +27,10 @@ func main() {
 	cfg := config.Load()
 	cfg.Print()
 
-	application := app.New(cfg)
+	application, err := app.New(cfg)
+	if err != nil {
+		log.Fatalf("[hawkeye] startup failed: %v", err)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -84,6 +84,7 @@ func setupIncidentRoutes(engine *store.StoreEngine, dedup *store.DeduplicationEn
 	ingestHandler := api.NewIngestHandler(engine, dedup)
 	queryHandler := api.NewQueryHandler(engine)
 	healthHandler := api.NewHealthHandler(healthStore)
+	exportHandler := api.NewExportHandler(engine)
 	router := chi.NewRouter()
 	router.Use(middleware.RequestID)
 	router.Use(middleware.RealIP)
@@ -92,5 +93,6 @@ func setupIncidentRoutes(engine *store.StoreEngine, dedup *store.DeduplicationEn
 	router.Get("/health", healthHandler.HealthCheck)
 	router.Post("/v1/incidents", ingestHandler.IngestIncident)
 	router.Get("/v1/incidents", queryHandler.QueryIncidents)
+	router.Patch("/v1/incidents/{id}/export", exportHandler.UpdateExport)
 	return router
 }
\ No newline at end of file
@@ -0,0 +1,119 @@
+/**
+ * Adapter Errors
+ *
+ * Responsibility: Classify ticket system failures as retryable or permanent
+ *
+ * Rules:
+ * - Network errors, 408, 429 and 5xx → retryable
+ * - Any other 4xx (bad auth, bad field, missing project) → permanent
+ * - GraphQL errors: RATELIMITED / INTERNAL_SERVER_ERROR codes → retryable,
+ *   any other code → permanent
+ * - The exporter stops retrying as soon as an error is permanent
+ */
+
+package adapters
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrRetryable marks failures that may succeed if retried
+	ErrRetryable = errors.New("retryable ticket system error")
+	// ErrPermanent marks failures that will not succeed without a config or data change
+	ErrPermanent = errors.New("permanent ticket system error")
+)
+
+// HTTPError is a non-2xx response from a ticket system
+type HTTPError struct {
+	System     string        // Adapter name
+	StatusCode int           // HTTP status code
+	Message    string        // Error detail from the response body
+	RetryAfter time.Duration // From Retry-After, if present
+	// RateLimited marks rate-limit responses that use a non-429 status
+	// (e.g. GitHub's 403 with X-RateLimit-Remaining: 0)
+	RateLimited bool
+}
+
+func (e *HTTPError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s: HTTP %d", e.System, e.StatusCode)
+	}
+	return fmt.Sprintf("%s: HTTP %d: %s", e.System, e.StatusCode, e.Message)
+}
+
+// Retryable reports whether the status code is worth retrying
+func (e *HTTPError) Retryable() bool {
+	return e.RateLimited || isRetryableStatus(e.StatusCode)
+}
+
+// Unwrap lets callers match ErrRetryable / ErrPermanent with errors.Is
+func (e *HTTPError) Unwrap() error {
+	if e.Retryable() {
+		return ErrRetryable
+	}
+	return ErrPermanent
+}
+
+// IsRetryable reports whether an adapter error is worth retrying. Errors that
+// are not classified (e.g. network failures) are treated as retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	return !errors.Is(err, ErrPermanent)
+}
+
+// newHTTPError builds an HTTPError from a response and its (already read) body message
+func newHTTPError(system string, resp *http.Response, message string) *HTTPError {
+	httpErr := &HTTPError{System: system, StatusCode: resp.StatusCode, Message: message}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		httpErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return httpErr
+}
+
+// permanentError wraps a non-HTTP failure that retrying cannot fix
+func permanentError(system string, err error) error {
+	return fmt.Errorf("%s: %w: %v", system, ErrPermanent, err)
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500
+}
+
+// GraphQLError is an error returned in a GraphQL response's errors array
+type GraphQLError struct {
+	System  string // Adapter name
+	Code    string // extensions.code (e.g. RATELIMITED, AUTHENTICATION_ERROR)
+	Message string
+}
+
+func (e *GraphQLError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%s: %s", e.System, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.System, e.Code, e.Message)
+}
+
+// Retryable reports whether the error code is worth retrying
+func (e *GraphQLError) Retryable() bool {
+	switch e.Code {
+	case "RATELIMITED", "INTERNAL_SERVER_ERROR", "SERVICE_UNAVAILABLE":
+		return true
+	default:
+		return false
+	}
+}
+
+// Unwrap lets callers match ErrRetryable / ErrPermanent with errors.Is
+func (e *GraphQLError) Unwrap() error {
+	if e.Retryable() {
+		return ErrRetryable
+	}
+	return ErrPermanent
+}
@@ -0,0 +1,303 @@
+/**
+ * GitHub Issues Adapter
+ *
+ * Responsibility: GitHub Issues integration (REST API)
+ *
+ * Idempotency:
+ * - Every issue body ends with a hidden marker
+ *   <!-- hawkeye-idempotency-key: incident_<id> -->
+ * - Before creating, the adapter searches the repository's issues for the
+ *   marker and confirms the exact key in the body
+ *
+ * The API base URL is configurable for GitHub Enterprise
+ * (https://github.example.com/api/v3) and local fakes.
+ *
+ * Status push: "issues" webhook events signed with X-Hub-Signature-256
+ */
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// DefaultGitHubAPIURL is the public GitHub REST API
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// githubMarkerPrefix starts the hidden idempotency marker in issue bodies
+const githubMarkerPrefix = "<!-- hawkeye-idempotency-key: "
+
+// GitHubConfig configures the GitHub adapter
+type GitHubConfig struct {
+	APIURL              string // Default DefaultGitHubAPIURL
+	Token               string
+	Owner               string
+	Repo                string
+	PriorityLabelPrefix string // Default "priority:" → "priority:P2"
+	Timeout             time.Duration
+}
+
+// GitHubAdapter implements GitHub Issues integration
+type GitHubAdapter struct {
+	apiURL              string
+	token               string
+	owner               string
+	repo                string
+	priorityLabelPrefix string
+	client              *http.Client
+}
+
+// NewGitHubAdapter creates a new GitHub adapter for the public API
+func NewGitHubAdapter(token, owner, repo string) *GitHubAdapter {
+	return NewGitHubAdapterWithConfig(GitHubConfig{
+		Token: token,
+		Owner: owner,
+		Repo:  repo,
+	})
+}
+
+// NewGitHubAdapterWithConfig creates a new GitHub adapter with custom config
+func NewGitHubAdapterWithConfig(config GitHubConfig) *GitHubAdapter {
+	if config.APIURL == "" {
+		config.APIURL = DefaultGitHubAPIURL
+	}
+	if config.PriorityLabelPrefix == "" {
+		config.PriorityLabelPrefix = "priority:"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &GitHubAdapter{
+		apiURL:              strings.TrimRight(config.APIURL, "/"),
+		token:               config.Token,
+		owner:               config.Owner,
+		repo:                config.Repo,
+		priorityLabelPrefix: config.PriorityLabelPrefix,
+		client: &http.Client{
+			Timeout: config.Timeout,
+		},
+	}
+}
+
+// Name returns adapter name
+func (g *GitHubAdapter) Name() string {
+	return "github"
+}
+
+// githubIssue is the subset of a GitHub issue the adapter reads
+type githubIssue struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	State       string     `json:"state"`
+	StateReason string     `json:"state_reason,omitempty"`
+	HTMLURL     string     `json:"html_url"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	Labels      []struct {
+		Name string `json:"name"`
+	} `json:"labels,omitempty"`
+}
+
+// CreateTicket creates an issue in GitHub. The returned ticket ID is
+// "owner/repo#number".
+func (g *GitHubAdapter) CreateTicket(ctx context.Context, incident types.Incident, ticket types.Ticket, idempotencyKey string) (string, error) {
+	// Check if ticket already exists (idempotency)
+	existingTicketID, err := g.findTicketByKey(ctx, idempotencyKey)
+	if err != nil {
+		return "", err // Cannot prove the ticket doesn't exist → don't risk a duplicate
+	}
+	if existingTicketID != "" {
+		return existingTicketID, nil // Ticket already exists
+	}
+
+	var created githubIssue
+	path := fmt.Sprintf("/repos/%s/%s/issues", url.PathEscape(g.owner), url.PathEscape(g.repo))
+	if err := g.do(ctx, http.MethodPost, path, g.buildGitHubIssue(ticket, idempotencyKey), &created); err != nil {
+		return "", err
+	}
+	if created.Number == 0 {
+		return "", permanentError(g.Name(), fmt.Errorf("create response has no issue number"))
+	}
+	return g.ticketID(created.Number), nil
+}
+
+// buildGitHubIssue builds the issue payload: Markdown body with the hidden
+// marker, formatter labels plus a priority label
+func (g *GitHubAdapter) buildGitHubIssue(ticket types.Ticket, idempotencyKey string) map[string]interface{} {
+	labels := append([]string{}, ticket.Labels...)
+	if priority := ticket.Metadata["priority"]; priority != "" {
+		labels = append(labels, g.priorityLabelPrefix+priority)
+	}
+
+	return map[string]interface{}{
+		"title":  ticket.Title,
+		"body":   ticket.Description + "\n\n" + githubMarker(idempotencyKey),
+		"labels": labels,
+	}
+}
+
+// findTicketByKey finds an issue whose body carries the idempotency marker
+func (g *GitHubAdapter) findTicketByKey(ctx context.Context, idempotencyKey string) (string, error) {
+	query := url.Values{}
+	query.Set("q", fmt.Sprintf(`repo:%s/%s is:issue in:body "hawkeye-idempotency-key: %s"`, g.owner, g.repo, idempotencyKey))
+	query.Set("per_page", "10")
+
+	var result struct {
+		Items []githubIssue `json:"items"`
+	}
+	if err := g.do(ctx, http.MethodGet, "/search/issues?"+query.Encode(), nil, &result); err != nil {
+		return "", err
+	}
+
+	// Search is tokenized; confirm the exact marker
+	marker := githubMarker(idempotencyKey)
+	for _, issue := range result.Items {
+		if strings.Contains(issue.Body, marker) {
+			return g.ticketID(issue.Number), nil
+		}
+	}
+	return "", nil
+}
+
+// GetTicket retrieves an issue by "owner/repo#number" or plain number
+func (g *GitHubAdapter) GetTicket(ctx context.Context, ticketID string) (*TicketInfo, error) {
+	number, err := strconv.Atoi(ticketID[strings.LastIndex(ticketID, "#")+1:])
+	if err != nil {
+		return nil, permanentError(g.Name(), fmt.Errorf("invalid ticket ID %q", ticketID))
+	}
+
+	var issue githubIssue
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d", url.PathEscape(g.owner), url.PathEscape(g.repo), number)
+	if err := g.do(ctx, http.MethodGet, path, nil, &issue); err != nil {
+		return nil, err
+	}
+	return g.ticketInfo(issue), nil
+}
+
+// ParseTicketWebhook decodes an "issues" webhook event for this repository,
+// verified with X-Hub-Signature-256. Other events are ignored
+func (g *GitHubAdapter) ParseTicketWebhook(header http.Header, body []byte, secret string) (*TicketInfo, error) {
+	if err := verifyHexSignature(secret, body, header.Get("X-Hub-Signature-256")); err != nil {
+		return nil, err
+	}
+	if header.Get("X-GitHub-Event") != "issues" {
+		return nil, nil
+	}
+	var event struct {
+		Issue      *githubIssue `json:"issue"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("decode github issues event: %w", err)
+	}
+	if event.Issue == nil || !strings.EqualFold(event.Repository.FullName, g.owner+"/"+g.repo) {
+		return nil, nil
+	}
+	return g.ticketInfo(*event.Issue), nil
+}
+
+// ticketInfo converts an issue to TicketInfo
+func (g *GitHubAdapter) ticketInfo(issue githubIssue) *TicketInfo {
+	info := &TicketInfo{
+		ID:         g.ticketID(issue.Number),
+		Title:      issue.Title,
+		Status:     issue.State,
+		Resolution: issue.StateReason,
+		URL:        issue.HTMLURL,
+	}
+	if issue.ClosedAt != nil {
+		info.ResolvedAt = *issue.ClosedAt
+	}
+	for _, label := range issue.Labels {
+		info.Labels = append(info.Labels, label.Name)
+	}
+	return info
+}
+
+// ReopenTicket comments on an issue and reopens it
+func (g *GitHubAdapter) ReopenTicket(ctx context.Context, ticketID, comment string) error {
+	number, err := strconv.Atoi(ticketID[strings.LastIndex(ticketID, "#")+1:])
+	if err != nil {
+		return permanentError(g.Name(), fmt.Errorf("invalid ticket ID %q", ticketID))
+	}
+
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d", url.PathEscape(g.owner), url.PathEscape(g.repo), number)
+	if err := g.do(ctx, http.MethodPost, path+"/comments", map[string]string{"body": comment}, nil); err != nil {
+		return err
+	}
+	return g.do(ctx, http.MethodPatch, path, map[string]string{"state": "open", "state_reason": "reopened"}, nil)
+}
+
+// do sends a request to the GitHub API and decodes the JSON response into out
+func (g *GitHubAdapter) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reqBody, err := json.Marshal(body)
+		if err != nil {
+			return permanentError(g.Name(), fmt.Errorf("failed to marshal request: %w", err))
+		}
+		reader = bytes.NewReader(reqBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.apiURL+path, reader)
+	if err != nil {
+		return permanentError(g.Name(), fmt.Errorf("failed to create request: %w", err))
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("github: %w: %v", ErrRetryable, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1*1024*1024)) // 1MB limit
+	if err != nil {
+		return fmt.Errorf("github: %w: failed to read response: %v", ErrRetryable, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var payload struct {
+			Message string `json:"message"`
+		}
+		json.Unmarshal(respBody, &payload)
+		httpErr := newHTTPError(g.Name(), resp, payload.Message)
+		// GitHub signals primary rate limits with 403 and no remaining quota
+		httpErr.RateLimited = resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0"
+		return httpErr
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return permanentError(g.Name(), fmt.Errorf("failed to decode response: %w", err))
+	}
+	return nil
+}
+
+// ticketID formats an issue number as "owner/repo#number"
+func (g *GitHubAdapter) ticketID(number int) string {
+	return fmt.Sprintf("%s/%s#%d", g.owner, g.repo, number)
+}
+
+// githubMarker renders the hidden idempotency marker for an issue body
+func githubMarker(idempotencyKey string) string {
+	return githubMarkerPrefix + idempotencyKey + " -->"
+}
@@ -0,0 +1,170 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// fakeGitHub is a minimal GitHub Issues API served under a GHE-style prefix.
+type fakeGitHub struct {
+	mu          sync.Mutex
+	issues      []githubIssue
+	labels      map[int][]string
+	lastAuth    string
+	lastQuery   string
+	rateLimited bool
+}
+
+func newFakeGitHub() *fakeGitHub {
+	return &fakeGitHub{labels: make(map[int][]string)}
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastAuth = r.Header.Get("Authorization")
+
+	if f.rateLimited {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{"message": "API rate limit exceeded"})
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v3")
+	switch {
+	case r.Method == http.MethodPost && path == "/repos/acme/web/issues":
+		var body struct {
+			Title  string   `json:"title"`
+			Body   string   `json:"body"`
+			Labels []string `json:"labels"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		issue := githubIssue{
+			Number:  len(f.issues) + 1,
+			Title:   body.Title,
+			Body:    body.Body,
+			State:   "open",
+			HTMLURL: "https://github.example.com/acme/web/issues/" + strconv.Itoa(len(f.issues)+1),
+		}
+		f.issues = append(f.issues, issue)
+		f.labels[issue.Number] = body.Labels
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(issue)
+
+	case r.Method == http.MethodGet && path == "/search/issues":
+		f.lastQuery = r.URL.Query().Get("q")
+		// Tokenized search: match on the key with punctuation stripped
+		start := strings.Index(f.lastQuery, "hawkeye-idempotency-key: ") + len("hawkeye-idempotency-key: ")
+		token := strings.Trim(f.lastQuery[start:], `"`)
+		token = strings.SplitN(token, "-", 2)[0]
+		items := []githubIssue{}
+		for _, issue := range f.issues {
+			if strings.Contains(issue.Body, token) {
+				items = append(items, issue)
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/repos/acme/web/issues/"):
+		number, _ := strconv.Atoi(strings.TrimPrefix(path, "/repos/acme/web/issues/"))
+		if number < 1 || number > len(f.issues) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": "Not Found"})
+			return
+		}
+		json.NewEncoder(w).Encode(f.issues[number-1])
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestGitHub(serverURL string) *GitHubAdapter {
+	return NewGitHubAdapterWithConfig(GitHubConfig{
+		APIURL: serverURL + "/api/v3",
+		Token:  "ghp_test",
+		Owner:  "acme",
+		Repo:   "web",
+	})
+}
+
+func TestGitHubAdapter_CreateTicketWithMarkerAndLabels(t *testing.T) {
+	fake := newFakeGitHub()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	github := newTestGitHub(server.URL)
+	ctx := context.Background()
+
+	first, err := github.CreateTicket(ctx, types.Incident{}, testTicket(), "incident_a-1")
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	again, _ := github.CreateTicket(ctx, types.Incident{}, testTicket(), "incident_a-1")
+	// Shares the search token "incident_a" but is a different key
+	other, _ := github.CreateTicket(ctx, types.Incident{}, testTicket(), "incident_a-2")
+
+	if first != "acme/web#1" || again != first {
+		t.Errorf("ticket IDs = %q, %q; want acme/web#1 twice", first, again)
+	}
+	if other == first || len(fake.issues) != 2 {
+		t.Errorf("different key must create a new issue (got %q, %d issues)", other, len(fake.issues))
+	}
+	if fake.lastAuth != "Bearer ghp_test" {
+		t.Errorf("Authorization = %q", fake.lastAuth)
+	}
+	if !strings.Contains(fake.lastQuery, "repo:acme/web") {
+		t.Errorf("search %q should be scoped to the repository", fake.lastQuery)
+	}
+
+	body := fake.issues[0].Body
+	if !strings.HasPrefix(body, "Summary") || !strings.HasSuffix(body, "<!-- hawkeye-idempotency-key: incident_a-1 -->") {
+		t.Errorf("body should be the description followed by the hidden marker, got %q", body)
+	}
+	labels := strings.Join(fake.labels[1], ",")
+	if labels != "user-behavior-detection,bug,priority:P2" {
+		t.Errorf("labels = %s", labels)
+	}
+}
+
+func TestGitHubAdapter_GetTicket(t *testing.T) {
+	fake := newFakeGitHub()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	github := newTestGitHub(server.URL)
+	ctx := context.Background()
+	id, _ := github.CreateTicket(ctx, types.Incident{}, testTicket(), "incident_x")
+
+	info, err := github.GetTicket(ctx, id)
+	if err != nil {
+		t.Fatalf("GetTicket: %v", err)
+	}
+	if info.ID != id || info.Status != "open" || info.Title != "Checkout button unresponsive" {
+		t.Errorf("GetTicket = %+v", info)
+	}
+	if _, err := github.GetTicket(ctx, "acme/web#99"); err == nil || IsRetryable(err) {
+		t.Errorf("missing issue: err = %v, want permanent error", err)
+	}
+}
+
+func TestGitHubAdapter_RateLimitIsRetryable(t *testing.T) {
+	fake := newFakeGitHub()
+	fake.rateLimited = true
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	_, err := newTestGitHub(server.URL).CreateTicket(context.Background(), types.Incident{}, testTicket(), "incident_x")
+	if err == nil || !IsRetryable(err) {
+		t.Errorf("rate-limited 403: err = %v, want retryable", err)
+	}
+}
@@ -9,6 +9,8 @@ package adapters
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/your-org/frustration-engine/internal/types"
 )
@@ -23,14 +25,34 @@ type Adapter interface {
 	// GetTicket retrieves ticket by ID (for idempotency check)
 	GetTicket(ctx context.Context, ticketID string) (*TicketInfo, error)
 
-	// Name returns adapter name (e.g., "jira", "linear")
+	// Name returns adapter name (e.g., "jira", "linear", "github")
 	Name() string
 }
 
+// TicketReopener is implemented by adapters that can reopen a resolved
+// ticket when its issue regresses. The comment explains the regression
+type TicketReopener interface {
+	ReopenTicket(ctx context.Context, ticketID, comment string) error
+}
+
+// TicketWebhookParser is implemented by adapters that accept ticket changes
+// pushed by their ticket system. ParseTicketWebhook verifies the delivery
+// against secret (failing with ErrInvalidSignature) and returns the ticket
+// it describes, or nil for deliveries that carry no ticket change
+type TicketWebhookParser interface {
+	ParseTicketWebhook(header http.Header, body []byte, secret string) (*TicketInfo, error)
+}
+
 // TicketInfo represents ticket information
 type TicketInfo struct {
-	ID      string
-	Title   string
-	Status  string
-	URL     string
+	ID     string
+	Title  string
+	Status string
+	// Resolution is why a closed ticket was closed, when the system records
+	// it (Jira resolution, Linear state type, GitHub state_reason)
+	Resolution string
+	Labels     []string
+	URL        string
+	// ResolvedAt is when the ticket was closed, if the system reports it
+	ResolvedAt time.Time
 }
\ No newline at end of file
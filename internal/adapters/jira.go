@@ -1,38 +1,85 @@
 /**
  * Jira Adapter
- * 
+ *
  * Author: Frank Miller (Team Beta)
- * Responsibility: Jira integration
+ * Responsibility: Jira integration (REST API v3)
+ *
+ * Idempotency:
+ * - Every issue carries the idempotency key (e.g. incident_<id>), either in
+ *   a configurable text custom field or, by default, as a label
+ * - Before creating, the adapter searches with JQL for that key and returns
+ *   the existing issue instead of filing a duplicate
+ *
+ * Auth:
+ * - Email + API token → Basic auth (Jira Cloud)
+ * - Token only → Bearer (personal access tokens)
  */
 
 package adapters
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/your-org/frustration-engine/internal/types"
 )
 
+// JiraConfig configures the Jira adapter
+type JiraConfig struct {
+	BaseURL          string // e.g. https://acme.atlassian.net
+	ProjectKey       string
+	Email            string // Set for Basic auth; empty = Bearer
+	APIToken         string
+	IssueType        string // Default "Bug"
+	IdempotencyField string // Custom field ID (e.g. customfield_10050); empty = label
+	Timeout          time.Duration
+}
+
 // JiraAdapter implements Jira integration
 type JiraAdapter struct {
-	baseURL    string
-	apiToken   string
-	projectKey string
-	client     *http.Client
+	baseURL          string
+	email            string
+	apiToken         string
+	projectKey       string
+	issueType        string
+	idempotencyField string
+	client           *http.Client
 }
 
-// NewJiraAdapter creates a new Jira adapter
+// NewJiraAdapter creates a new Jira adapter using Bearer auth and label-based idempotency
 func NewJiraAdapter(baseURL, apiToken, projectKey string) *JiraAdapter {
+	return NewJiraAdapterWithConfig(JiraConfig{
+		BaseURL:    baseURL,
+		APIToken:   apiToken,
+		ProjectKey: projectKey,
+	})
+}
+
+// NewJiraAdapterWithConfig creates a new Jira adapter with custom config
+func NewJiraAdapterWithConfig(config JiraConfig) *JiraAdapter {
+	if config.IssueType == "" {
+		config.IssueType = "Bug"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
 	return &JiraAdapter{
-		baseURL:    baseURL,
-		apiToken:   apiToken,
-		projectKey: projectKey,
+		baseURL:          strings.TrimRight(config.BaseURL, "/"),
+		email:            config.Email,
+		apiToken:         config.APIToken,
+		projectKey:       config.ProjectKey,
+		issueType:        config.IssueType,
+		idempotencyField: config.IdempotencyField,
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: config.Timeout,
 		},
 	}
 }
@@ -46,72 +93,64 @@ func (j *JiraAdapter) Name() string {
 func (j *JiraAdapter) CreateTicket(ctx context.Context, incident types.Incident, ticket types.Ticket, idempotencyKey string) (string, error) {
 	// Check if ticket already exists (idempotency)
 	existingTicketID, err := j.findTicketByKey(ctx, idempotencyKey)
-	if err == nil && existingTicketID != "" {
+	if err != nil {
+		return "", err // Cannot prove the ticket doesn't exist → don't risk a duplicate
+	}
+	if existingTicketID != "" {
 		return existingTicketID, nil // Ticket already exists
 	}
 
 	// Create Jira issue
-	issue := j.buildJiraIssue(ticket, incident)
+	issue := j.buildJiraIssue(ticket, incident, idempotencyKey)
 
-	// POST to Jira API
-	url := fmt.Sprintf("%s/rest/api/3/issue", j.baseURL)
-	reqBody, err := json.Marshal(issue)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal issue: %w", err)
+	var created struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
 	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+	if err := j.do(ctx, http.MethodPost, "/rest/api/3/issue", issue, &created); err != nil {
+		return "", err
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+j.apiToken)
-	req.Body = http.MaxBytesReader(nil, req.Body, 1*1024*1024) // 1MB limit
-
-	// TODO: Implement actual HTTP request
-	// For now, return placeholder
-	_ = reqBody
-
-	return "", fmt.Errorf("Jira integration not yet implemented")
+	if created.Key == "" {
+		return "", permanentError(j.Name(), fmt.Errorf("create response has no issue key"))
+	}
+	return created.Key, nil
 }
 
 // buildJiraIssue builds Jira issue structure
-func (j *JiraAdapter) buildJiraIssue(ticket types.Ticket, incident types.Incident) map[string]interface{} {
+func (j *JiraAdapter) buildJiraIssue(ticket types.Ticket, incident types.Incident, idempotencyKey string) map[string]interface{} {
 	// Get priority from ticket metadata (already mapped by PriorityMapper)
 	priority := ticket.Metadata["priority"]
 	jiraPriority := mapPriorityToJira(priority)
 
+	labels := append([]string{}, ticket.Labels...)
+	if j.idempotencyField == "" {
+		labels = append(labels, idempotencyKey)
+	}
+
 	// Build issue fields
 	fields := map[string]interface{}{
 		"project": map[string]string{
 			"key": j.projectKey,
 		},
 		"summary":     ticket.Title,
-		"description": map[string]interface{}{
-			"type":    "doc",
-			"version": 1,
-			"content": []map[string]interface{}{
-				{
-					"type": "paragraph",
-					"content": []map[string]interface{}{
-						{"type": "text", "text": ticket.Description},
-					},
-				},
-			},
-		},
+		"description": adfDocument(ticket.Description),
 		"issuetype": map[string]string{
-			"name": "Bug", // Or "Task" based on severity
+			"name": j.issueType,
 		},
 		"priority": map[string]string{
 			"name": jiraPriority,
 		},
-		"labels": ticket.Labels,
+		"labels": labels,
 	}
 
-	// Add custom fields from metadata
+	// Add custom fields from metadata (other metadata keys are not Jira fields)
 	for key, value := range ticket.Metadata {
-		fields[key] = value
+		if strings.HasPrefix(key, "customfield_") {
+			fields[key] = value
+		}
+	}
+	if j.idempotencyField != "" {
+		fields[j.idempotencyField] = idempotencyKey
 	}
 
 	return map[string]interface{}{
@@ -121,15 +160,244 @@ func (j *JiraAdapter) buildJiraIssue(ticket types.Ticket, incident types.Inciden
 
 // findTicketByKey finds ticket by idempotency key
 func (j *JiraAdapter) findTicketByKey(ctx context.Context, idempotencyKey string) (string, error) {
-	// TODO: Search Jira for ticket with custom field matching idempotency key
-	// This ensures idempotency
+	clause := fmt.Sprintf("labels = %s", jqlQuote(idempotencyKey))
+	fieldsParam := "key"
+	if j.idempotencyField != "" {
+		clause = fmt.Sprintf("%s ~ %s", jqlField(j.idempotencyField), jqlQuote(idempotencyKey))
+		fieldsParam = j.idempotencyField
+	}
+
+	query := url.Values{}
+	query.Set("jql", fmt.Sprintf("project = %s AND %s", jqlQuote(j.projectKey), clause))
+	query.Set("fields", fieldsParam)
+	query.Set("maxResults", "10")
+
+	var result struct {
+		Issues []struct {
+			Key    string                 `json:"key"`
+			Fields map[string]interface{} `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := j.do(ctx, http.MethodGet, "/rest/api/3/search/jql?"+query.Encode(), nil, &result); err != nil {
+		return "", err
+	}
+
+	for _, issue := range result.Issues {
+		if j.idempotencyField == "" {
+			return issue.Key, nil // Label match is exact
+		}
+		// Text custom field search (~) is fuzzy; confirm the exact value
+		if value, ok := issue.Fields[j.idempotencyField].(string); ok && value == idempotencyKey {
+			return issue.Key, nil
+		}
+	}
 	return "", nil
 }
 
 // GetTicket retrieves ticket by ID
 func (j *JiraAdapter) GetTicket(ctx context.Context, ticketID string) (*TicketInfo, error) {
-	// TODO: GET /rest/api/3/issue/{ticketID}
-	return nil, fmt.Errorf("not implemented")
+	var issue jiraIssue
+	path := "/rest/api/3/issue/" + url.PathEscape(ticketID) + "?fields=summary,status,resolution,resolutiondate,labels"
+	if err := j.do(ctx, http.MethodGet, path, nil, &issue); err != nil {
+		return nil, err
+	}
+	return j.ticketInfo(issue), nil
+}
+
+// jiraIssue is the part of a Jira issue the sync reads
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		Status  struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		Resolution *struct {
+			Name string `json:"name"`
+		} `json:"resolution"`
+		ResolutionDate string   `json:"resolutiondate"`
+		Labels         []string `json:"labels"`
+	} `json:"fields"`
+}
+
+// jiraTimeLayout is how Jira formats timestamps, e.g. 2026-10-18T09:30:00.000+0000
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// ParseTicketWebhook decodes a jira:issue_* webhook event, verified with
+// the X-Hub-Signature header Jira sends for webhooks with a secret. Other
+// events are ignored
+func (j *JiraAdapter) ParseTicketWebhook(header http.Header, body []byte, secret string) (*TicketInfo, error) {
+	if err := verifyHexSignature(secret, body, header.Get("X-Hub-Signature")); err != nil {
+		return nil, err
+	}
+	var event struct {
+		WebhookEvent string     `json:"webhookEvent"`
+		Issue        *jiraIssue `json:"issue"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("decode jira webhook event: %w", err)
+	}
+	if !strings.HasPrefix(event.WebhookEvent, "jira:issue_") || event.Issue == nil {
+		return nil, nil
+	}
+	return j.ticketInfo(*event.Issue), nil
+}
+
+// ticketInfo converts an issue to TicketInfo
+func (j *JiraAdapter) ticketInfo(issue jiraIssue) *TicketInfo {
+	info := &TicketInfo{
+		ID:     issue.Key,
+		Title:  issue.Fields.Summary,
+		Status: issue.Fields.Status.Name,
+		Labels: issue.Fields.Labels,
+		URL:    j.baseURL + "/browse/" + issue.Key,
+	}
+	if issue.Fields.Resolution != nil {
+		info.Resolution = issue.Fields.Resolution.Name
+	}
+	if resolvedAt, err := time.Parse(jiraTimeLayout, issue.Fields.ResolutionDate); err == nil {
+		info.ResolvedAt = resolvedAt
+	}
+	return info
+}
+
+// jiraReopenTransitions are transition (or target status) names that reopen
+// an issue, in order of preference
+var jiraReopenTransitions = []string{"reopen", "reopen issue", "reopened", "to do", "open", "backlog"}
+
+// ReopenTicket comments on an issue and applies its reopen transition, if
+// the workflow has one
+func (j *JiraAdapter) ReopenTicket(ctx context.Context, ticketID, comment string) error {
+	path := "/rest/api/3/issue/" + url.PathEscape(ticketID)
+	if err := j.do(ctx, http.MethodPost, path+"/comment", map[string]interface{}{"body": adfDocument(comment)}, nil); err != nil {
+		return err
+	}
+
+	var result struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := j.do(ctx, http.MethodGet, path+"/transitions", nil, &result); err != nil {
+		return err
+	}
+	for _, name := range jiraReopenTransitions {
+		for _, t := range result.Transitions {
+			if strings.EqualFold(t.Name, name) || strings.EqualFold(t.To.Name, name) {
+				body := map[string]interface{}{"transition": map[string]string{"id": t.ID}}
+				return j.do(ctx, http.MethodPost, path+"/transitions", body, nil)
+			}
+		}
+	}
+	log.Printf("[Jira Adapter] No reopen transition for %s; left a comment only", ticketID)
+	return nil
+}
+
+// do sends a request to the Jira API and decodes the JSON response into out
+func (j *JiraAdapter) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reqBody, err := json.Marshal(body)
+		if err != nil {
+			return permanentError(j.Name(), fmt.Errorf("failed to marshal request: %w", err))
+		}
+		reader = bytes.NewReader(reqBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, j.baseURL+path, reader)
+	if err != nil {
+		return permanentError(j.Name(), fmt.Errorf("failed to create request: %w", err))
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if j.email != "" {
+		req.SetBasicAuth(j.email, j.apiToken)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+j.apiToken)
+	}
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("jira: %w: %v", ErrRetryable, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1*1024*1024)) // 1MB limit
+	if err != nil {
+		return fmt.Errorf("jira: %w: failed to read response: %v", ErrRetryable, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newHTTPError(j.Name(), resp, jiraErrorMessage(respBody))
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return permanentError(j.Name(), fmt.Errorf("failed to decode response: %w", err))
+	}
+	return nil
+}
+
+// jiraErrorMessage extracts errorMessages / errors from a Jira error body
+func jiraErrorMessage(body []byte) string {
+	var payload struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return strings.TrimSpace(string(body))
+	}
+	messages := append([]string{}, payload.ErrorMessages...)
+	for field, message := range payload.Errors {
+		messages = append(messages, field+": "+message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// adfDocument converts plain text into an Atlassian Document Format document,
+// one paragraph per blank-line separated block
+func adfDocument(text string) map[string]interface{} {
+	content := make([]map[string]interface{}, 0)
+	for _, block := range strings.Split(text, "\n\n") {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+		inline := make([]map[string]interface{}, 0)
+		for i, line := range strings.Split(block, "\n") {
+			if i > 0 {
+				inline = append(inline, map[string]interface{}{"type": "hardBreak"})
+			}
+			if line != "" {
+				inline = append(inline, map[string]interface{}{"type": "text", "text": line})
+			}
+		}
+		content = append(content, map[string]interface{}{"type": "paragraph", "content": inline})
+	}
+	return map[string]interface{}{
+		"type":    "doc",
+		"version": 1,
+		"content": content,
+	}
+}
+
+// jqlQuote quotes a JQL string literal
+func jqlQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// jqlField converts a custom field ID (customfield_10050) to its JQL name (cf[10050])
+func jqlField(fieldID string) string {
+	if id := strings.TrimPrefix(fieldID, "customfield_"); id != fieldID {
+		return "cf[" + id + "]"
+	}
+	return jqlQuote(fieldID)
 }
 
 // mapPriorityToJira maps priority level to Jira priority
@@ -148,4 +416,4 @@ func mapPriorityToJira(priority string) string {
 	default:
 		return "Medium"
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,302 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// fakeJira is a minimal in-memory Jira REST v3 server.
+type fakeJira struct {
+	mu         sync.Mutex
+	issues     map[string]map[string]interface{} // key → fields
+	nextID     int
+	created    int
+	lastAuth   string
+	lastJQL    string
+	failCreate int // HTTP status to return from create, 0 = succeed
+}
+
+func newFakeJira() *fakeJira {
+	return &fakeJira{issues: make(map[string]map[string]interface{}), nextID: 1}
+}
+
+func (f *fakeJira) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastAuth = r.Header.Get("Authorization")
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/rest/api/3/issue":
+		if f.failCreate != 0 {
+			w.WriteHeader(f.failCreate)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"errorMessages": []string{},
+				"errors":        map[string]string{"priority": "Priority name 'P2' is not valid"},
+			})
+			return
+		}
+		var body struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		key := "HAWK-" + strconv.Itoa(f.nextID)
+		f.nextID++
+		f.created++
+		f.issues[key] = body.Fields
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": strconv.Itoa(10000 + f.nextID), "key": key})
+
+	case r.Method == http.MethodGet && r.URL.Path == "/rest/api/3/search/jql":
+		f.lastJQL = r.URL.Query().Get("jql")
+		var matches []map[string]interface{}
+		for key, fields := range f.issues {
+			if f.matches(fields, f.lastJQL) {
+				matches = append(matches, map[string]interface{}{"key": key, "fields": fields})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"issues": matches})
+
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/rest/api/3/issue/"):
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/3/issue/")
+		fields, ok := f.issues[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{"errorMessages": []string{"Issue does not exist"}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"key": key,
+			"fields": map[string]interface{}{
+				"summary": fields["summary"],
+				"status":  map[string]string{"name": "To Do"},
+			},
+		})
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// matches implements just enough JQL for the adapter's idempotency queries.
+func (f *fakeJira) matches(fields map[string]interface{}, jql string) bool {
+	for _, label := range toStrings(fields["labels"]) {
+		if strings.Contains(jql, `labels = "`+label+`"`) {
+			return true
+		}
+	}
+	if value, ok := fields["customfield_10050"].(string); ok {
+		// Fuzzy, like real text search: prefix match only
+		return strings.Contains(jql, `cf[10050] ~ "`+value[:len(value)-1])
+	}
+	return false
+}
+
+func toStrings(v interface{}) []string {
+	items, _ := v.([]interface{})
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func testTicket() types.Ticket {
+	return types.Ticket{
+		Title:       "Checkout button unresponsive",
+		Description: "Summary\n\nUsers clicked pay repeatedly.\nNothing happened.",
+		Labels:      []string{"user-behavior-detection", "bug"},
+		Metadata:    map[string]string{"priority": "P2", "route": "/checkout"},
+	}
+}
+
+func TestJiraAdapter_CreateTicketIsIdempotentByLabel(t *testing.T) {
+	fake := newFakeJira()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	jira := NewJiraAdapterWithConfig(JiraConfig{
+		BaseURL:    server.URL,
+		ProjectKey: "HAWK",
+		Email:      "bot@example.com",
+		APIToken:   "secret",
+	})
+	ctx := context.Background()
+	incident := types.Incident{IncidentID: "inc-1"}
+
+	first, err := jira.CreateTicket(ctx, incident, testTicket(), "incident_inc-1")
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	second, err := jira.CreateTicket(ctx, incident, testTicket(), "incident_inc-1")
+	if err != nil {
+		t.Fatalf("CreateTicket (repeat): %v", err)
+	}
+
+	if first != "HAWK-1" || second != first {
+		t.Errorf("ticket IDs = %q, %q; want HAWK-1 twice", first, second)
+	}
+	if fake.created != 1 {
+		t.Errorf("created %d issues, want 1", fake.created)
+	}
+	if !strings.HasPrefix(fake.lastAuth, "Basic ") {
+		t.Errorf("Authorization = %q, want Basic auth", fake.lastAuth)
+	}
+
+	fields := fake.issues["HAWK-1"]
+	if priority := fields["priority"].(map[string]interface{})["name"]; priority != "High" {
+		t.Errorf("priority = %v, want High", priority)
+	}
+	if _, leaked := fields["route"]; leaked {
+		t.Error("non-Jira metadata must not be sent as fields")
+	}
+	if fields["description"].(map[string]interface{})["type"] != "doc" {
+		t.Error("description should be an ADF document")
+	}
+}
+
+func TestJiraAdapter_CustomFieldIdempotencyAndBearer(t *testing.T) {
+	fake := newFakeJira()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	jira := NewJiraAdapterWithConfig(JiraConfig{
+		BaseURL:          server.URL,
+		ProjectKey:       "HAWK",
+		APIToken:         "pat",
+		IdempotencyField: "customfield_10050",
+	})
+	ctx := context.Background()
+
+	first, err := jira.CreateTicket(ctx, types.Incident{}, testTicket(), "issue_abc")
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	// "issue_abd" fuzzily matches "issue_abc" in the fake; exact check must reject it
+	second, err := jira.CreateTicket(ctx, types.Incident{}, testTicket(), "issue_abd")
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+
+	if first == second {
+		t.Errorf("different keys must not share a ticket (%s)", first)
+	}
+	if fake.lastAuth != "Bearer pat" {
+		t.Errorf("Authorization = %q, want Bearer pat", fake.lastAuth)
+	}
+	if !strings.Contains(fake.lastJQL, `project = "HAWK"`) {
+		t.Errorf("JQL %q should be scoped to the project", fake.lastJQL)
+	}
+}
+
+func TestJiraAdapter_GetTicket(t *testing.T) {
+	fake := newFakeJira()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	jira := NewJiraAdapter(server.URL, "pat", "HAWK")
+	ctx := context.Background()
+	key, _ := jira.CreateTicket(ctx, types.Incident{}, testTicket(), "incident_x")
+
+	info, err := jira.GetTicket(ctx, key)
+	if err != nil {
+		t.Fatalf("GetTicket: %v", err)
+	}
+	if info.Title != "Checkout button unresponsive" || info.Status != "To Do" || info.URL != server.URL+"/browse/"+key {
+		t.Errorf("GetTicket = %+v", info)
+	}
+
+	_, err = jira.GetTicket(ctx, "HAWK-999")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound || IsRetryable(err) {
+		t.Errorf("missing issue: err = %v, want permanent 404", err)
+	}
+}
+
+func TestJiraAdapter_ErrorClassification(t *testing.T) {
+	tests := []struct {
+		status    int
+		retryable bool
+	}{
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusServiceUnavailable, true},
+	}
+
+	for _, tt := range tests {
+		fake := newFakeJira()
+		fake.failCreate = tt.status
+		server := httptest.NewServer(fake)
+
+		jira := NewJiraAdapter(server.URL, "pat", "HAWK")
+		_, err := jira.CreateTicket(context.Background(), types.Incident{}, testTicket(), "incident_x")
+		server.Close()
+
+		if err == nil {
+			t.Fatalf("status %d: expected error", tt.status)
+		}
+		if IsRetryable(err) != tt.retryable {
+			t.Errorf("status %d: IsRetryable = %v, want %v (%v)", tt.status, IsRetryable(err), tt.retryable, err)
+		}
+		if !strings.Contains(err.Error(), "priority") {
+			t.Errorf("status %d: error %q should include Jira's field errors", tt.status, err)
+		}
+	}
+
+	// Unreachable server → retryable
+	jira := NewJiraAdapter("http://127.0.0.1:1", "pat", "HAWK")
+	if _, err := jira.CreateTicket(context.Background(), types.Incident{}, testTicket(), "k"); !IsRetryable(err) {
+		t.Errorf("network error should be retryable, got %v", err)
+	}
+}
+
+func TestJiraAdapter_ReopenTicket(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/transitions"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"transitions": []map[string]interface{}{
+				{"id": "31", "name": "Done", "to": map[string]string{"name": "Done"}},
+				{"id": "41", "name": "Back to work", "to": map[string]string{"name": "To Do"}},
+			}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/transitions"):
+			var body struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Transition.ID != "41" {
+				t.Errorf("transition = %s, want 41 (to To Do)", body.Transition.ID)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	jira := NewJiraAdapter(server.URL, "pat", "HAWK")
+	if err := jira.ReopenTicket(context.Background(), "HAWK-1", "recurred"); err != nil {
+		t.Fatalf("ReopenTicket: %v", err)
+	}
+	want := "POST /rest/api/3/issue/HAWK-1/comment,GET /rest/api/3/issue/HAWK-1/transitions,POST /rest/api/3/issue/HAWK-1/transitions"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("calls = %s", got)
+	}
+}
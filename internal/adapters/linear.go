@@ -1,37 +1,91 @@
 /**
  * Linear Adapter
- * 
+ *
  * Author: Frank Miller (Team Beta)
- * Responsibility: Linear integration
+ * Responsibility: Linear integration (GraphQL API)
+ *
+ * Idempotency:
+ * - Attachment mode (AttachmentBaseURL set): every issue gets an attachment
+ *   whose URL is AttachmentBaseURL + idempotency key; lookups use
+ *   attachmentsForURL
+ * - Label mode (default): the idempotency key is written into the issue
+ *   description and lookups search the team's issues (restricted to the
+ *   configured labels) for it
  */
 
 package adapters
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/your-org/frustration-engine/internal/types"
 )
 
+// DefaultLinearAPIURL is Linear's GraphQL endpoint
+const DefaultLinearAPIURL = "https://api.linear.app/graphql"
+
+// LinearConfig configures the Linear adapter
+type LinearConfig struct {
+	APIURL            string // Default DefaultLinearAPIURL
+	APIKey            string
+	TeamID            string
+	ProjectID         string   // Optional
+	LabelIDs          []string // Optional, applied to every issue
+	AttachmentBaseURL string   // Optional, enables attachment-based idempotency
+
+	// Priority maps a P0-P4 level to Linear's priority scale. Wire it to
+	// exporter.PriorityMapper.LinearPriority; nil = issues get no priority.
+	Priority func(priority string) int
+	Timeout  time.Duration
+}
+
 // LinearAdapter implements Linear integration
 type LinearAdapter struct {
-	apiURL     string
-	apiKey     string
-	teamID     string
-	client     *http.Client
+	apiURL            string
+	apiKey            string
+	teamID            string
+	projectID         string
+	labelIDs          []string
+	attachmentBaseURL string
+	priority          func(priority string) int
+	client            *http.Client
 }
 
-// NewLinearAdapter creates a new Linear adapter
+// NewLinearAdapter creates a new Linear adapter with label-based idempotency
 func NewLinearAdapter(apiURL, apiKey, teamID string) *LinearAdapter {
+	return NewLinearAdapterWithConfig(LinearConfig{
+		APIURL: apiURL,
+		APIKey: apiKey,
+		TeamID: teamID,
+	})
+}
+
+// NewLinearAdapterWithConfig creates a new Linear adapter with custom config
+func NewLinearAdapterWithConfig(config LinearConfig) *LinearAdapter {
+	if config.APIURL == "" {
+		config.APIURL = DefaultLinearAPIURL
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
 	return &LinearAdapter{
-		apiURL: apiURL,
-		apiKey: apiKey,
-		teamID: teamID,
+		apiURL:            config.APIURL,
+		apiKey:            config.APIKey,
+		teamID:            config.TeamID,
+		projectID:         config.ProjectID,
+		labelIDs:          config.LabelIDs,
+		attachmentBaseURL: config.AttachmentBaseURL,
+		priority:          config.Priority,
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: config.Timeout,
 		},
 	}
 }
@@ -41,62 +95,161 @@ func (l *LinearAdapter) Name() string {
 	return "linear"
 }
 
-// CreateTicket creates a ticket in Linear
-func (l *LinearAdapter) CreateTicket(ctx context.Context, incident types.Incident, ticket types.Ticket, idempotencyKey string) (string, error) {
-	// Check if ticket already exists (idempotency)
-	existingTicketID, err := l.findTicketByKey(ctx, idempotencyKey)
-	if err == nil && existingTicketID != "" {
-		return existingTicketID, nil // Ticket already exists
-	}
+const linearCreateIssueMutation = `
+	mutation CreateIssue($input: IssueCreateInput!) {
+		issueCreate(input: $input) {
+			success
+			issue {
+				id
+				identifier
+			}
+		}
+	}`
 
-	// Build Linear issue
-	issue := l.buildLinearIssue(ticket, incident)
+const linearCreateAttachmentMutation = `
+	mutation CreateAttachment($input: AttachmentCreateInput!) {
+		attachmentCreate(input: $input) {
+			success
+		}
+	}`
 
-	// GraphQL mutation to create issue
-	query := `
-		mutation CreateIssue($input: IssueCreateInput!) {
-			issueCreate(input: $input) {
-				success
+const linearAttachmentsForURLQuery = `
+	query AttachmentsForURL($url: String!) {
+		attachmentsForURL(url: $url) {
+			nodes {
 				issue {
-					id
 					identifier
 				}
 			}
 		}
-	`
+	}`
+
+const linearSearchIssuesQuery = `
+	query FindIssues($filter: IssueFilter) {
+		issues(first: 10, filter: $filter) {
+			nodes {
+				identifier
+				description
+			}
+		}
+	}`
+
+const linearGetIssueQuery = `
+	query GetIssue($id: String!) {
+		issue(id: $id) {
+			identifier
+			title
+			url
+			state {
+				name
+				type
+			}
+			completedAt
+			canceledAt
+		}
+	}`
 
-	variables := map[string]interface{}{
-		"input": issue,
+const linearCreateCommentMutation = `
+	mutation CreateComment($input: CommentCreateInput!) {
+		commentCreate(input: $input) {
+			success
+		}
+	}`
+
+const linearUnstartedStatesQuery = `
+	query UnstartedStates($id: String!) {
+		issue(id: $id) {
+			id
+			team {
+				states(filter: { type: { eq: "unstarted" } }) {
+					nodes {
+						id
+						position
+					}
+				}
+			}
+		}
+	}`
+
+const linearUpdateIssueMutation = `
+	mutation UpdateIssue($id: String!, $input: IssueUpdateInput!) {
+		issueUpdate(id: $id, input: $input) {
+			success
+		}
+	}`
+
+// CreateTicket creates a ticket in Linear
+func (l *LinearAdapter) CreateTicket(ctx context.Context, incident types.Incident, ticket types.Ticket, idempotencyKey string) (string, error) {
+	// Check if ticket already exists (idempotency)
+	existingTicketID, err := l.findTicketByKey(ctx, idempotencyKey)
+	if err != nil {
+		return "", err // Cannot prove the ticket doesn't exist → don't risk a duplicate
+	}
+	if existingTicketID != "" {
+		return existingTicketID, nil // Ticket already exists
 	}
 
-	reqBody := map[string]interface{}{
-		"query":     query,
-		"variables": variables,
+	// Build Linear issue
+	issue := l.buildLinearIssue(ticket, incident, idempotencyKey)
+
+	var created struct {
+		IssueCreate struct {
+			Success bool `json:"success"`
+			Issue   struct {
+				ID         string `json:"id"`
+				Identifier string `json:"identifier"`
+			} `json:"issue"`
+		} `json:"issueCreate"`
+	}
+	if err := l.do(ctx, linearCreateIssueMutation, map[string]interface{}{"input": issue}, &created); err != nil {
+		return "", err
+	}
+	if !created.IssueCreate.Success || created.IssueCreate.Issue.Identifier == "" {
+		return "", permanentError(l.Name(), fmt.Errorf("issueCreate was not successful"))
 	}
 
-	// TODO: Implement actual GraphQL request
-	_ = reqBody
+	if l.attachmentBaseURL != "" {
+		attachment := map[string]interface{}{
+			"issueId":  created.IssueCreate.Issue.ID,
+			"url":      l.attachmentURL(idempotencyKey),
+			"title":    "HawkEye",
+			"subtitle": idempotencyKey,
+		}
+		var result struct {
+			AttachmentCreate struct {
+				Success bool `json:"success"`
+			} `json:"attachmentCreate"`
+		}
+		if err := l.do(ctx, linearCreateAttachmentMutation, map[string]interface{}{"input": attachment}, &result); err != nil || !result.AttachmentCreate.Success {
+			// The issue exists; failing here would file a duplicate on retry
+			log.Printf("[Linear Adapter] Failed to attach idempotency key to %s: %v", created.IssueCreate.Issue.Identifier, err)
+		}
+	}
 
-	return "", fmt.Errorf("Linear integration not yet implemented")
+	return created.IssueCreate.Issue.Identifier, nil
 }
 
 // buildLinearIssue builds Linear issue structure
-func (l *LinearAdapter) buildLinearIssue(ticket types.Ticket, incident types.Incident) map[string]interface{} {
-	// Get priority from ticket metadata (already mapped by PriorityMapper)
-	priority := ticket.Metadata["priority"]
-	linearPriority := mapPriorityToLinear(priority)
+func (l *LinearAdapter) buildLinearIssue(ticket types.Ticket, incident types.Incident, idempotencyKey string) map[string]interface{} {
+	description := ticket.Description
+	if l.attachmentBaseURL == "" {
+		description += "\n\n---\nHawkEye key: " + idempotencyKey
+	}
 
 	issue := map[string]interface{}{
-		"teamId":     l.teamID,
-		"title":      ticket.Title,
-		"description": ticket.Description,
-		"priority":   linearPriority,
-		"labels":     ticket.Labels,
+		"teamId":      l.teamID,
+		"title":       ticket.Title,
+		"description": description,
 	}
-
-	// Add metadata as custom fields
-	for key, value := range ticket.Metadata {
-		issue[key] = value
+	if l.priority != nil {
+		// The ticket's P0-P4 level, set by the exporter's PriorityMapper
+		issue["priority"] = l.priority(ticket.Metadata["priority"])
+	}
+	if l.projectID != "" {
+		issue["projectId"] = l.projectID
+	}
+	if len(l.labelIDs) > 0 {
+		issue["labelIds"] = l.labelIDs
 	}
 
 	return issue
@@ -104,30 +257,264 @@ func (l *LinearAdapter) buildLinearIssue(ticket types.Ticket, incident types.Inc
 
 // findTicketByKey finds ticket by idempotency key
 func (l *LinearAdapter) findTicketByKey(ctx context.Context, idempotencyKey string) (string, error) {
-	// TODO: GraphQL query to find issue by custom field matching idempotency key
+	if l.attachmentBaseURL != "" {
+		var result struct {
+			AttachmentsForURL struct {
+				Nodes []struct {
+					Issue struct {
+						Identifier string `json:"identifier"`
+					} `json:"issue"`
+				} `json:"nodes"`
+			} `json:"attachmentsForURL"`
+		}
+		if err := l.do(ctx, linearAttachmentsForURLQuery, map[string]interface{}{"url": l.attachmentURL(idempotencyKey)}, &result); err != nil {
+			return "", err
+		}
+		for _, node := range result.AttachmentsForURL.Nodes {
+			if node.Issue.Identifier != "" {
+				return node.Issue.Identifier, nil
+			}
+		}
+		return "", nil
+	}
+
+	filter := map[string]interface{}{
+		"team":        map[string]interface{}{"id": map[string]string{"eq": l.teamID}},
+		"description": map[string]string{"contains": idempotencyKey},
+	}
+	if len(l.labelIDs) > 0 {
+		filter["labels"] = map[string]interface{}{"some": map[string]interface{}{"id": map[string]interface{}{"in": l.labelIDs}}}
+	}
+
+	var result struct {
+		Issues struct {
+			Nodes []struct {
+				Identifier  string `json:"identifier"`
+				Description string `json:"description"`
+			} `json:"nodes"`
+		} `json:"issues"`
+	}
+	if err := l.do(ctx, linearSearchIssuesQuery, map[string]interface{}{"filter": filter}, &result); err != nil {
+		return "", err
+	}
+	// "contains" would also match incident_12 inside incident_123; confirm the exact key line
+	marker := "HawkEye key: " + idempotencyKey
+	for _, node := range result.Issues.Nodes {
+		for _, line := range strings.Split(node.Description, "\n") {
+			if strings.TrimSpace(line) == marker {
+				return node.Identifier, nil
+			}
+		}
+	}
 	return "", nil
 }
 
 // GetTicket retrieves ticket by ID
 func (l *LinearAdapter) GetTicket(ctx context.Context, ticketID string) (*TicketInfo, error) {
-	// TODO: GraphQL query to get issue by ID
-	return nil, fmt.Errorf("not implemented")
+	var result struct {
+		Issue *linearIssue `json:"issue"`
+	}
+	if err := l.do(ctx, linearGetIssueQuery, map[string]interface{}{"id": ticketID}, &result); err != nil {
+		return nil, err
+	}
+	if result.Issue == nil {
+		return nil, permanentError(l.Name(), fmt.Errorf("issue %s not found", ticketID))
+	}
+	return result.Issue.ticketInfo(), nil
+}
+
+// linearIssue is the part of a Linear issue the sync reads, from the API or
+// a webhook's data
+type linearIssue struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	State      struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"state"`
+	CompletedAt *time.Time `json:"completedAt"`
+	CanceledAt  *time.Time `json:"canceledAt"`
+	Labels      []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+func (i linearIssue) ticketInfo() *TicketInfo {
+	info := &TicketInfo{
+		ID:         i.Identifier,
+		Title:      i.Title,
+		Status:     i.State.Name,
+		Resolution: i.State.Type,
+		URL:        i.URL,
+	}
+	switch {
+	case i.CompletedAt != nil:
+		info.ResolvedAt = *i.CompletedAt
+	case i.CanceledAt != nil:
+		info.ResolvedAt = *i.CanceledAt
+	}
+	for _, label := range i.Labels {
+		info.Labels = append(info.Labels, label.Name)
+	}
+	return info
+}
+
+// ParseTicketWebhook decodes an Issue webhook event, verified with the
+// Linear-Signature header and rejected when its webhookTimestamp is stale.
+// Other resource types are ignored
+func (l *LinearAdapter) ParseTicketWebhook(header http.Header, body []byte, secret string) (*TicketInfo, error) {
+	if err := verifyHexSignature(secret, body, header.Get("Linear-Signature")); err != nil {
+		return nil, err
+	}
+	var event struct {
+		Type             string       `json:"type"`
+		Data             *linearIssue `json:"data"`
+		WebhookTimestamp int64        `json:"webhookTimestamp"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("decode linear webhook event: %w", err)
+	}
+	if age := time.Since(time.UnixMilli(event.WebhookTimestamp)); age > ticketWebhookTolerance || age < -ticketWebhookTolerance {
+		return nil, fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+	}
+	if event.Type != "Issue" || event.Data == nil {
+		return nil, nil
+	}
+	return event.Data.ticketInfo(), nil
+}
+
+// ReopenTicket comments on an issue and moves it back to its team's first
+// unstarted state (by workflow position; the API returns states unordered)
+func (l *LinearAdapter) ReopenTicket(ctx context.Context, ticketID, comment string) error {
+	var lookup struct {
+		Issue *struct {
+			ID   string `json:"id"`
+			Team struct {
+				States struct {
+					Nodes []struct {
+						ID       string  `json:"id"`
+						Position float64 `json:"position"`
+					} `json:"nodes"`
+				} `json:"states"`
+			} `json:"team"`
+		} `json:"issue"`
+	}
+	if err := l.do(ctx, linearUnstartedStatesQuery, map[string]interface{}{"id": ticketID}, &lookup); err != nil {
+		return err
+	}
+	if lookup.Issue == nil {
+		return permanentError(l.Name(), fmt.Errorf("issue %s not found", ticketID))
+	}
+
+	var commented struct {
+		CommentCreate struct {
+			Success bool `json:"success"`
+		} `json:"commentCreate"`
+	}
+	input := map[string]interface{}{"issueId": lookup.Issue.ID, "body": comment}
+	if err := l.do(ctx, linearCreateCommentMutation, map[string]interface{}{"input": input}, &commented); err != nil {
+		return err
+	}
+	if !commented.CommentCreate.Success {
+		return permanentError(l.Name(), fmt.Errorf("commentCreate was not successful"))
+	}
+
+	states := lookup.Issue.Team.States.Nodes
+	if len(states) == 0 {
+		log.Printf("[Linear Adapter] No unstarted state to reopen %s into; left a comment only", ticketID)
+		return nil
+	}
+	var updated struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueUpdate"`
+	}
+	first := states[0]
+	for _, state := range states[1:] {
+		if state.Position < first.Position {
+			first = state
+		}
+	}
+	variables := map[string]interface{}{"id": lookup.Issue.ID, "input": map[string]interface{}{"stateId": first.ID}}
+	if err := l.do(ctx, linearUpdateIssueMutation, variables, &updated); err != nil {
+		return err
+	}
+	if !updated.IssueUpdate.Success {
+		return permanentError(l.Name(), fmt.Errorf("issueUpdate was not successful"))
+	}
+	return nil
+}
+
+// do executes a GraphQL operation and decodes its data into out
+func (l *LinearAdapter) do(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return permanentError(l.Name(), fmt.Errorf("failed to marshal request: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.apiURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return permanentError(l.Name(), fmt.Errorf("failed to create request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", l.apiKey)
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("linear: %w: %v", ErrRetryable, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1*1024*1024)) // 1MB limit
+	if err != nil {
+		return fmt.Errorf("linear: %w: failed to read response: %v", ErrRetryable, err)
+	}
+
+	var payload struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message    string `json:"message"`
+			Extensions struct {
+				Code                   string `json:"code"`
+				UserPresentableMessage string `json:"userPresentableMessage"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}
+	decodeErr := json.Unmarshal(respBody, &payload)
+
+	// GraphQL errors carry the useful detail even on non-2xx responses
+	if decodeErr == nil && len(payload.Errors) > 0 {
+		first := payload.Errors[0]
+		message := first.Message
+		if first.Extensions.UserPresentableMessage != "" {
+			message = first.Extensions.UserPresentableMessage
+		}
+		code := first.Extensions.Code
+		if code == "" && isRetryableStatus(resp.StatusCode) {
+			code = "INTERNAL_SERVER_ERROR"
+		}
+		return &GraphQLError{System: l.Name(), Code: code, Message: message}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newHTTPError(l.Name(), resp, strings.TrimSpace(string(respBody)))
+	}
+	if decodeErr != nil {
+		return permanentError(l.Name(), fmt.Errorf("failed to decode response: %w", decodeErr))
+	}
+	if out == nil || len(payload.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(payload.Data, out); err != nil {
+		return permanentError(l.Name(), fmt.Errorf("failed to decode data: %w", err))
+	}
+	return nil
 }
 
-// mapPriorityToLinear maps priority level to Linear priority (0-4 scale)
-func mapPriorityToLinear(priority string) int {
-	switch priority {
-	case "P0":
-		return 4 // Urgent
-	case "P1":
-		return 3 // High
-	case "P2":
-		return 2 // Medium
-	case "P3":
-		return 1 // Low
-	case "P4":
-		return 0 // Backlog
-	default:
-		return 2
-	}
-}
\ No newline at end of file
+// attachmentURL returns the idempotency attachment URL for a key
+func (l *LinearAdapter) attachmentURL(idempotencyKey string) string {
+	return strings.TrimRight(l.attachmentBaseURL, "/") + "/" + idempotencyKey
+}
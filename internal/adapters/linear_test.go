@@ -0,0 +1,257 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// linearStub is a minimal Linear GraphQL server that dispatches on the
+// operation name.
+type linearStub struct {
+	mu          sync.Mutex
+	issues      map[string]map[string]interface{} // identifier → issueCreate input
+	attachments map[string]string                 // url → identifier
+	created     int
+	lastAuth    string
+	lastFilter  map[string]interface{}
+	errorCode   string // extensions.code to return for every request, "" = succeed
+	movedTo     string // stateId of the last issueUpdate
+}
+
+func newLinearStub() *linearStub {
+	return &linearStub{issues: make(map[string]map[string]interface{}), attachments: make(map[string]string)}
+}
+
+func (s *linearStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastAuth = r.Header.Get("Authorization")
+
+	var req struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	if s.errorCode != "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"errors": []map[string]interface{}{{
+				"message":    "stub error",
+				"extensions": map[string]string{"code": s.errorCode, "userPresentableMessage": "Team not found"},
+			}},
+		})
+		return
+	}
+
+	var data interface{}
+	switch {
+	case strings.Contains(req.Query, "mutation CreateIssue"):
+		s.created++
+		identifier := "ENG-" + strconv.Itoa(s.created)
+		s.issues[identifier] = req.Variables["input"].(map[string]interface{})
+		data = map[string]interface{}{"issueCreate": map[string]interface{}{
+			"success": true,
+			"issue":   map[string]string{"id": "uuid-" + identifier, "identifier": identifier},
+		}}
+
+	case strings.Contains(req.Query, "mutation CreateAttachment"):
+		input := req.Variables["input"].(map[string]interface{})
+		s.attachments[input["url"].(string)] = strings.TrimPrefix(input["issueId"].(string), "uuid-")
+		data = map[string]interface{}{"attachmentCreate": map[string]bool{"success": true}}
+
+	case strings.Contains(req.Query, "query AttachmentsForURL"):
+		nodes := []interface{}{}
+		if identifier, ok := s.attachments[req.Variables["url"].(string)]; ok {
+			nodes = append(nodes, map[string]interface{}{"issue": map[string]string{"identifier": identifier}})
+		}
+		data = map[string]interface{}{"attachmentsForURL": map[string]interface{}{"nodes": nodes}}
+
+	case strings.Contains(req.Query, "query FindIssues"):
+		s.lastFilter = req.Variables["filter"].(map[string]interface{})
+		contains := s.lastFilter["description"].(map[string]interface{})["contains"].(string)
+		nodes := []interface{}{}
+		for identifier, input := range s.issues {
+			if description := input["description"].(string); strings.Contains(description, contains) {
+				nodes = append(nodes, map[string]string{"identifier": identifier, "description": description})
+			}
+		}
+		data = map[string]interface{}{"issues": map[string]interface{}{"nodes": nodes}}
+
+	case strings.Contains(req.Query, "mutation CreateComment"):
+		data = map[string]interface{}{"commentCreate": map[string]bool{"success": true}}
+
+	case strings.Contains(req.Query, "query UnstartedStates"):
+		// Unordered, like the API
+		data = map[string]interface{}{"issue": map[string]interface{}{
+			"id": "uuid-" + req.Variables["id"].(string),
+			"team": map[string]interface{}{"states": map[string]interface{}{"nodes": []map[string]interface{}{
+				{"id": "state-triage-later", "position": 3.5},
+				{"id": "state-todo", "position": 1},
+				{"id": "state-ready", "position": 2},
+			}}},
+		}}
+
+	case strings.Contains(req.Query, "mutation UpdateIssue"):
+		s.movedTo = req.Variables["input"].(map[string]interface{})["stateId"].(string)
+		data = map[string]interface{}{"issueUpdate": map[string]bool{"success": true}}
+
+	case strings.Contains(req.Query, "query GetIssue"):
+		id := req.Variables["id"].(string)
+		input, ok := s.issues[id]
+		if !ok {
+			data = map[string]interface{}{"issue": nil}
+			break
+		}
+		data = map[string]interface{}{"issue": map[string]interface{}{
+			"identifier": id,
+			"title":      input["title"],
+			"url":        "https://linear.app/acme/issue/" + id,
+			"state":      map[string]string{"name": "Todo"},
+		}}
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func TestLinearAdapter_LabelSearchIdempotency(t *testing.T) {
+	stub := newLinearStub()
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	linear := NewLinearAdapterWithConfig(LinearConfig{
+		APIURL:    server.URL,
+		APIKey:    "lin_api_key",
+		TeamID:    "team-1",
+		ProjectID: "proj-1",
+		LabelIDs:  []string{"label-hawkeye"},
+	})
+	ctx := context.Background()
+
+	first, err := linear.CreateTicket(ctx, types.Incident{}, testTicket(), "incident_12")
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	again, _ := linear.CreateTicket(ctx, types.Incident{}, testTicket(), "incident_12")
+	// incident_1 is a substring of incident_12 but must not match it
+	other, _ := linear.CreateTicket(ctx, types.Incident{}, testTicket(), "incident_1")
+
+	if first != "ENG-1" || again != first {
+		t.Errorf("ticket IDs = %q, %q; want ENG-1 twice", first, again)
+	}
+	if other == first {
+		t.Errorf("incident_1 must not reuse the ticket for incident_12")
+	}
+	if stub.lastAuth != "lin_api_key" {
+		t.Errorf("Authorization = %q, want the API key", stub.lastAuth)
+	}
+	if _, ok := stub.lastFilter["labels"]; !ok {
+		t.Error("search should be restricted to the configured labels")
+	}
+
+	input := stub.issues["ENG-1"]
+	if _, ok := input["priority"]; ok {
+		t.Errorf("priority = %v without a priority mapper, want none", input["priority"])
+	}
+	if input["projectId"] != "proj-1" || input["teamId"] != "team-1" {
+		t.Errorf("team/project not set: %v", input)
+	}
+	if _, leaked := input["route"]; leaked {
+		t.Error("metadata must not be sent as issue fields")
+	}
+}
+
+func TestLinearAdapter_AttachmentIdempotencyAndGetTicket(t *testing.T) {
+	stub := newLinearStub()
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	linear := NewLinearAdapterWithConfig(LinearConfig{
+		APIURL:            server.URL,
+		APIKey:            "key",
+		TeamID:            "team-1",
+		AttachmentBaseURL: "https://hawkeye.example.com/issues/",
+		Priority:          func(string) int { return 1 },
+	})
+	ctx := context.Background()
+
+	first, err := linear.CreateTicket(ctx, types.Incident{}, testTicket(), "issue_abc")
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	second, _ := linear.CreateTicket(ctx, types.Incident{}, testTicket(), "issue_abc")
+	if second != first || stub.created != 1 {
+		t.Errorf("expected one issue, got %q/%q (%d created)", first, second, stub.created)
+	}
+	if _, ok := stub.attachments["https://hawkeye.example.com/issues/issue_abc"]; !ok {
+		t.Errorf("attachment not created: %v", stub.attachments)
+	}
+	if stub.issues[first]["priority"] != float64(1) {
+		t.Errorf("configured priority mapper not used")
+	}
+
+	info, err := linear.GetTicket(ctx, first)
+	if err != nil {
+		t.Fatalf("GetTicket: %v", err)
+	}
+	if info.Status != "Todo" || info.Title != "Checkout button unresponsive" {
+		t.Errorf("GetTicket = %+v", info)
+	}
+	if _, err := linear.GetTicket(ctx, "ENG-404"); err == nil || IsRetryable(err) {
+		t.Errorf("missing issue: err = %v, want permanent error", err)
+	}
+}
+
+func TestLinearAdapter_ErrorExtensions(t *testing.T) {
+	tests := []struct {
+		code      string
+		retryable bool
+	}{
+		{"RATELIMITED", true},
+		{"AUTHENTICATION_ERROR", false},
+		{"INVALID_INPUT", false},
+	}
+
+	for _, tt := range tests {
+		stub := newLinearStub()
+		stub.errorCode = tt.code
+		server := httptest.NewServer(stub)
+
+		linear := NewLinearAdapter(server.URL, "key", "team-1")
+		_, err := linear.CreateTicket(context.Background(), types.Incident{}, testTicket(), "incident_x")
+		server.Close()
+
+		gqlErr, ok := err.(*GraphQLError)
+		if !ok {
+			t.Fatalf("%s: err = %v, want *GraphQLError", tt.code, err)
+		}
+		if gqlErr.Code != tt.code || gqlErr.Message != "Team not found" {
+			t.Errorf("%s: parsed %+v", tt.code, gqlErr)
+		}
+		if IsRetryable(err) != tt.retryable {
+			t.Errorf("%s: IsRetryable = %v, want %v", tt.code, IsRetryable(err), tt.retryable)
+		}
+	}
+}
+
+func TestLinearAdapter_ReopenTicketUsesFirstUnstartedState(t *testing.T) {
+	stub := newLinearStub()
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	linear := NewLinearAdapter(server.URL, "key", "team-1")
+	if err := linear.ReopenTicket(context.Background(), "ENG-1", "Regressed"); err != nil {
+		t.Fatalf("ReopenTicket: %v", err)
+	}
+	if stub.movedTo != "state-todo" {
+		t.Errorf("reopened into %q, want the lowest-position unstarted state", stub.movedTo)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 
 	"github.com/your-org/frustration-engine/internal/types"
 )
@@ -43,3 +44,8 @@ func (a *NoOpAdapter) CreateTicket(ctx context.Context, incident types.Incident,
 func (a *NoOpAdapter) GetTicket(ctx context.Context, ticketID string) (*TicketInfo, error) {
 	return nil, fmt.Errorf("noop adapter does not support GetTicket")
 }
+
+// ParseTicketWebhook accepts HawkEye-signed ticket status changes
+func (a *NoOpAdapter) ParseTicketWebhook(header http.Header, body []byte, secret string) (*TicketInfo, error) {
+	return parseSignedTicketStatus(header, body, secret)
+}
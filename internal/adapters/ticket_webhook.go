@@ -0,0 +1,83 @@
+/**
+ * Ticket Webhooks
+ *
+ * Responsibility: Verify and decode ticket changes pushed by ticket systems
+ *
+ * Rules:
+ * - Every delivery is authenticated with the ticket system's own HMAC-SHA256
+ *   signature scheme before its body is trusted
+ * - Only the system's native payload is accepted; events that do not
+ *   describe a ticket (pings, comments, other resources) are ignored
+ * - Systems without native webhooks (generic webhook, noop) accept a
+ *   HawkEye-signed TicketStatus body (see webhook.Verify)
+ */
+
+package adapters
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/webhook"
+)
+
+// ErrInvalidSignature marks ticket webhooks that are unsigned or whose
+// signature does not match the configured secret
+var ErrInvalidSignature = errors.New("invalid ticket webhook signature")
+
+// ticketWebhookTolerance bounds the age of deliveries that carry a timestamp
+const ticketWebhookTolerance = 5 * time.Minute
+
+// TicketStatus is the HawkEye-signed ticket change accepted by adapters
+// whose ticket system has no native webhooks
+type TicketStatus struct {
+	TicketID   string    `json:"ticketId"`
+	Status     string    `json:"status"`
+	Resolution string    `json:"resolution,omitempty"`
+	Labels     []string  `json:"labels,omitempty"`
+	ResolvedAt time.Time `json:"resolvedAt,omitempty"`
+}
+
+// verifyHexSignature checks a hex HMAC-SHA256 of body, optionally behind a
+// "sha256=" prefix as GitHub and Jira send it
+func verifyHexSignature(secret string, body []byte, signature string) error {
+	signature = strings.TrimPrefix(signature, "sha256=")
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return fmt.Errorf("%w: missing or malformed signature", ErrInvalidSignature)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// parseSignedTicketStatus decodes a HawkEye-signed TicketStatus body
+func parseSignedTicketStatus(header http.Header, body []byte, secret string) (*TicketInfo, error) {
+	if err := webhook.Verify(secret, header, body, ticketWebhookTolerance); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	var status TicketStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("decode ticket status: %w", err)
+	}
+	if status.TicketID == "" || status.Status == "" {
+		return nil, fmt.Errorf("ticketId and status are required")
+	}
+	return &TicketInfo{
+		ID:         status.TicketID,
+		Status:     status.Status,
+		Resolution: status.Resolution,
+		Labels:     status.Labels,
+		ResolvedAt: status.ResolvedAt,
+	}, nil
+}
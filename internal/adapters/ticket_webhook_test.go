@@ -0,0 +1,107 @@
+package adapters
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/webhook"
+)
+
+func hexSignature(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestGitHubAdapter_ParseTicketWebhook(t *testing.T) {
+	github := newTestGitHub("http://unused")
+	body := `{"action":"closed","issue":{"number":42,"state":"closed","state_reason":"not_planned","closed_at":"2026-10-01T09:30:00Z","labels":[{"name":"bug"}]},"repository":{"full_name":"acme/web"}}`
+	header := http.Header{}
+	header.Set("X-GitHub-Event", "issues")
+	header.Set("X-Hub-Signature-256", "sha256="+hexSignature("s3cret", body))
+
+	info, err := github.ParseTicketWebhook(header, []byte(body), "s3cret")
+	if err != nil || info == nil {
+		t.Fatalf("ParseTicketWebhook = %+v, %v", info, err)
+	}
+	if info.ID != "acme/web#42" || info.Resolution != "not_planned" || len(info.Labels) != 1 ||
+		!info.ResolvedAt.Equal(time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("info = %+v", info)
+	}
+
+	if _, err := github.ParseTicketWebhook(header, []byte(body), "other"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong secret: err = %v", err)
+	}
+	header.Set("X-GitHub-Event", "ping")
+	if info, err := github.ParseTicketWebhook(header, []byte(body), "s3cret"); info != nil || err != nil {
+		t.Errorf("ping = %+v, %v; want ignored", info, err)
+	}
+}
+
+func TestJiraAdapter_ParseTicketWebhook(t *testing.T) {
+	jira := NewJiraAdapter("https://acme.atlassian.net", "pat", "HAWK")
+	body := `{"webhookEvent":"jira:issue_updated","issue":{"key":"HAWK-7","fields":{"status":{"name":"Done"},"resolution":{"name":"Won't Fix"},"resolutiondate":"2026-10-01T09:30:00.000+0000","labels":["frontend"]}}}`
+	header := http.Header{}
+	header.Set("X-Hub-Signature", "sha256="+hexSignature("s3cret", body))
+
+	info, err := jira.ParseTicketWebhook(header, []byte(body), "s3cret")
+	if err != nil || info == nil {
+		t.Fatalf("ParseTicketWebhook = %+v, %v", info, err)
+	}
+	if info.ID != "HAWK-7" || info.Resolution != "Won't Fix" ||
+		!info.ResolvedAt.Equal(time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("info = %+v", info)
+	}
+	if _, err := jira.ParseTicketWebhook(http.Header{}, []byte(body), "s3cret"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("unsigned: err = %v", err)
+	}
+}
+
+func TestLinearAdapter_ParseTicketWebhook(t *testing.T) {
+	linear := NewLinearAdapter("http://unused", "key", "team")
+	sign := func(timestamp time.Time) (http.Header, []byte) {
+		body := fmt.Sprintf(`{"action":"update","type":"Issue","data":{"identifier":"ENG-3","state":{"name":"Canceled","type":"canceled"},"canceledAt":"2026-10-01T09:30:00.000Z","labels":[{"name":"hawkeye"}]},"webhookTimestamp":%d}`, timestamp.UnixMilli())
+		header := http.Header{}
+		header.Set("Linear-Signature", hexSignature("s3cret", body))
+		return header, []byte(body)
+	}
+
+	header, body := sign(time.Now())
+	info, err := linear.ParseTicketWebhook(header, body, "s3cret")
+	if err != nil || info == nil {
+		t.Fatalf("ParseTicketWebhook = %+v, %v", info, err)
+	}
+	if info.ID != "ENG-3" || info.Resolution != "canceled" || len(info.Labels) != 1 ||
+		!info.ResolvedAt.Equal(time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("info = %+v", info)
+	}
+
+	header, body = sign(time.Now().Add(-time.Hour))
+	if _, err := linear.ParseTicketWebhook(header, body, "s3cret"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("replayed delivery: err = %v", err)
+	}
+}
+
+func TestWebhookAdapter_ParseTicketWebhook(t *testing.T) {
+	adapter := NewWebhookAdapter(nil)
+	body := []byte(`{"ticketId":"OPS-9","status":"Closed","resolution":"Cannot Reproduce"}`)
+	now := time.Now().Unix()
+	header := http.Header{}
+	header.Set(webhook.HeaderTimestamp, strconv.FormatInt(now, 10))
+	header.Set(webhook.HeaderSignature, webhook.Sign("s3cret", now, body))
+
+	info, err := adapter.ParseTicketWebhook(header, body, "s3cret")
+	if err != nil || info == nil || info.ID != "OPS-9" || info.Resolution != "Cannot Reproduce" {
+		t.Fatalf("ParseTicketWebhook = %+v, %v", info, err)
+	}
+	if _, err := adapter.ParseTicketWebhook(header, body, "other"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong secret: err = %v", err)
+	}
+}
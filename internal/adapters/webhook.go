@@ -0,0 +1,82 @@
+/**
+ * Webhook Adapter
+ *
+ * Responsibility: Export tickets to an arbitrary HTTP endpoint
+ *
+ * Sends a signed ticket.requested payload (incident + formatted ticket) via
+ * webhook.Notifier. The receiver may reply with {"ticketId": "..."} to
+ * report the ID it filed; otherwise the delivery ID is used. The delivery ID
+ * is derived from the idempotency key, so retries of the same export replace
+ * (rather than duplicate) entries in the delivery log.
+ *
+ * The receiver reports ticket changes back as a TicketStatus signed the
+ * same way (see ParseTicketWebhook).
+ */
+
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/webhook"
+)
+
+// WebhookAdapter implements export to a generic webhook
+type WebhookAdapter struct {
+	notifier *webhook.Notifier
+}
+
+// NewWebhookAdapter creates a new webhook adapter
+func NewWebhookAdapter(notifier *webhook.Notifier) *WebhookAdapter {
+	return &WebhookAdapter{notifier: notifier}
+}
+
+// Name returns adapter name
+func (w *WebhookAdapter) Name() string {
+	return "webhook"
+}
+
+// CreateTicket delivers the ticket to the webhook endpoint
+func (w *WebhookAdapter) CreateTicket(ctx context.Context, incident types.Incident, ticket types.Ticket, idempotencyKey string) (string, error) {
+	payload := webhook.NewPayload(webhook.EventTicketRequested, incident)
+	payload.DeliveryID = "ticket_" + idempotencyKey
+	payload.IdempotencyKey = idempotencyKey
+	payload.Ticket = &webhook.TicketPayload{
+		Title:       ticket.Title,
+		Description: ticket.Description,
+		Labels:      ticket.Labels,
+		Metadata:    ticket.Metadata,
+	}
+
+	resp, err := w.notifier.Send(ctx, payload)
+	if err != nil {
+		if errors.Is(err, webhook.ErrPermanent) {
+			return "", fmt.Errorf("webhook: %w: %v", ErrPermanent, err)
+		}
+		return "", fmt.Errorf("webhook: %w: %v", ErrRetryable, err)
+	}
+
+	var reply struct {
+		TicketID string `json:"ticketId"`
+	}
+	if json.Unmarshal(resp.Body, &reply) == nil && reply.TicketID != "" {
+		return reply.TicketID, nil
+	}
+	return payload.DeliveryID, nil
+}
+
+// GetTicket is not supported: webhooks are fire-and-forget
+func (w *WebhookAdapter) GetTicket(ctx context.Context, ticketID string) (*TicketInfo, error) {
+	return nil, permanentError(w.Name(), fmt.Errorf("webhook adapter does not support GetTicket"))
+}
+
+// ParseTicketWebhook accepts ticket status changes from the receiver, signed
+// the same way HawkEye signs its deliveries
+func (w *WebhookAdapter) ParseTicketWebhook(header http.Header, body []byte, secret string) (*TicketInfo, error) {
+	return parseSignedTicketStatus(header, body, secret)
+}
@@ -0,0 +1,49 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/resilience"
+	"github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/webhook"
+)
+
+func TestWebhookAdapter_CreateTicket(t *testing.T) {
+	var payload webhook.Payload
+	var idempotency string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotency = r.Header.Get(webhook.HeaderIdempotency)
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]string{"ticketId": "TRIAGE-7"})
+	}))
+	defer server.Close()
+
+	notifier := webhook.NewNotifier(webhook.Config{
+		URL:   server.URL,
+		Retry: resilience.RetryConfig{MaxRetries: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffMultiplier: 1},
+	})
+	adapter := NewWebhookAdapter(notifier)
+
+	id, err := adapter.CreateTicket(context.Background(), types.Incident{IncidentID: "inc-1"}, testTicket(), "incident_inc-1")
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	if id != "TRIAGE-7" {
+		t.Errorf("ticket ID = %q, want the receiver's ticketId", id)
+	}
+	if idempotency != "incident_inc-1" || payload.Event != webhook.EventTicketRequested || payload.Ticket == nil || payload.Ticket.Title != testTicket().Title {
+		t.Errorf("payload = %+v (Idempotency-Key %q)", payload, idempotency)
+	}
+
+	status = http.StatusBadRequest
+	if _, err := adapter.CreateTicket(context.Background(), types.Incident{}, testTicket(), "incident_x"); err == nil || IsRetryable(err) {
+		t.Errorf("400: err = %v, want permanent", err)
+	}
+}
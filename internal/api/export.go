@@ -0,0 +1,59 @@
+/**
+ * Export Write-back Handler
+ *
+ * Responsibility: Record ticket export outcomes on incidents
+ *
+ * Called by the Ticket Exporter once a ticket exists (or export has
+ * permanently failed). Only export metadata is writable
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/frustration-engine/internal/store"
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// ExportHandler handles export write-back
+type ExportHandler struct {
+	engine *store.StoreEngine
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(engine *store.StoreEngine) *ExportHandler {
+	return &ExportHandler{engine: engine}
+}
+
+// UpdateExport handles PATCH /v1/incidents/{id}/export
+func (h *ExportHandler) UpdateExport(w http.ResponseWriter, r *http.Request) {
+	incidentID := chi.URLParam(r, "id")
+
+	var req types.ExportUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	switch {
+	case req.ExportFailed:
+		err = h.engine.MarkExportFailed(r.Context(), incidentID)
+	case req.ExternalTicketID != "" && req.ExternalSystem != "":
+		err = h.engine.MarkExported(r.Context(), incidentID, req.ExternalTicketID, req.ExternalSystem)
+	default:
+		http.Error(w, "externalTicketId and externalSystem, or exportFailed, are required", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Printf("[Incident Store] Export write-back failed for %s: %v", incidentID, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -6,44 +6,165 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/your-org/frustration-engine/internal/config"
 	"github.com/your-org/frustration-engine/internal/engine"
+	"github.com/your-org/frustration-engine/internal/enrich"
+	"github.com/your-org/frustration-engine/internal/exporter"
 	hawkhttp "github.com/your-org/frustration-engine/internal/http"
 	"github.com/your-org/frustration-engine/internal/incident"
 	"github.com/your-org/frustration-engine/internal/ingest"
+	"github.com/your-org/frustration-engine/internal/ingestion"
+	"github.com/your-org/frustration-engine/internal/issue"
 	"github.com/your-org/frustration-engine/internal/metrics"
+	"github.com/your-org/frustration-engine/internal/notify"
+	"github.com/your-org/frustration-engine/internal/otlp"
+	"github.com/your-org/frustration-engine/internal/ratelimit"
+	"github.com/your-org/frustration-engine/internal/route"
 	"github.com/your-org/frustration-engine/internal/session"
 	memstorage "github.com/your-org/frustration-engine/internal/storage/memory"
 	oldtypes "github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/ufse/scoring"
+	"github.com/your-org/frustration-engine/internal/validation"
+	"github.com/your-org/frustration-engine/internal/webhook"
 	"github.com/your-org/frustration-engine/pkg/types"
 )
 
 // App is the fully wired HawkEye application.
 type App struct {
-	Server         *hawkhttp.Server
-	SessionManager *session.Manager
-	IncidentSvc    *incident.Service
-	cfg            *config.Config
-	cancel         context.CancelFunc
+	Server          *hawkhttp.Server
+	SessionManager  *session.Manager
+	IncidentSvc     *incident.Service
+	IssueSvc        *issue.Service
+	Engine          *engine.Engine
+	Notifier        *notify.Notifier  // nil when chat notifications are disabled
+	IncidentWebhook *webhook.Notifier // nil when incident webhooks are disabled
+	DeadLetters     *ingestion.DeadLetterQueue
+	RateLimits      *ratelimit.ProjectLimiter
+	Exporter        *exporter.Engine // nil when ticket export is disabled
+	TicketSyncer    *exporter.Syncer // nil when ticket export is disabled
+	cfg             *config.Config
+	cancel          context.CancelFunc
+	notifications   *notifyQueue // nil when no notifier is configured
 }
 
-// New builds the application from configuration.
-func New(cfg *config.Config) *App {
+// New builds the application from configuration. It fails when a
+// configured scorer model cannot be loaded, rather than running with
+// different scoring than configured.
+func New(cfg *config.Config) (*App, error) {
 	eventStore := memstorage.New()
 	incidentStore := memstorage.NewIncidentStore()
 	sessionMgr := session.NewManager()
 	incidentSvc := incident.NewService(incidentStore)
-	ingestHandler := ingest.NewHandler(eventStore, sessionMgr)
-	server := hawkhttp.NewServer(ingestHandler, incidentSvc, cfg.APIKey, cfg.Dev)
+	issueCfg := issue.DefaultConfig()
+	if cfg.RegressionQuietPeriod > 0 {
+		issueCfg.RegressionQuietPeriod = cfg.RegressionQuietPeriod
+	}
+	issueSvc := issue.NewService(memstorage.NewIssueStore(), issueCfg)
 
-	return &App{
-		Server:         server,
-		SessionManager: sessionMgr,
-		IncidentSvc:    incidentSvc,
-		cfg:            cfg,
+	normalizer := route.NewNormalizer()
+	if cfg.RouteTemplatesFile != "" {
+		if err := normalizer.LoadTemplatesFile(cfg.RouteTemplatesFile); err != nil {
+			log.Printf("[app] route templates not loaded, using heuristics only: %v", err)
+		}
+	}
+
+	scorer, err := scoring.NewScorer(cfg.Scorer, cfg.ScorerModel)
+	if err != nil {
+		return nil, fmt.Errorf("scorer %q: %w", cfg.Scorer, err)
+	}
+
+	var notifier *notify.Notifier
+	if cfg.NotifyConfigFile != "" {
+		notifyCfg, err := notify.LoadConfigFile(cfg.NotifyConfigFile)
+		if err == nil {
+			notifier, err = notify.New(notifyCfg)
+		}
+		if err != nil {
+			log.Printf("[app] chat notifications disabled: %v", err)
+		}
+	}
+
+	ingestHandler := ingest.NewHandler(eventStore, sessionMgr, normalizer)
+	deadLetters, err := newDeadLetterQueue(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("dead letter file: %w", err)
 	}
+	ingestHandler.SetDeadLetterQueue(deadLetters)
+	if cfg.RedactionConfigFile != "" {
+		redactor, err := validation.LoadRedactionConfigFile(cfg.RedactionConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("redaction config: %w", err)
+		}
+		ingestHandler.SetRedactor(redactor)
+	}
+	if cfg.GeoDBFile != "" {
+		geo, err := enrich.LoadGeoDB(cfg.GeoDBFile)
+		if err != nil {
+			log.Printf("[app] geo database not loaded, enriching without country: %v", err)
+		} else {
+			ingestHandler.SetEnricher(enrich.New(geo))
+		}
+	}
+	server := hawkhttp.NewServer(ingestHandler, incidentSvc, issueSvc, cfg.APIKey, cfg.Dev)
+	if cfg.AdminKey != "" && cfg.AdminKey == cfg.APIKey {
+		return nil, fmt.Errorf("--admin-key must differ from the SDK --api-key")
+	}
+	server.SetAdminKey(cfg.AdminKey)
+	projectKeys, err := parseProjectKeys(cfg.ProjectKeys, cfg.APIKey, cfg.AdminKey)
+	if err != nil {
+		return nil, fmt.Errorf("--project-keys: %w", err)
+	}
+	server.SetProjectKeys(projectKeys)
+	rateLimits, err := newRateLimiter(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("--quota-file: %w", err)
+	}
+	server.SetRateLimiter(rateLimits)
+	server.SetBeaconOrigins(splitList(cfg.BeaconOrigins))
+	if err := server.SetTrustedProxies(splitList(cfg.TrustedProxies)); err != nil {
+		return nil, fmt.Errorf("--trusted-proxies: %w", err)
+	}
+	server.SetOTLPMapper(otlp.NewMapper(otlp.Options{
+		SessionAttribute: cfg.OTLPSessionAttribute,
+		RouteAttribute:   cfg.OTLPRouteAttribute,
+	}))
+
+	hooks, err := newWebhooks(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("webhook delivery log: %w", err)
+	}
+	server.SetWebhookOutbox(hooks.outbox)
+
+	var exp *exporter.Engine
+	var syncer *exporter.Syncer
+	if cfg.ExportAdapter != "" {
+		exp, syncer, err = newExporter(cfg, incidentSvc, issueSvc, hooks.export)
+		if err != nil {
+			log.Printf("[app] ticket export disabled: %v", err)
+		} else {
+			server.SetExporter(exp)
+			server.SetTicketSyncer(syncer, cfg.TicketSyncSecret)
+		}
+	}
+
+	return &App{
+		Server:          server,
+		SessionManager:  sessionMgr,
+		IncidentSvc:     incidentSvc,
+		IssueSvc:        issueSvc,
+		Engine:          engine.New(scorer),
+		Notifier:        notifier,
+		IncidentWebhook: hooks.incident,
+		DeadLetters:     deadLetters,
+		RateLimits:      rateLimits,
+		Exporter:        exp,
+		TicketSyncer:    syncer,
+		cfg:             cfg,
+	}, nil
 }
 
 // Start begins background processing (session manager, engine pipeline).
@@ -52,6 +173,20 @@ func (a *App) Start(ctx context.Context) {
 	a.cancel = cancel
 
 	a.SessionManager.Start(ctx)
+	go a.DeadLetters.Start(ctx, deadLetterPruneInterval)
+	go a.RateLimits.Quotas().Start(ctx, quotaFlushInterval)
+	if a.Notifier != nil {
+		a.Notifier.Start(ctx)
+	}
+	if a.Notifier != nil || a.IncidentWebhook != nil {
+		a.notifications = newNotifyQueue(a.Notifier, a.IncidentWebhook)
+	}
+	if a.Exporter != nil && a.cfg.ExportInterval > 0 {
+		go exporter.NewScheduler(a.cfg.ExportInterval, a.cfg.ExportMaxPerInterval, a.Exporter).Start(ctx)
+	}
+	if a.TicketSyncer != nil && a.cfg.TicketSyncInterval > 0 && pollsTicketStatus(a.cfg.ExportAdapter) {
+		go a.TicketSyncer.Start(ctx, a.cfg.TicketSyncInterval)
+	}
 
 	// Session → Engine → Incident Store pipeline
 	go func() {
@@ -68,14 +203,23 @@ func (a *App) Start(ctx context.Context) {
 				log.Printf("[app] processing session %s through engine", newSess.SessionID)
 				metrics.EventQueueDepth.Set(0)
 
-				incidents := engine.DetectFrustration(newSess)
+				incidents := a.Engine.Detect(newSess)
 				for _, inc := range incidents {
+					if iss, err := a.IssueSvc.Record(ctx, *inc); err != nil {
+						log.Printf("[app] failed to cluster incident %s: %v", inc.IncidentID, err)
+					} else {
+						inc.IssueID = iss.IssueID
+						inc.Regression = iss.Status == types.IssueStatusRegressed
+					}
 					if err := a.IncidentSvc.Store(ctx, *inc); err != nil {
 						log.Printf("[app] failed to store incident: %v", err)
 					} else {
 						log.Printf("[app] incident stored: %s (score: %d, confidence: %s)",
 							inc.IncidentID, inc.FrustrationScore, inc.ConfidenceLevel)
 					}
+					if a.notifications != nil {
+						a.notifications.Enqueue(*inc)
+					}
 				}
 			}
 		}
@@ -88,6 +232,48 @@ func (a *App) Stop() {
 		a.cancel()
 	}
 	a.SessionManager.Stop()
+	if a.notifications != nil {
+		a.notifications.Close()
+	}
+	if err := a.RateLimits.Quotas().Flush(); err != nil {
+		log.Printf("[app] failed to save quota usage: %v", err)
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseProjectKeys parses "project=key" pairs into project IDs and keys.
+// Every key must be unique and differ from the API and admin keys, so a key
+// always names one project.
+func parseProjectKeys(v, apiKey, adminKey string) (map[string]string, error) {
+	keys := make(map[string]string)
+	seen := map[string]bool{apiKey: true}
+	for _, item := range splitList(v) {
+		projectID, key, ok := strings.Cut(item, "=")
+		projectID, key = strings.TrimSpace(projectID), strings.TrimSpace(key)
+		switch {
+		case !ok || projectID == "" || key == "":
+			return nil, fmt.Errorf("%q is not a project=key pair", item)
+		case projectID == "default":
+			return nil, fmt.Errorf("the default project's key is --api-key")
+		case keys[projectID] != "":
+			return nil, fmt.Errorf("project %s has two keys", projectID)
+		case seen[key] || (adminKey != "" && key == adminKey):
+			return nil, fmt.Errorf("project %s reuses another key", projectID)
+		}
+		keys[projectID] = key
+		seen[key] = true
+	}
+	return keys, nil
 }
 
 // convertSession bridges the old internal/types.Session to pkg/types.Session.
@@ -103,6 +289,7 @@ func convertSession(old *oldtypes.Session) types.Session {
 			Metadata:       e.Metadata,
 			Environment:    e.Environment,
 			IdempotencyKey: e.IdempotencyKey,
+			SchemaVersion:  e.SchemaVersion,
 		}
 	}
 
@@ -2,17 +2,39 @@ package app
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/andybalholm/brotli"
+
 	"github.com/your-org/frustration-engine/internal/config"
+	"github.com/your-org/frustration-engine/internal/validation"
+	"github.com/your-org/frustration-engine/internal/webhook"
 	"github.com/your-org/frustration-engine/pkg/types"
 )
 
+// newTestApp builds the application, failing the test on a startup error.
+func newTestApp(t *testing.T, cfg *config.Config) *App {
+	t.Helper()
+	application, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return application
+}
+
 func TestApp_HealthEndpoint(t *testing.T) {
 	cfg := &config.Config{
 		Port:   "0",
@@ -20,7 +42,7 @@ func TestApp_HealthEndpoint(t *testing.T) {
 		Dev:    true,
 	}
 
-	application := New(cfg)
+	application := newTestApp(t, cfg)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	application.Start(ctx)
@@ -53,7 +75,7 @@ func TestApp_MetricsEndpoint(t *testing.T) {
 		Dev:    true,
 	}
 
-	application := New(cfg)
+	application := newTestApp(t, cfg)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	application.Start(ctx)
@@ -80,7 +102,7 @@ func TestApp_IngestEvents(t *testing.T) {
 		Dev:    true,
 	}
 
-	application := New(cfg)
+	application := newTestApp(t, cfg)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	application.Start(ctx)
@@ -105,6 +127,14 @@ func TestApp_IngestEvents(t *testing.T) {
 				Timestamp: time.Now().Format(time.RFC3339),
 				SessionID: "session-1",
 				Route:     "/home",
+				Target:    types.EventTarget{Type: "window"},
+			},
+			{
+				EventType: "click",
+				Timestamp: "yesterday",
+				SessionID: "session-1",
+				Route:     "/home",
+				Target:    types.EventTarget{Type: "button", ID: "cta"},
 			},
 		},
 	}
@@ -132,6 +162,9 @@ func TestApp_IngestEvents(t *testing.T) {
 	if result.Processed != 2 {
 		t.Errorf("ingest processed = %d, want 2", result.Processed)
 	}
+	if len(result.Rejected) != 1 || result.Rejected[0].Index != 2 || result.Rejected[0].Reason != "invalid_timestamp" {
+		t.Errorf("ingest rejected = %+v, want event 2 rejected as invalid_timestamp", result.Rejected)
+	}
 }
 
 func TestApp_IngestUnauthorized(t *testing.T) {
@@ -141,7 +174,7 @@ func TestApp_IngestUnauthorized(t *testing.T) {
 		Dev:    true,
 	}
 
-	application := New(cfg)
+	application := newTestApp(t, cfg)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	application.Start(ctx)
@@ -175,7 +208,7 @@ func TestApp_QueryIncidents(t *testing.T) {
 		Dev:    true,
 	}
 
-	application := New(cfg)
+	application := newTestApp(t, cfg)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	application.Start(ctx)
@@ -185,7 +218,9 @@ func TestApp_QueryIncidents(t *testing.T) {
 	defer srv.Close()
 
 	// Query incidents (should be empty initially)
-	resp, err := http.Get(srv.URL + "/v1/incidents")
+	req, _ := http.NewRequest("GET", srv.URL+"/v1/incidents", nil)
+	req.Header.Set("X-API-Key", "test-key")
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		t.Fatalf("query request failed: %v", err)
 	}
@@ -201,3 +236,1148 @@ func TestApp_QueryIncidents(t *testing.T) {
 		t.Errorf("expected 0 incidents initially, got %d", result.Total)
 	}
 }
+
+func TestApp_ExportTrigger(t *testing.T) {
+	cfg := &config.Config{
+		Port:                 "0",
+		APIKey:               "test-key",
+		AdminKey:             "admin-key",
+		TicketSyncSecret:     "sync-secret",
+		Dev:                  true,
+		ExportAdapter:        "noop",
+		ExportThreshold:      0.7,
+		ExportMaxPerInterval: 10,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	application.IncidentSvc.Store(ctx, types.Incident{
+		IncidentID:      "inc-1",
+		ProjectID:       "default",
+		ConfidenceScore: 91,
+		SeverityType:    "Bug",
+		Timestamp:       time.Now(),
+	})
+
+	// The browser SDK key cannot run the exporter
+	req, _ := http.NewRequest("POST", srv.URL+"/v1/export/trigger", nil)
+	req.Header.Set("X-API-Key", "test-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("trigger request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("trigger with the SDK key = %d, want 401", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("POST", srv.URL+"/v1/export/trigger", nil)
+	req.Header.Set("X-Admin-Key", "admin-key")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("trigger request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	if resp.StatusCode != http.StatusOK || result["exported"] != float64(1) {
+		t.Fatalf("trigger = %d %v, want 200 with 1 export", resp.StatusCode, result)
+	}
+
+	inc, _, _ := application.IncidentSvc.Get(ctx, "inc-1")
+	if inc.ExternalTicketID != "test-ticket-inc-1" || inc.ExternalSystem != "noop" || inc.ExportedAt == nil {
+		t.Errorf("ticket not written back: %+v", inc)
+	}
+
+	// The ticket system reports the ticket closed as invalid, signed with
+	// the sync secret; the SDK key is not enough
+	body := []byte(`{"ticketId":"test-ticket-inc-1","status":"Closed","resolution":"Cannot Reproduce"}`)
+	req, _ = http.NewRequest("POST", srv.URL+"/v1/tickets/sync", bytes.NewReader(body))
+	req.Header.Set("X-API-Key", "test-key")
+	syncResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("sync request failed: %v", err)
+	}
+	syncResp.Body.Close()
+	if syncResp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("unsigned sync = %d, want 401", syncResp.StatusCode)
+	}
+
+	now := time.Now().Unix()
+	req, _ = http.NewRequest("POST", srv.URL+"/v1/tickets/sync", bytes.NewReader(body))
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(now, 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign("sync-secret", now, body))
+	syncResp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("sync request failed: %v", err)
+	}
+	defer syncResp.Body.Close()
+	if syncResp.StatusCode != http.StatusOK {
+		t.Fatalf("sync = %d, want 200", syncResp.StatusCode)
+	}
+
+	inc, _, _ = application.IncidentSvc.Get(ctx, "inc-1")
+	if inc.Status != "suppressed" || !inc.Suppressed {
+		t.Errorf("incident after invalid ticket = %s (suppressed=%v), want suppressed", inc.Status, inc.Suppressed)
+	}
+}
+
+func TestApp_ExportsDraftIncidentsByDefault(t *testing.T) {
+	cfg := &config.Config{
+		Port:            "0",
+		APIKey:          "test-key",
+		AdminKey:        "admin-key",
+		ExportAdapter:   "noop",
+		ExportThreshold: 0.7,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	application.IncidentSvc.Store(ctx, types.Incident{
+		IncidentID:      "inc-draft",
+		ProjectID:       "default",
+		Status:          "draft",
+		ConfidenceScore: 91,
+		SeverityType:    "Bug",
+		Timestamp:       time.Now(),
+	})
+
+	req, _ := http.NewRequest("POST", srv.URL+"/v1/export/trigger", nil)
+	req.Header.Set("X-Admin-Key", "admin-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("trigger request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	if result["queued"] != float64(1) || result["exported"] != float64(1) {
+		t.Fatalf("trigger = %v, want 1 queued and 1 exported", result)
+	}
+	inc, _, _ := application.IncidentSvc.Get(ctx, "inc-draft")
+	if inc.ExternalTicketID == "" {
+		t.Errorf("draft incident not exported with default config: %+v", inc)
+	}
+}
+
+func TestApp_ExportRequireConfirm(t *testing.T) {
+	cfg := &config.Config{
+		Port:                 "0",
+		APIKey:               "test-key",
+		AdminKey:             "admin-key",
+		ExportAdapter:        "noop",
+		ExportThreshold:      0.7,
+		ExportRequireConfirm: true,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	application.IncidentSvc.Store(ctx, types.Incident{
+		IncidentID:      "inc-draft",
+		ProjectID:       "default",
+		Status:          "draft",
+		ConfidenceScore: 91,
+		SeverityType:    "Bug",
+		Timestamp:       time.Now(),
+	})
+
+	post := func(path, key string) map[string]interface{} {
+		req, _ := http.NewRequest("POST", srv.URL+path, nil)
+		req.Header.Set("X-Admin-Key", key)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s request failed: %v", path, err)
+		}
+		defer resp.Body.Close()
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		result["code"] = float64(resp.StatusCode)
+		return result
+	}
+
+	if result := post("/v1/export/trigger", "admin-key"); result["exported"] != float64(0) {
+		t.Fatalf("trigger before confirmation = %v, want nothing exported", result)
+	}
+	if result := post("/v1/admin/incidents/inc-draft/confirm", "test-key"); result["code"] != float64(http.StatusUnauthorized) {
+		t.Fatalf("confirm with the SDK key = %v, want 401", result["code"])
+	}
+	if result := post("/v1/admin/incidents/inc-draft/confirm", "admin-key"); result["status"] != "confirmed" {
+		t.Fatalf("confirm = %v, want confirmed", result)
+	}
+	if result := post("/v1/export/trigger", "admin-key"); result["exported"] != float64(1) {
+		t.Fatalf("trigger after confirmation = %v, want 1 export", result)
+	}
+	if result := post("/v1/admin/incidents/missing/confirm", "admin-key"); result["code"] != float64(http.StatusNotFound) {
+		t.Errorf("confirm of an unknown incident = %v, want 404", result["code"])
+	}
+}
+
+func TestNewExportAdapter_JiraAuth(t *testing.T) {
+	for _, tc := range []struct {
+		auth, email string
+		ok          bool
+	}{
+		{"basic", "ops@example.com", true},
+		{"basic", "", false},
+		{"bearer", "", true},
+		{"bearer", "ops@example.com", true},
+		{"oauth", "ops@example.com", false},
+	} {
+		cfg := &config.Config{
+			ExportAdapter:        "jira",
+			JiraURL:              "https://jira.example.com",
+			JiraAPIToken:         "token",
+			JiraProject:          "HAWK",
+			JiraEmail:            tc.email,
+			JiraAuth:             tc.auth,
+			JiraIssueType:        "Task",
+			JiraIdempotencyField: "customfield_10050",
+		}
+		if _, err := newExportAdapter(cfg, nil); (err == nil) != tc.ok {
+			t.Errorf("--jira-auth %s with email %q: err = %v, want ok = %v", tc.auth, tc.email, err, tc.ok)
+		}
+	}
+}
+
+func TestApp_ExportPreview(t *testing.T) {
+	cfg := &config.Config{
+		Port:          "0",
+		APIKey:        "test-key",
+		AdminKey:      "admin-key",
+		Dev:           true,
+		ExportAdapter: "noop",
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	application.IncidentSvc.Store(ctx, types.Incident{
+		IncidentID:          "inc-1",
+		ProjectID:           "default",
+		ConfidenceScore:     91,
+		SeverityType:        "Bug",
+		PrimaryFailurePoint: "/checkout",
+		Timestamp:           time.Now(),
+	})
+
+	req, _ := http.NewRequest("GET", srv.URL+"/v1/export/preview/inc-1", nil)
+	req.Header.Set("X-Admin-Key", "admin-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("preview request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var preview map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&preview)
+	if resp.StatusCode != http.StatusOK || preview["template"] != "built-in" || preview["title"] == "" {
+		t.Fatalf("preview = %d %v", resp.StatusCode, preview)
+	}
+
+	inc, _, _ := application.IncidentSvc.Get(ctx, "inc-1")
+	if inc.ExternalTicketID != "" {
+		t.Errorf("preview must not export the incident: %+v", inc)
+	}
+}
+
+func TestApp_DeadLetterQueue(t *testing.T) {
+	cfg := &config.Config{
+		Port:     "0",
+		APIKey:   "test-key",
+		AdminKey: "admin-key",
+		Dev:      true,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	payload := types.IngestRequest{Events: []types.Event{{
+		EventType: "click",
+		Timestamp: "yesterday",
+		SessionID: "session-1",
+		Route:     "/home",
+		Target:    types.EventTarget{Type: "button", ID: "cta"},
+	}}}
+	body, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("POST", srv.URL+"/v1/events", bytes.NewReader(body))
+	req.Header.Set("X-API-Key", "test-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("ingest request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// The SDK key is public, so it cannot read dead letters
+	req, _ = http.NewRequest("GET", srv.URL+"/v1/admin/dlq?reason=invalid_timestamp", nil)
+	req.Header.Set("X-API-Key", "test-key")
+	sdkResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("list request failed: %v", err)
+	}
+	sdkResp.Body.Close()
+	if sdkResp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("list with the SDK key = %d, want 401", sdkResp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("GET", srv.URL+"/v1/admin/dlq?reason=invalid_timestamp", nil)
+	req.Header.Set("X-Admin-Key", "admin-key")
+	listResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("list request failed: %v", err)
+	}
+	defer listResp.Body.Close()
+
+	var list struct {
+		Events []map[string]interface{} `json:"events"`
+		Total  int                      `json:"total"`
+	}
+	json.NewDecoder(listResp.Body).Decode(&list)
+	if listResp.StatusCode != http.StatusOK || list.Total != 1 {
+		t.Fatalf("list = %d %+v, want the rejected event", listResp.StatusCode, list)
+	}
+
+	req, _ = http.NewRequest("POST", srv.URL+"/v1/admin/dlq/replay", strings.NewReader(`{"reason":"invalid_timestamp"}`))
+	req.Header.Set("X-Admin-Key", "admin-key")
+	replayResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("replay request failed: %v", err)
+	}
+	defer replayResp.Body.Close()
+
+	var replay map[string]int
+	json.NewDecoder(replayResp.Body).Decode(&replay)
+	if replayResp.StatusCode != http.StatusOK || replay["replayed"] != 1 || replay["deadLettered"] != 1 {
+		t.Errorf("replay = %d %v, want the event replayed and dead-lettered again", replayResp.StatusCode, replay)
+	}
+
+	// Replay needs a selection
+	req, _ = http.NewRequest("POST", srv.URL+"/v1/admin/dlq/replay", strings.NewReader(`{}`))
+	req.Header.Set("X-Admin-Key", "admin-key")
+	emptyResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("replay request failed: %v", err)
+	}
+	defer emptyResp.Body.Close()
+	if emptyResp.StatusCode != http.StatusBadRequest {
+		t.Errorf("replay without selection = %d, want 400", emptyResp.StatusCode)
+	}
+}
+
+func TestApp_IngestRateLimit(t *testing.T) {
+	cfg := &config.Config{
+		Port:            "0",
+		APIKey:          "test-key",
+		Dev:             true,
+		RateLimitRPS:    1,
+		DailyEventQuota: 1000,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	send := func() *http.Response {
+		payload := types.IngestRequest{Events: []types.Event{{
+			EventType: "click",
+			Timestamp: time.Now().Format(time.RFC3339),
+			SessionID: "session-1",
+			Route:     "/home",
+			Target:    types.EventTarget{Type: "button", ID: "cta"},
+		}}}
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("POST", srv.URL+"/v1/events", bytes.NewReader(body))
+		req.Header.Set("X-API-Key", "test-key")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("ingest request failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	if resp := send(); resp.StatusCode != http.StatusOK || resp.Header.Get("X-RateLimit-Limit") != "1" {
+		t.Fatalf("first ingest = %d (limit %q), want 200 with X-RateLimit-Limit 1", resp.StatusCode, resp.Header.Get("X-RateLimit-Limit"))
+	}
+	resp := send()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" || resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("second ingest = %d %v, want 429 with Retry-After", resp.StatusCode, resp.Header)
+	}
+
+	req, _ := http.NewRequest("GET", srv.URL+"/v1/usage", nil)
+	req.Header.Set("X-API-Key", "test-key")
+	usageResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("usage request failed: %v", err)
+	}
+	defer usageResp.Body.Close()
+
+	var usage struct {
+		ProjectID string `json:"projectId"`
+		Daily     struct {
+			Used  int64 `json:"used"`
+			Limit int64 `json:"limit"`
+		} `json:"daily"`
+	}
+	json.NewDecoder(usageResp.Body).Decode(&usage)
+	if usage.ProjectID != "default" || usage.Daily.Used != 1 || usage.Daily.Limit != 1000 {
+		t.Errorf("usage = %+v, want 1 of 1000 daily events used", usage)
+	}
+}
+
+func TestApp_IssuesScopedToCallerProject(t *testing.T) {
+	cfg := &config.Config{
+		Port:        "0",
+		APIKey:      "test-key",
+		ProjectKeys: "web=web-key",
+		Dev:         true,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	webIssue, err := application.IssueSvc.Record(ctx, types.Incident{
+		IncidentID: "inc-web", ProjectID: "web", SessionID: "s-web", UserID: "user-1",
+		PrimaryFailurePoint: "#pay", Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := application.IssueSvc.Record(ctx, types.Incident{
+		IncidentID: "inc-default", ProjectID: "default", SessionID: "s-default",
+		PrimaryFailurePoint: "#submit", Timestamp: time.Now(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(path, key string) *http.Response {
+		req, _ := http.NewRequest("GET", srv.URL+path, nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	if resp := get("/v1/issues", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("issues without a key = %d, want 401", resp.StatusCode)
+	}
+	if resp := get("/v1/incidents", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("incidents without a key = %d, want 401", resp.StatusCode)
+	}
+	if resp := get("/v1/issues?projectId=web", "test-key"); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("issues of another project = %d, want 403", resp.StatusCode)
+	}
+
+	var result types.IssueQueryResponse
+	json.NewDecoder(get("/v1/issues", "test-key").Body).Decode(&result)
+	if result.Total != 1 || result.Issues[0].ProjectID != "default" {
+		t.Errorf("default key issues = %+v, want only the default project's", result.Issues)
+	}
+	if resp := get("/v1/issues/"+webIssue.IssueID, "test-key"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("another project's issue = %d, want 404", resp.StatusCode)
+	}
+	if resp := get("/v1/issues/"+webIssue.IssueID, "web-key"); resp.StatusCode != http.StatusOK {
+		t.Errorf("own issue = %d, want 200", resp.StatusCode)
+	}
+
+	// Issue IDs are not metric labels; the route pattern is
+	body, _ := io.ReadAll(get("/metrics", "").Body)
+	if strings.Contains(string(body), webIssue.IssueID) || !strings.Contains(string(body), `path="/v1/issues/{issueID}"`) {
+		t.Errorf("HTTP metrics not labelled by route pattern")
+	}
+}
+
+func TestApp_ProjectKeysGetProjectLimits(t *testing.T) {
+	limitsFile := filepath.Join(t.TempDir(), "limits.json")
+	os.WriteFile(limitsFile, []byte(`{"projects": {"web": {"requestsPerSecond": 1}}}`), 0o600)
+	cfg := &config.Config{
+		Port:                "0",
+		APIKey:              "test-key",
+		ProjectKeys:         "web=web-key",
+		Dev:                 true,
+		RateLimitConfigFile: limitsFile,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	do := func(method, path, key string, body []byte) *http.Response {
+		req, _ := http.NewRequest(method, srv.URL+path, bytes.NewReader(body))
+		req.Header.Set("X-API-Key", key)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", method, path, err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+	body, _ := json.Marshal(types.IngestRequest{Events: []types.Event{{
+		EventType: "click",
+		Timestamp: time.Now().Format(time.RFC3339),
+		SessionID: "session-1",
+		Route:     "/home",
+		Target:    types.EventTarget{Type: "button", ID: "cta"},
+	}}})
+
+	// The web key is limited to one request per second, the default key is not
+	for i, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		if resp := do("POST", "/v1/events", "web-key", body); resp.StatusCode != want {
+			t.Errorf("web ingest %d = %d, want %d", i+1, resp.StatusCode, want)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if resp := do("POST", "/v1/events", "test-key", body); resp.StatusCode != http.StatusOK {
+			t.Errorf("default ingest %d = %d, want 200", i+1, resp.StatusCode)
+		}
+	}
+	if u := application.RateLimits.Usage("web"); u.Daily.Used != 1 {
+		t.Errorf("web usage = %d events, want 1", u.Daily.Used)
+	}
+
+	// Usage is reported for the caller's project only
+	var usage struct {
+		ProjectID string `json:"projectId"`
+	}
+	resp := do("GET", "/v1/usage", "web-key", nil)
+	json.NewDecoder(resp.Body).Decode(&usage)
+	if resp.StatusCode != http.StatusOK || usage.ProjectID != "web" {
+		t.Errorf("usage with the web key = %d %+v, want the web project", resp.StatusCode, usage)
+	}
+	if resp := do("GET", "/v1/usage?projectId=web", "test-key", nil); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("usage of another project = %d, want 403", resp.StatusCode)
+	}
+}
+
+func TestApp_QuotaCountsAcceptedEvents(t *testing.T) {
+	cfg := &config.Config{
+		Port:            "0",
+		APIKey:          "test-key",
+		Dev:             true,
+		DailyEventQuota: 1000,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	event := types.Event{
+		EventType: "click",
+		Timestamp: time.Now().Format(time.RFC3339),
+		SessionID: "session-1",
+		Route:     "/home",
+		Target:    types.EventTarget{Type: "button", ID: "cta"},
+	}
+	invalid := event
+	invalid.EventType = "not-an-event"
+	body, _ := json.Marshal(types.IngestRequest{Events: []types.Event{event, invalid, event}})
+	req, _ := http.NewRequest("POST", srv.URL+"/v1/events", bytes.NewReader(body))
+	req.Header.Set("X-API-Key", "test-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("ingest request failed: %v", err)
+	}
+	var result types.IngestResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || result.Processed != 2 || len(result.Rejected) != 1 {
+		t.Fatalf("ingest = %d %+v, want 2 processed and 1 rejected", resp.StatusCode, result)
+	}
+
+	// The rejected event is refunded
+	if u := application.RateLimits.Usage("default"); u.Daily.Used != 2 || u.Monthly.Used != 2 {
+		t.Errorf("usage = %d today, %d this month, want 2", u.Daily.Used, u.Monthly.Used)
+	}
+}
+
+func TestApp_IngestCompressedAndLimits(t *testing.T) {
+	cfg := &config.Config{
+		Port:   "0",
+		APIKey: "test-key",
+		Dev:    true,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	batch := func(n int) []byte {
+		payload := types.IngestRequest{}
+		for i := 0; i < n; i++ {
+			payload.Events = append(payload.Events, types.Event{
+				EventType: "click",
+				Timestamp: time.Now().Format(time.RFC3339),
+				SessionID: "session-1",
+				Route:     "/home",
+				Target:    types.EventTarget{Type: "button", ID: "cta"},
+			})
+		}
+		body, _ := json.Marshal(payload)
+		return body
+	}
+	compress := func(encoding string, data []byte) []byte {
+		var buf bytes.Buffer
+		var w io.WriteCloser
+		switch encoding {
+		case "gzip":
+			w = gzip.NewWriter(&buf)
+		case "deflate":
+			w = zlib.NewWriter(&buf)
+		case "br":
+			w = brotli.NewWriter(&buf)
+		}
+		w.Write(data)
+		w.Close()
+		return buf.Bytes()
+	}
+	// A bomb: a small compressed body that inflates past the payload limit
+	bomb := append([]byte(`{"events":[],"pad":"`), bytes.Repeat([]byte("a"), 4*validation.MaxPayloadSize)...)
+
+	tests := []struct {
+		name       string
+		encoding   string
+		body       []byte
+		wantStatus int
+		wantCode   string
+	}{
+		{"gzip", "gzip", compress("gzip", batch(2)), http.StatusOK, ""},
+		{"deflate", "deflate", compress("deflate", batch(2)), http.StatusOK, ""},
+		{"brotli", "br", compress("br", batch(2)), http.StatusOK, ""},
+		{"decompression bomb", "gzip", compress("gzip", bomb), http.StatusRequestEntityTooLarge, "payload_too_large"},
+		{"oversized payload", "", bomb, http.StatusRequestEntityTooLarge, "payload_too_large"},
+		{"oversized batch", "br", compress("br", batch(validation.MaxBatchSize+1)), http.StatusRequestEntityTooLarge, "batch_too_large"},
+		{"unknown encoding", "zstd", batch(1), http.StatusUnsupportedMediaType, "unsupported_encoding"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", srv.URL+"/v1/events", bytes.NewReader(tt.body))
+			req.Header.Set("X-API-Key", "test-key")
+			if tt.encoding != "" {
+				req.Header.Set("Content-Encoding", tt.encoding)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("ingest request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			var result types.IngestResponse
+			json.NewDecoder(resp.Body).Decode(&result)
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d (%+v), want %d", resp.StatusCode, result, tt.wantStatus)
+			}
+			if tt.wantCode == "" {
+				if result.Processed != 2 {
+					t.Errorf("processed = %d, want 2", result.Processed)
+				}
+			} else if result.Error == nil || result.Error.Code != tt.wantCode {
+				t.Errorf("error = %+v, want %s", result.Error, tt.wantCode)
+			} else if tt.wantStatus == http.StatusRequestEntityTooLarge && result.Error.Limit == 0 {
+				t.Errorf("error = %+v, want the exceeded limit", result.Error)
+			}
+		})
+	}
+}
+
+func TestApp_IngestStream(t *testing.T) {
+	cfg := &config.Config{
+		Port:            "0",
+		APIKey:          "test-key",
+		Dev:             true,
+		DailyEventQuota: 150,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	line, _ := json.Marshal(types.Event{
+		EventType: "click",
+		Timestamp: time.Now().Format(time.RFC3339),
+		SessionID: "session-1",
+		Route:     "/home",
+		Target:    types.EventTarget{Type: "button", ID: "cta"},
+	})
+	stream := func(lines int) (*http.Response, types.StreamIngestResponse) {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		for i := 0; i < lines; i++ {
+			zw.Write(line)
+			zw.Write([]byte("\n"))
+		}
+		zw.Write([]byte("not json\n"))
+		zw.Close()
+
+		req, _ := http.NewRequest("POST", srv.URL+"/v1/events/stream", &buf)
+		req.Header.Set("X-API-Key", "test-key")
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		req.Header.Set("Content-Encoding", "gzip")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("stream request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var result types.StreamIngestResponse
+		json.NewDecoder(resp.Body).Decode(&result)
+		return resp, result
+	}
+
+	resp, result := stream(120)
+	if resp.StatusCode != http.StatusOK || result.Lines != 121 || result.Processed != 120 || result.Rejected != 1 ||
+		len(result.Errors) != 1 || result.Errors[0].Line != 121 || result.Errors[0].Reason != "invalid_json" {
+		t.Fatalf("stream = %d %+v, want 120 processed and line 121 rejected", resp.StatusCode, result)
+	}
+
+	// The daily quota (150) runs out in the first chunk of the next stream
+	resp, result = stream(120)
+	if resp.StatusCode != http.StatusTooManyRequests || result.Error == nil || result.Error.Code != "daily_quota" ||
+		result.Processed != 0 || resp.Header.Get("Retry-After") == "" {
+		t.Errorf("stream over quota = %d %+v, want 429 daily_quota", resp.StatusCode, result)
+	}
+}
+
+func TestApp_BeaconIngest(t *testing.T) {
+	cfg := &config.Config{
+		Port:          "0",
+		APIKey:        "test-key",
+		BeaconOrigins: "https://*.example.com, https://app.test",
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	batch := func(apiKey string) string {
+		body, _ := json.Marshal(types.IngestRequest{APIKey: apiKey, Events: []types.Event{{
+			EventType: "click",
+			Timestamp: time.Now().Format(time.RFC3339),
+			SessionID: "session-1",
+			Route:     "/checkout",
+			Target:    types.EventTarget{Type: "button", ID: "pay"},
+		}}})
+		return string(body)
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		body       string
+		headers    map[string]string
+		wantStatus int
+	}{
+		{"key in query", "?api_key=test-key", batch(""), map[string]string{"Origin": "https://shop.example.com"}, http.StatusOK},
+		{"key in payload", "", batch("test-key"), map[string]string{"Origin": "https://app.test"}, http.StatusOK},
+		{"origin from referer", "", batch("test-key"), map[string]string{"Referer": "https://shop.example.com/cart?step=2"}, http.StatusOK},
+		{"foreign origin", "?api_key=test-key", batch(""), map[string]string{"Origin": "https://evil-example.com"}, http.StatusForbidden},
+		{"no origin", "?api_key=test-key", batch(""), nil, http.StatusForbidden},
+		{"wrong key", "?api_key=guess", batch(""), map[string]string{"Origin": "https://app.test"}, http.StatusUnauthorized},
+		{"form body", "?api_key=test-key", batch(""), map[string]string{"Origin": "https://app.test", "Content-Type": "application/x-www-form-urlencoded"}, http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", srv.URL+"/v1/events/beacon"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "text/plain;charset=UTF-8")
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("beacon request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			var result types.IngestResponse
+			json.NewDecoder(resp.Body).Decode(&result)
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d (%+v), want %d", resp.StatusCode, result, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && result.Processed != 1 {
+				t.Errorf("processed = %d, want 1", result.Processed)
+			}
+		})
+	}
+
+	// A query key is only honored on the origin-checked beacon route
+	resp, err := http.Post(srv.URL+"/v1/events?api_key=test-key", "text/plain", strings.NewReader(batch("")))
+	if err != nil {
+		t.Fatalf("ingest request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("/v1/events with a query key = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestApp_ClientIPFromTrustedProxiesOnly(t *testing.T) {
+	geoFile := filepath.Join(t.TempDir(), "geo.csv")
+	os.WriteFile(geoFile, []byte("81.2.69.0,81.2.69.255,GB\n"), 0o600)
+
+	// country ingests an invalid event with X-Forwarded-For and reads the
+	// country it was enriched with back from the dead letter queue
+	country := func(trustedProxies, forwardedFor string) string {
+		application := newTestApp(t, &config.Config{
+			Port:           "0",
+			APIKey:         "test-key",
+			AdminKey:       "admin-key",
+			GeoDBFile:      geoFile,
+			TrustedProxies: trustedProxies,
+		})
+		srv := httptest.NewServer(application.Server.Handler())
+		defer srv.Close()
+
+		body, _ := json.Marshal(types.IngestRequest{Events: []types.Event{{
+			EventType: "click",
+			Timestamp: "yesterday",
+			SessionID: "session-1",
+			Route:     "/home",
+			Target:    types.EventTarget{Type: "button"},
+		}}})
+		req, _ := http.NewRequest("POST", srv.URL+"/v1/events", bytes.NewReader(body))
+		req.Header.Set("X-API-Key", "test-key")
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("ingest request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		req, _ = http.NewRequest("GET", srv.URL+"/v1/admin/dlq", nil)
+		req.Header.Set("X-Admin-Key", "admin-key")
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("list request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var list struct {
+			Events []struct {
+				Event types.Event `json:"event"`
+			} `json:"events"`
+		}
+		json.NewDecoder(resp.Body).Decode(&list)
+		if len(list.Events) != 1 {
+			t.Fatalf("dead letters = %d, want 1", len(list.Events))
+		}
+		client, _ := list.Events[0].Event.Metadata["client"].(map[string]interface{})
+		c, _ := client["country"].(string)
+		return c
+	}
+
+	if got := country("", "81.2.69.142"); got != "" {
+		t.Errorf("country from an untrusted peer's header = %q, want none", got)
+	}
+	if got := country("127.0.0.1", "81.2.69.142"); got != "GB" {
+		t.Errorf("country from a trusted proxy = %q, want GB", got)
+	}
+	// The proxy appends the peer it saw; what the client sent before it is ignored
+	if got := country("127.0.0.0/8", "81.2.69.142, 10.1.2.3"); got != "" {
+		t.Errorf("country with a client-supplied hop = %q, want none", got)
+	}
+}
+
+func TestApp_EnrichmentDoesNotCountAgainstMetadataKeys(t *testing.T) {
+	application := newTestApp(t, &config.Config{Port: "0", APIKey: "test-key"})
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	metadata := make(map[string]interface{}, validation.MaxMetadataKeys)
+	for i := 0; i < validation.MaxMetadataKeys; i++ {
+		metadata["key"+strconv.Itoa(i)] = i
+	}
+	body, _ := json.Marshal(types.IngestRequest{Events: []types.Event{{
+		EventType: "click",
+		Timestamp: time.Now().Format(time.RFC3339),
+		SessionID: "session-1",
+		Route:     "/home",
+		Target:    types.EventTarget{Type: "button"},
+		Metadata:  metadata,
+	}}})
+	req, _ := http.NewRequest("POST", srv.URL+"/v1/events", bytes.NewReader(body))
+	req.Header.Set("X-API-Key", "test-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("ingest request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result types.IngestResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+	if result.Processed != 1 {
+		t.Errorf("event with %d metadata keys: %+v, want it processed", validation.MaxMetadataKeys, result)
+	}
+}
+
+func TestApp_OTLPIngest(t *testing.T) {
+	cfg := &config.Config{
+		Port:                 "0",
+		APIKey:               "test-key",
+		Dev:                  true,
+		OTLPSessionAttribute: "app.session_id",
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	post := func(path, contentType, body string) (*http.Response, map[string]interface{}) {
+		req, _ := http.NewRequest("POST", srv.URL+path, strings.NewReader(body))
+		req.Header.Set("X-API-Key", "test-key")
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("OTLP request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		return resp, result
+	}
+
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	traces := `{"resourceSpans":[{"resource":{"attributes":[{"key":"app.session_id","value":{"stringValue":"otel-1"}}]},
+	  "scopeSpans":[{"spans":[
+	    {"name":"click","startTimeUnixNano":"` + now + `","attributes":[{"key":"event_type","value":{"stringValue":"click"}}]},
+	    {"name":"HTTP GET","startTimeUnixNano":"` + now + `","endTimeUnixNano":"` + now + `",
+	     "attributes":[{"key":"http.method","value":{"stringValue":"GET"}},{"key":"http.status_code","value":{"intValue":"503"}}]}]}]},
+	  {"scopeSpans":[{"spans":[{"name":"longtask","startTimeUnixNano":"` + now + `"}]}]}]}`
+
+	resp, result := post("/v1/traces", "application/json", traces)
+	partial, _ := result["partialSuccess"].(map[string]interface{})
+	if resp.StatusCode != http.StatusOK || partial["rejectedSpans"] != "1" {
+		t.Fatalf("traces = %d %v, want 200 with 1 rejected span", resp.StatusCode, result)
+	}
+
+	logs := `{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"timeUnixNano":"` + now + `","severityNumber":17,
+	  "body":{"stringValue":"boom"},"attributes":[{"key":"app.session_id","value":{"stringValue":"otel-1"}}]}]}]}]}`
+	resp, result = post("/v1/logs", "application/json", logs)
+	if resp.StatusCode != http.StatusOK || result["partialSuccess"] != nil {
+		t.Fatalf("logs = %d %v, want 200 without partial success", resp.StatusCode, result)
+	}
+
+	if resp, _ := post("/v1/traces", "application/x-protobuf", ""); resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("protobuf traces status = %d, want 415", resp.StatusCode)
+	}
+	if resp, _ := post("/v1/logs", "application/json", "{"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("malformed logs status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestApp_Schema(t *testing.T) {
+	cfg := &config.Config{
+		Port:   "0",
+		APIKey: "test-key",
+		Dev:    true,
+	}
+
+	application := newTestApp(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	application.Start(ctx)
+	defer application.Stop()
+
+	srv := httptest.NewServer(application.Server.Handler())
+	defer srv.Close()
+
+	// The schema is public: SDKs and tooling fetch it without an API key
+	resp, err := http.Get(srv.URL + "/v1/schema")
+	if err != nil {
+		t.Fatalf("schema request failed: %v", err)
+	}
+	var index struct {
+		Current    int      `json:"current"`
+		Versions   []int    `json:"versions"`
+		EventTypes []string `json:"eventTypes"`
+	}
+	json.NewDecoder(resp.Body).Decode(&index)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || index.Current != types.CurrentSchemaVersion || len(index.Versions) != index.Current || len(index.EventTypes) == 0 {
+		t.Errorf("GET /v1/schema = %d %+v", resp.StatusCode, index)
+	}
+
+	resp, err = http.Get(srv.URL + "/v1/schema/2/click")
+	if err != nil {
+		t.Fatalf("schema request failed: %v", err)
+	}
+	var doc map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&doc)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/schema+json" || doc["$id"] != "urn:hawkeye:event:v2:click" {
+		t.Errorf("GET /v1/schema/2/click = %d %s %v", resp.StatusCode, resp.Header.Get("Content-Type"), doc["$id"])
+	}
+
+	for _, path := range []string{"/v1/schema/9", "/v1/schema/two", "/v1/schema/2/hover"} {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("schema request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", path, resp.StatusCode)
+		}
+	}
+
+	// Events from a newer SDK than the server are rejected, not misread
+	body := `{"events":[{"eventType":"click","timestamp":"` + time.Now().UTC().Format(time.RFC3339) + `",
+	  "sessionId":"s-1","route":"/","target":{"type":"button"},"schemaVersion":99}]}`
+	req, _ := http.NewRequest("POST", srv.URL+"/v1/events", strings.NewReader(body))
+	req.Header.Set("X-API-Key", "test-key")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("ingest request failed: %v", err)
+	}
+	var ingested types.IngestResponse
+	json.NewDecoder(resp.Body).Decode(&ingested)
+	resp.Body.Close()
+	if ingested.Processed != 0 || len(ingested.Rejected) != 1 || ingested.Rejected[0].Reason != validation.ReasonUnsupportedSchema {
+		t.Errorf("ingest of a version 99 event = %+v", ingested)
+	}
+}
+
+func TestNew_FailsOnUnloadableScorerModel(t *testing.T) {
+	cfg := &config.Config{
+		Port:        "0",
+		APIKey:      "test-key",
+		Scorer:      "logistic",
+		ScorerModel: filepath.Join(t.TempDir(), "missing.json"),
+	}
+	if _, err := New(cfg); err == nil {
+		t.Fatal("New started with a scorer model that does not exist")
+	}
+}
+
+func TestNew_FailsOnUnopenableDeadLetterFile(t *testing.T) {
+	cfg := &config.Config{
+		Port:    "0",
+		APIKey:  "test-key",
+		DLQFile: filepath.Join(t.TempDir(), "missing", "dlq.json"),
+	}
+	if _, err := New(cfg); err == nil {
+		t.Fatal("New() with an unopenable dead letter file succeeded, want error")
+	}
+}
+
+func TestNew_FailsOnUnloadableRedactionConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "redaction.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Port: "0", APIKey: "test-key", RedactionConfigFile: path}
+	if _, err := New(cfg); err == nil {
+		t.Fatal("New() with an unparseable redaction config succeeded, want error")
+	}
+}
+
+func TestNew_FailsOnUnreadableQuotaFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quota.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Port: "0", APIKey: "test-key", QuotaFile: path}
+	if _, err := New(cfg); err == nil {
+		t.Fatal("New() with an unparseable quota file succeeded, want error")
+	}
+}
+
+func TestNotifyQueue_DrainsOnClose(t *testing.T) {
+	var received int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&received, 1)
+	}))
+	defer receiver.Close()
+
+	q := newNotifyQueue(nil, webhook.NewNotifier(webhook.Config{URL: receiver.URL}))
+	for i := 0; i < 20; i++ {
+		if !q.Enqueue(types.Incident{IncidentID: "inc-" + strconv.Itoa(i)}) {
+			t.Fatalf("incident %d not queued", i)
+		}
+	}
+	q.Close()
+
+	if got := atomic.LoadInt32(&received); got != 20 {
+		t.Errorf("received %d incidents after Close, want 20", got)
+	}
+	if q.Enqueue(types.Incident{IncidentID: "late"}) {
+		t.Errorf("Enqueue after Close succeeded")
+	}
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/config"
+	"github.com/your-org/frustration-engine/internal/ingestion"
+)
+
+// deadLetterPruneInterval is how often dead letter retention is applied.
+const deadLetterPruneInterval = time.Minute
+
+// defaultDLQMaxEvents applies when the configuration leaves the limit unset.
+const defaultDLQMaxEvents = 10000
+
+// newDeadLetterQueue builds the dead letter queue for failed events,
+// persisted to cfg.DLQFile when set. A file that cannot be opened fails
+// startup: events reported as dead-lettered would otherwise be lost on the
+// next restart.
+func newDeadLetterQueue(cfg *config.Config) (*ingestion.DeadLetterQueue, error) {
+	maxEvents := cfg.DLQMaxEvents
+	if maxEvents <= 0 {
+		maxEvents = defaultDLQMaxEvents
+	}
+
+	var storage ingestion.DeadLetterStorage = ingestion.NewInMemoryDeadLetterStorage()
+	if cfg.DLQFile != "" {
+		fileStorage, err := ingestion.NewFileDeadLetterStorage(cfg.DLQFile)
+		if err != nil {
+			return nil, err
+		}
+		storage = fileStorage
+	}
+
+	dlq := ingestion.NewDeadLetterQueue(maxEvents, storage)
+	dlq.SetRetention(cfg.DLQMaxAge)
+	return dlq, nil
+}
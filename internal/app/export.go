@@ -0,0 +1,133 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/adapters"
+	"github.com/your-org/frustration-engine/internal/config"
+	"github.com/your-org/frustration-engine/internal/exporter"
+	"github.com/your-org/frustration-engine/internal/incident"
+	"github.com/your-org/frustration-engine/internal/issue"
+	"github.com/your-org/frustration-engine/internal/webhook"
+)
+
+// newExporter builds the ticket exporter, and the syncer that reflects
+// ticket status back onto incidents, over the in-process incident and issue
+// services.
+func newExporter(cfg *config.Config, incidentSvc *incident.Service, issueSvc *issue.Service, hook *webhook.Notifier) (*exporter.Engine, *exporter.Syncer, error) {
+	adapter, err := newExportAdapter(cfg, hook)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	maxPerInterval := cfg.ExportMaxPerInterval
+	if maxPerInterval <= 0 {
+		maxPerInterval = 10
+	}
+	interval := cfg.ExportInterval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	store := incident.NewExportStore(incidentSvc, !cfg.ExportRequireConfirm)
+	exp := exporter.NewEngine(store, adapter, cfg.ExportThreshold, maxPerInterval)
+	exp.SetRateLimit(maxPerInterval, interval)
+	store.SetMinConfidence(exp.MinConfidence())
+	exp.SetIssueSource(issueSvc)
+	if cfg.TicketTemplatesFile != "" {
+		templates, err := exporter.LoadTicketTemplatesFile(cfg.TicketTemplatesFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		exp.SetTemplates(templates)
+	}
+	if cfg.ExportQueueFile != "" {
+		queue, err := exporter.NewFileJobQueue(cfg.ExportQueueFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		exp.SetQueue(queue)
+	}
+	syncer := exporter.NewSyncer(store, adapter)
+	syncer.SetIssueResolver(issueSvc)
+	return exp, syncer, nil
+}
+
+// pollsTicketStatus reports whether the export adapter can fetch ticket
+// status; the others only receive it via POST /v1/tickets/sync.
+func pollsTicketStatus(adapter string) bool {
+	switch adapter {
+	case "jira", "linear", "github":
+		return true
+	default:
+		return false
+	}
+}
+
+// newExportAdapter creates the ticket system adapter named by
+// cfg.ExportAdapter. The webhook adapter delivers through hook.
+func newExportAdapter(cfg *config.Config, hook *webhook.Notifier) (adapters.Adapter, error) {
+	switch cfg.ExportAdapter {
+	case "jira":
+		if cfg.JiraURL == "" || cfg.JiraAPIToken == "" || cfg.JiraProject == "" {
+			return nil, fmt.Errorf("jira export needs --jira-url, --jira-token and --jira-project")
+		}
+		// The adapter uses Basic auth when given an email, Bearer otherwise.
+		email := cfg.JiraEmail
+		switch cfg.JiraAuth {
+		case "", "basic":
+			if email == "" {
+				return nil, fmt.Errorf("jira basic auth needs --jira-email (or --jira-auth bearer)")
+			}
+		case "bearer":
+			email = ""
+		default:
+			return nil, fmt.Errorf("unknown --jira-auth %q (want basic or bearer)", cfg.JiraAuth)
+		}
+		return adapters.NewJiraAdapterWithConfig(adapters.JiraConfig{
+			BaseURL:          cfg.JiraURL,
+			ProjectKey:       cfg.JiraProject,
+			Email:            email,
+			APIToken:         cfg.JiraAPIToken,
+			IssueType:        cfg.JiraIssueType,
+			IdempotencyField: cfg.JiraIdempotencyField,
+		}), nil
+
+	case "linear":
+		if cfg.LinearAPIKey == "" || cfg.LinearTeamID == "" {
+			return nil, fmt.Errorf("linear export needs --linear-api-key and --linear-team")
+		}
+		return adapters.NewLinearAdapterWithConfig(adapters.LinearConfig{
+			APIKey:    cfg.LinearAPIKey,
+			TeamID:    cfg.LinearTeamID,
+			ProjectID: cfg.LinearProjectID,
+			LabelIDs:  splitList(cfg.LinearLabelIDs),
+			Priority:  exporter.NewPriorityMapper().LinearPriority,
+		}), nil
+
+	case "github":
+		owner, repo, ok := strings.Cut(cfg.GitHubRepo, "/")
+		if cfg.GitHubToken == "" || !ok || owner == "" || repo == "" {
+			return nil, fmt.Errorf("github export needs --github-token and --github-repo owner/repo")
+		}
+		return adapters.NewGitHubAdapterWithConfig(adapters.GitHubConfig{
+			APIURL: cfg.GitHubAPIURL,
+			Token:  cfg.GitHubToken,
+			Owner:  owner,
+			Repo:   repo,
+		}), nil
+
+	case "webhook":
+		if hook == nil {
+			return nil, fmt.Errorf("webhook export needs --export-webhook-url")
+		}
+		return adapters.NewWebhookAdapter(hook), nil
+
+	case "noop":
+		return adapters.NewNoOpAdapter(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown export adapter %q", cfg.ExportAdapter)
+	}
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/incident"
+	"github.com/your-org/frustration-engine/internal/metrics"
+	"github.com/your-org/frustration-engine/internal/notify"
+	"github.com/your-org/frustration-engine/internal/webhook"
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+const (
+	// notifyWorkers is the number of concurrent incident notifications.
+	notifyWorkers = 4
+	// notifyQueueSize bounds the incidents waiting for a worker; incidents
+	// beyond it are dropped and counted.
+	notifyQueueSize = 1000
+	// notifyDrainTimeout bounds how long Close waits for queued incidents.
+	notifyDrainTimeout = 15 * time.Second
+)
+
+// notifyQueue delivers stored incidents to the chat notifier and the
+// incident webhook on a fixed pool of workers, so a slow receiver cannot
+// pile up goroutines. Close stops intake and drains what is queued.
+type notifyQueue struct {
+	chat *notify.Notifier  // may be nil
+	hook *webhook.Notifier // may be nil
+
+	mu       sync.Mutex
+	closed   bool
+	incoming chan types.Incident
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// newNotifyQueue starts the notification workers.
+func newNotifyQueue(chat *notify.Notifier, hook *webhook.Notifier) *notifyQueue {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &notifyQueue{
+		chat:     chat,
+		hook:     hook,
+		incoming: make(chan types.Incident, notifyQueueSize),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	for i := 0; i < notifyWorkers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+	return q
+}
+
+// Enqueue queues an incident for notification without blocking. It reports
+// false when the queue is full or closed.
+func (q *notifyQueue) Enqueue(inc types.Incident) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return false
+	}
+	select {
+	case q.incoming <- inc:
+		return true
+	default:
+		metrics.NotificationsDropped.Inc()
+		log.Printf("[app] notification queue full, dropping incident %s", inc.IncidentID)
+		return false
+	}
+}
+
+// Close stops intake and waits up to notifyDrainTimeout for queued
+// incidents to be delivered, then cancels deliveries still in flight.
+func (q *notifyQueue) Close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	close(q.incoming)
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(notifyDrainTimeout):
+		log.Printf("[app] notification queue not drained after %s, cancelling", notifyDrainTimeout)
+		q.cancel()
+		<-done
+	}
+	q.cancel()
+}
+
+func (q *notifyQueue) work() {
+	defer q.wg.Done()
+	for inc := range q.incoming {
+		q.deliver(inc)
+	}
+}
+
+func (q *notifyQueue) deliver(inc types.Incident) {
+	if q.chat != nil {
+		if err := q.chat.Notify(q.ctx, inc); err != nil {
+			log.Printf("[app] chat notification failed for %s: %v", inc.IncidentID, err)
+		}
+	}
+	if q.hook != nil {
+		if _, err := q.hook.Notify(q.ctx, incident.ToExporterIncident(inc)); err != nil {
+			log.Printf("[app] incident webhook failed for %s: %v", inc.IncidentID, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"log"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/config"
+	"github.com/your-org/frustration-engine/internal/ratelimit"
+)
+
+// quotaFlushInterval is how often quota usage is written to the quota file.
+const quotaFlushInterval = 10 * time.Second
+
+// newRateLimiter builds the per-project ingestion limits from flags, replaced
+// by cfg.RateLimitConfigFile when set. A rate limit config that cannot be
+// loaded falls back to the flag limits, but a quota file that cannot be read
+// fails startup: counting in memory would reset usage on the next restart.
+func newRateLimiter(cfg *config.Config) (*ratelimit.ProjectLimiter, error) {
+	limits := ratelimit.Config{Default: ratelimit.Limits{
+		RequestsPerSecond: cfg.RateLimitRPS,
+		RequestBurst:      cfg.RateLimitBurst,
+		EventsPerSecond:   cfg.EventRateLimit,
+		EventBurst:        cfg.EventRateBurst,
+		DailyEvents:       cfg.DailyEventQuota,
+		MonthlyEvents:     cfg.MonthlyEventQuota,
+	}}
+	if cfg.RateLimitConfigFile != "" {
+		fileLimits, err := ratelimit.LoadConfigFile(cfg.RateLimitConfigFile)
+		if err != nil {
+			log.Printf("[app] rate limit config not loaded, using flag limits: %v", err)
+		} else {
+			limits = fileLimits
+		}
+	}
+
+	quotas, err := ratelimit.NewQuotaTracker(cfg.QuotaFile)
+	if err != nil {
+		return nil, err
+	}
+	return ratelimit.NewProjectLimiter(limits, quotas), nil
+}
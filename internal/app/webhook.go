@@ -0,0 +1,52 @@
+package app
+
+import (
+	"github.com/your-org/frustration-engine/internal/config"
+	"github.com/your-org/frustration-engine/internal/resilience"
+	"github.com/your-org/frustration-engine/internal/webhook"
+)
+
+// webhooks are the outbound webhook notifiers and the outbox over their
+// shared delivery log.
+type webhooks struct {
+	export   *webhook.Notifier // nil unless --export-webhook-url is set
+	incident *webhook.Notifier // nil unless --incident-webhook-url is set
+	outbox   *webhook.Outbox
+}
+
+// newWebhooks builds the webhook notifiers. Failed deliveries are logged to
+// cfg.WebhookDeliveryLog when set (startup fails if it cannot be opened), in
+// memory otherwise.
+func newWebhooks(cfg *config.Config) (webhooks, error) {
+	var deliveries webhook.DeliveryLog = webhook.NewMemoryLog()
+	if cfg.WebhookDeliveryLog != "" {
+		fileLog, err := webhook.NewFileLog(cfg.WebhookDeliveryLog)
+		if err != nil {
+			return webhooks{}, err
+		}
+		deliveries = fileLog
+	}
+
+	var w webhooks
+	var notifiers []*webhook.Notifier
+	if cfg.ExportWebhookURL != "" {
+		// The exporter's job queue retries failed exports
+		w.export = webhook.NewNotifier(webhook.Config{
+			URL:    cfg.ExportWebhookURL,
+			Secret: cfg.ExportWebhookSecret,
+			Log:    deliveries,
+		})
+		notifiers = append(notifiers, w.export)
+	}
+	if cfg.IncidentWebhookURL != "" {
+		w.incident = webhook.NewNotifier(webhook.Config{
+			URL:    cfg.IncidentWebhookURL,
+			Secret: cfg.IncidentWebhookSecret,
+			Retry:  resilience.DefaultRetryConfig(),
+			Log:    deliveries,
+		})
+		notifiers = append(notifiers, w.incident)
+	}
+	w.outbox = webhook.NewOutbox(deliveries, notifiers...)
+	return w, nil
+}
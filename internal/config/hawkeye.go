@@ -5,16 +5,130 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 // Config holds all configuration for the HawkEye server.
 type Config struct {
 	Port         string
 	APIKey       string
+	AdminKey     string // operator API key, never shipped to browsers; "" disables admin routes
+	// ProjectKeys are per-project SDK keys as "project=key" pairs separated
+	// by commas. APIKey authenticates the "default" project.
+	ProjectKeys string
 	StorageMode  string // "memory" or "clickhouse"
 	IncidentDSN  string // PostgreSQL DSN or "" for log-only
 	Dev          bool   // development mode: memory storage, debug logging, wide CORS
 	LogLevel     string // "debug", "info", "warn", "error"
+
+	// RouteTemplatesFile is a JSON file of per-project route templates
+	// (e.g. {"*": ["/users/:id/settings"]}). Empty = heuristics only.
+	RouteTemplatesFile string
+
+	// Scorer selects the frustration scorer: "deterministic" or "logistic".
+	Scorer string
+	// ScorerModel is the coefficients file for the logistic scorer
+	// (produced by cmd/calibrate).
+	ScorerModel string
+
+	// RedactionConfigFile is a JSON file of PII redaction rules and actions
+	// (see internal/validation/redaction.go). Empty = mask built-in rules.
+	RedactionConfigFile string
+
+	// Dead letter queue for events that fail validation, storage or session
+	// forwarding (inspect and replay under /v1/admin/dlq).
+	DLQFile      string        // persist dead letters here ("" = memory)
+	DLQMaxEvents int           // events kept; the oldest are dropped first
+	DLQMaxAge    time.Duration // events older than this are dropped
+
+	// Ingestion limits per project (see internal/ratelimit). Zero disables
+	// a limit; RateLimitConfigFile overrides these defaults per project.
+	RateLimitRPS        int    // requests per second
+	RateLimitBurst      int    // request burst (0 = RateLimitRPS)
+	EventRateLimit      int    // events per second
+	EventRateBurst      int    // event burst (0 = EventRateLimit)
+	DailyEventQuota     int64  // events per UTC day
+	MonthlyEventQuota   int64  // events per UTC month
+	RateLimitConfigFile string // JSON file of default and per-project limits
+	QuotaFile           string // persist quota usage here ("" = memory)
+
+	// TrustedProxies is a comma-separated list of proxy IPs or CIDR ranges
+	// whose X-Forwarded-For and X-Real-IP headers are believed. Empty = use
+	// the peer address.
+	TrustedProxies string
+
+	// BeaconOrigins is a comma-separated list of page origins allowed to use
+	// the sendBeacon ingest route (e.g. "https://*.example.com"). Empty =
+	// beacons accepted in dev mode only.
+	BeaconOrigins string
+
+	// GeoDBFile is an offline IP-to-country CSV database (DB-IP lite layout,
+	// see internal/enrich) for enriching events with the client's country.
+	// Empty = no geo enrichment.
+	GeoDBFile string
+
+	// OTLP receiver (POST /v1/traces and /v1/logs, see internal/otlp). Only
+	// the OTLP/HTTP JSON encoding is accepted, not protobuf.
+	OTLPSessionAttribute string // attribute holding the session ID
+	OTLPRouteAttribute   string // attribute holding the page URL ("" = http.url of interactions)
+
+	// NotifyConfigFile is a JSON file of Slack/Teams channels and routing
+	// rules (see internal/notify). Empty = chat notifications disabled.
+	NotifyConfigFile string
+
+	// Ticket export. ExportAdapter selects the ticket system ("jira",
+	// "linear", "github" or "webhook"); empty disables the exporter.
+	ExportAdapter        string
+	ExportThreshold      float64       // minimum confidence (0-1) to export
+	ExportInterval       time.Duration // how often the exporter runs
+	ExportMaxPerInterval int           // tickets per project per interval
+	ExportQueueFile      string        // persist export jobs here ("" = memory)
+	// ExportRequireConfirm exports only incidents confirmed through
+	// POST /v1/admin/incidents/{id}/confirm; by default draft incidents
+	// over ExportThreshold are exported as well.
+	ExportRequireConfirm bool
+	// TicketTemplatesFile is a JSON file of text/template ticket templates
+	// per project and adapter (see internal/exporter/template.go). Empty =
+	// built-in ticket format.
+	TicketTemplatesFile string
+	// TicketSyncInterval is how often ticket status is polled back onto
+	// incidents (jira, linear and github only); 0 disables polling.
+	TicketSyncInterval time.Duration
+	// TicketSyncSecret verifies ticket system webhooks on /v1/tickets/sync
+	// (the secret configured on the Jira, Linear or GitHub webhook).
+	// Empty disables the route.
+	TicketSyncSecret string
+
+	// RegressionQuietPeriod is how long after an issue's ticket is
+	// resolved before a recurrence counts as a regression and reopens it.
+	RegressionQuietPeriod time.Duration
+
+	// Ticket system credentials (only those of ExportAdapter are used).
+	JiraURL              string
+	JiraEmail            string
+	JiraAPIToken         string
+	JiraProject          string
+	JiraAuth             string // "basic" (email + API token) or "bearer" (personal access token)
+	JiraIssueType        string // "" = Bug
+	JiraIdempotencyField string // custom field ID holding the idempotency key ("" = label)
+	LinearAPIKey         string
+	LinearTeamID         string
+	LinearProjectID      string // "" = no project
+	LinearLabelIDs       string // comma-separated label IDs applied to every issue
+	GitHubToken          string
+	GitHubRepo           string // "owner/repo"
+	GitHubAPIURL         string // GitHub Enterprise: "https://ghe.example.com/api/v3"
+	ExportWebhookURL     string
+	ExportWebhookSecret  string
+
+	// Incident webhook: every stored incident is POSTed to this URL as a
+	// signed incident.detected payload (see internal/webhook).
+	IncidentWebhookURL    string
+	IncidentWebhookSecret string
+	// WebhookDeliveryLog persists failed webhook deliveries for inspection
+	// and replay under /v1/admin/webhooks ("" = memory).
+	WebhookDeliveryLog string
 }
 
 // Load reads configuration from flags and environment variables.
@@ -24,10 +138,62 @@ func Load() *Config {
 
 	flag.StringVar(&cfg.Port, "port", getEnv("PORT", "8080"), "Server port")
 	flag.StringVar(&cfg.APIKey, "api-key", getEnv("TEST_API_KEY", "dev-api-key"), "API key for SDK authentication")
+	flag.StringVar(&cfg.ProjectKeys, "project-keys", getEnv("HAWKEYE_PROJECT_KEYS", ""), "Per-project SDK keys as project=key pairs, comma-separated (--api-key is the \"default\" project)")
+	flag.StringVar(&cfg.AdminKey, "admin-key", getEnv("HAWKEYE_ADMIN_KEY", ""), "API key for operator endpoints (export, admin); empty disables them")
 	flag.StringVar(&cfg.StorageMode, "storage", getEnv("HAWKEYE_STORAGE", "memory"), "Event storage: memory or clickhouse")
 	flag.StringVar(&cfg.IncidentDSN, "incident-dsn", getEnv("INCIDENT_DSN", ""), "PostgreSQL DSN for incidents (empty = log-only)")
 	flag.BoolVar(&cfg.Dev, "dev", getEnvBool("HAWKEYE_DEV", true), "Enable development mode")
 	flag.StringVar(&cfg.LogLevel, "log-level", getEnv("LOG_LEVEL", "info"), "Log level: debug, info, warn, error")
+	flag.StringVar(&cfg.RouteTemplatesFile, "route-templates", getEnv("HAWKEYE_ROUTE_TEMPLATES", ""), "JSON file of per-project route templates")
+	flag.StringVar(&cfg.Scorer, "scorer", getEnv("HAWKEYE_SCORER", "deterministic"), "Frustration scorer: deterministic or logistic")
+	flag.StringVar(&cfg.ScorerModel, "scorer-model", getEnv("HAWKEYE_SCORER_MODEL", ""), "Coefficients file for the logistic scorer")
+	flag.StringVar(&cfg.RedactionConfigFile, "redaction-config", getEnv("HAWKEYE_REDACTION_CONFIG", ""), "JSON file of PII redaction rules (empty = mask built-in rules)")
+	flag.StringVar(&cfg.DLQFile, "dlq-file", getEnv("HAWKEYE_DLQ_FILE", ""), "File to persist dead-lettered events (empty = in memory)")
+	flag.IntVar(&cfg.DLQMaxEvents, "dlq-max-events", getEnvInt("HAWKEYE_DLQ_MAX_EVENTS", 10000), "Maximum dead-lettered events kept")
+	flag.DurationVar(&cfg.DLQMaxAge, "dlq-max-age", getEnvDuration("HAWKEYE_DLQ_MAX_AGE", 7*24*time.Hour), "How long dead-lettered events are kept")
+	flag.IntVar(&cfg.RateLimitRPS, "rate-limit-rps", getEnvInt("HAWKEYE_RATE_LIMIT_RPS", 0), "Ingestion requests per second per project (0 = unlimited)")
+	flag.IntVar(&cfg.RateLimitBurst, "rate-limit-burst", getEnvInt("HAWKEYE_RATE_LIMIT_BURST", 0), "Ingestion request burst per project (0 = rate)")
+	flag.IntVar(&cfg.EventRateLimit, "event-rate-limit", getEnvInt("HAWKEYE_EVENT_RATE_LIMIT", 0), "Ingested events per second per project (0 = unlimited)")
+	flag.IntVar(&cfg.EventRateBurst, "event-rate-burst", getEnvInt("HAWKEYE_EVENT_RATE_BURST", 0), "Ingested event burst per project (0 = rate)")
+	flag.Int64Var(&cfg.DailyEventQuota, "daily-event-quota", getEnvInt64("HAWKEYE_DAILY_EVENT_QUOTA", 0), "Events per project per UTC day (0 = unlimited)")
+	flag.Int64Var(&cfg.MonthlyEventQuota, "monthly-event-quota", getEnvInt64("HAWKEYE_MONTHLY_EVENT_QUOTA", 0), "Events per project per UTC month (0 = unlimited)")
+	flag.StringVar(&cfg.RateLimitConfigFile, "rate-limit-config", getEnv("HAWKEYE_RATE_LIMIT_CONFIG", ""), "JSON file of default and per-project ingestion limits")
+	flag.StringVar(&cfg.QuotaFile, "quota-file", getEnv("HAWKEYE_QUOTA_FILE", ""), "File to persist event quota usage (empty = in memory)")
+	flag.StringVar(&cfg.TrustedProxies, "trusted-proxies", getEnv("HAWKEYE_TRUSTED_PROXIES", ""), "Comma-separated proxy IPs or CIDR ranges whose X-Forwarded-For/X-Real-IP headers are trusted (empty = use the peer address)")
+	flag.StringVar(&cfg.BeaconOrigins, "beacon-origins", getEnv("HAWKEYE_BEACON_ORIGINS", ""), "Comma-separated page origins allowed to send beacons (empty = dev mode only)")
+	flag.StringVar(&cfg.GeoDBFile, "geo-db", getEnv("HAWKEYE_GEO_DB", ""), "IP-to-country CSV database for event enrichment (empty = no geo)")
+	flag.StringVar(&cfg.OTLPSessionAttribute, "otlp-session-attribute", getEnv("HAWKEYE_OTLP_SESSION_ATTRIBUTE", "session.id"), "OTLP span/log/resource attribute holding the session ID (the receiver takes OTLP/HTTP JSON only; protobuf gets 415)")
+	flag.StringVar(&cfg.OTLPRouteAttribute, "otlp-route-attribute", getEnv("HAWKEYE_OTLP_ROUTE_ATTRIBUTE", ""), "OTLP attribute holding the page URL or path (empty = http.url of interaction spans)")
+	flag.StringVar(&cfg.NotifyConfigFile, "notify-config", getEnv("HAWKEYE_NOTIFY_CONFIG", ""), "JSON file of Slack/Teams notification channels and rules")
+	flag.StringVar(&cfg.ExportAdapter, "export-adapter", getEnv("HAWKEYE_EXPORT_ADAPTER", ""), "Ticket export adapter: jira, linear, github or webhook (empty = disabled)")
+	flag.Float64Var(&cfg.ExportThreshold, "export-threshold", getEnvFloat("HAWKEYE_EXPORT_THRESHOLD", 0.7), "Minimum confidence (0-1) for ticket export")
+	flag.DurationVar(&cfg.ExportInterval, "export-interval", getEnvDuration("HAWKEYE_EXPORT_INTERVAL", 5*time.Minute), "How often the exporter runs")
+	flag.IntVar(&cfg.ExportMaxPerInterval, "export-max-per-interval", getEnvInt("HAWKEYE_EXPORT_MAX_PER_INTERVAL", 10), "Maximum tickets per project per export interval")
+	flag.StringVar(&cfg.ExportQueueFile, "export-queue", getEnv("HAWKEYE_EXPORT_QUEUE", ""), "File to persist export jobs (empty = in memory)")
+	flag.BoolVar(&cfg.ExportRequireConfirm, "export-require-confirm", getEnvBool("HAWKEYE_EXPORT_REQUIRE_CONFIRM", false), "Export only incidents confirmed by an operator")
+	flag.StringVar(&cfg.TicketTemplatesFile, "ticket-templates", getEnv("HAWKEYE_TICKET_TEMPLATES", ""), "JSON file of ticket templates per project and adapter")
+	flag.DurationVar(&cfg.TicketSyncInterval, "ticket-sync-interval", getEnvDuration("HAWKEYE_TICKET_SYNC_INTERVAL", 15*time.Minute), "How often ticket status is synced back onto incidents (0 = disabled)")
+	flag.StringVar(&cfg.TicketSyncSecret, "ticket-sync-secret", getEnv("HAWKEYE_TICKET_SYNC_SECRET", ""), "Secret of the ticket system webhook that pushes ticket changes (empty = disabled)")
+	flag.DurationVar(&cfg.RegressionQuietPeriod, "regression-quiet-period", getEnvDuration("HAWKEYE_REGRESSION_QUIET_PERIOD", 24*time.Hour), "Time after a ticket is resolved before a recurrence reopens it")
+	flag.StringVar(&cfg.JiraURL, "jira-url", getEnv("JIRA_URL", ""), "Jira base URL")
+	flag.StringVar(&cfg.JiraEmail, "jira-email", getEnv("JIRA_EMAIL", ""), "Jira account email (basic auth)")
+	flag.StringVar(&cfg.JiraAPIToken, "jira-token", getEnv("JIRA_API_TOKEN", ""), "Jira API token")
+	flag.StringVar(&cfg.JiraProject, "jira-project", getEnv("JIRA_PROJECT", ""), "Jira project key")
+	flag.StringVar(&cfg.JiraAuth, "jira-auth", getEnv("JIRA_AUTH", "basic"), "Jira auth: basic (email + API token, Jira Cloud) or bearer (personal access token, Data Center)")
+	flag.StringVar(&cfg.JiraIssueType, "jira-issue-type", getEnv("JIRA_ISSUE_TYPE", "Bug"), "Jira issue type of filed tickets")
+	flag.StringVar(&cfg.JiraIdempotencyField, "jira-idempotency-field", getEnv("JIRA_IDEMPOTENCY_FIELD", ""), "Jira custom field ID (e.g. customfield_10050) holding the idempotency key (empty = label)")
+	flag.StringVar(&cfg.LinearAPIKey, "linear-api-key", getEnv("LINEAR_API_KEY", ""), "Linear API key")
+	flag.StringVar(&cfg.LinearTeamID, "linear-team", getEnv("LINEAR_TEAM_ID", ""), "Linear team ID")
+	flag.StringVar(&cfg.LinearProjectID, "linear-project", getEnv("LINEAR_PROJECT_ID", ""), "Linear project ID of filed issues (empty = none)")
+	flag.StringVar(&cfg.LinearLabelIDs, "linear-labels", getEnv("LINEAR_LABEL_IDS", ""), "Comma-separated Linear label IDs applied to every filed issue")
+	flag.StringVar(&cfg.GitHubToken, "github-token", getEnv("GITHUB_TOKEN", ""), "GitHub token")
+	flag.StringVar(&cfg.GitHubRepo, "github-repo", getEnv("GITHUB_REPO", ""), "GitHub repository (owner/repo)")
+	flag.StringVar(&cfg.GitHubAPIURL, "github-api-url", getEnv("GITHUB_API_URL", "https://api.github.com"), "GitHub REST API base URL (GitHub Enterprise: https://HOST/api/v3)")
+	flag.StringVar(&cfg.ExportWebhookURL, "export-webhook-url", getEnv("HAWKEYE_EXPORT_WEBHOOK_URL", ""), "Webhook URL for the webhook export adapter")
+	flag.StringVar(&cfg.ExportWebhookSecret, "export-webhook-secret", getEnv("HAWKEYE_EXPORT_WEBHOOK_SECRET", ""), "HMAC secret for export webhooks")
+	flag.StringVar(&cfg.IncidentWebhookURL, "incident-webhook-url", getEnv("HAWKEYE_INCIDENT_WEBHOOK_URL", ""), "URL to POST every detected incident to (empty = disabled)")
+	flag.StringVar(&cfg.IncidentWebhookSecret, "incident-webhook-secret", getEnv("HAWKEYE_INCIDENT_WEBHOOK_SECRET", ""), "HMAC secret for incident webhooks")
+	flag.StringVar(&cfg.WebhookDeliveryLog, "webhook-delivery-log", getEnv("HAWKEYE_WEBHOOK_DELIVERY_LOG", ""), "File to persist failed webhook deliveries (empty = in memory)")
 	flag.Parse()
 
 	return cfg
@@ -45,13 +211,36 @@ func (c *Config) Print() {
 	fmt.Println("=============================================================")
 	fmt.Printf("  Port:          %s\n", c.Port)
 	fmt.Printf("  API Key:       %s\n", c.APIKey)
+	if c.AdminKey == "" {
+		fmt.Println("  Admin API:     disabled (set --admin-key)")
+	} else {
+		fmt.Println("  Admin API:     enabled (X-Admin-Key)")
+	}
 	fmt.Printf("  Event Storage: %s\n", c.StorageMode)
 	fmt.Printf("  Incidents:     %s\n", incidentMode)
 	fmt.Printf("  Dev Mode:      %v\n", c.Dev)
+	fmt.Printf("  Scorer:        %s\n", c.Scorer)
+	if c.ExportAdapter != "" {
+		fmt.Printf("  Export:        %s (every %s, max %d)\n", c.ExportAdapter, c.ExportInterval, c.ExportMaxPerInterval)
+		fmt.Printf("  Ticket Sync:   every %s\n", c.TicketSyncInterval)
+	}
 	fmt.Println("-------------------------------------------------------------")
 	fmt.Println("  Endpoints:")
 	fmt.Printf("    POST http://localhost:%s/v1/events       (event ingestion)\n", c.Port)
+	fmt.Printf("    POST http://localhost:%s/v1/events/stream (NDJSON event stream)\n", c.Port)
+	fmt.Printf("    POST http://localhost:%s/v1/events/beacon (sendBeacon ingestion)\n", c.Port)
+	fmt.Printf("    POST http://localhost:%s/v1/traces        (OTLP/HTTP JSON spans)\n", c.Port)
+	fmt.Printf("    POST http://localhost:%s/v1/logs          (OTLP/HTTP JSON logs)\n", c.Port)
 	fmt.Printf("    GET  http://localhost:%s/v1/incidents     (query incidents)\n", c.Port)
+	fmt.Printf("    GET  http://localhost:%s/v1/issues        (query issues)\n", c.Port)
+	if c.ExportAdapter != "" {
+		fmt.Printf("    POST http://localhost:%s/v1/export/trigger (manual export run, admin)\n", c.Port)
+		fmt.Printf("    POST http://localhost:%s/v1/tickets/sync    (signed ticket system webhook)\n", c.Port)
+		fmt.Printf("    GET  http://localhost:%s/v1/export/preview/{id} (ticket preview, admin)\n", c.Port)
+	}
+	fmt.Printf("    GET  http://localhost:%s/v1/admin/dlq     (dead-lettered events, admin)\n", c.Port)
+	fmt.Printf("    GET  http://localhost:%s/v1/admin/webhooks (webhook deliveries, admin)\n", c.Port)
+	fmt.Printf("    GET  http://localhost:%s/v1/usage         (event quota usage)\n", c.Port)
 	fmt.Printf("    GET  http://localhost:%s/health           (health check)\n", c.Port)
 	fmt.Printf("    GET  http://localhost:%s/metrics          (prometheus)\n", c.Port)
 	fmt.Println("-------------------------------------------------------------")
@@ -68,6 +257,34 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func getEnvInt(key string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt64(key string, fallback int64) int64 {
+	if v, err := strconv.ParseInt(os.Getenv(key), 10, 64); err == nil {
+		return v
+	}
+	return fallback
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return v
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+	return fallback
+}
+
 func getEnvBool(key string, fallback bool) bool {
 	v := os.Getenv(key)
 	switch v {
@@ -13,10 +13,15 @@
 package engine
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/your-org/frustration-engine/internal/enrich"
 	"github.com/your-org/frustration-engine/internal/metrics"
 	"github.com/your-org/frustration-engine/internal/ufse/correlation"
 	"github.com/your-org/frustration-engine/internal/ufse/emission"
@@ -26,9 +31,33 @@ import (
 	"github.com/your-org/frustration-engine/pkg/types"
 )
 
-// DetectFrustration processes a session and returns detected incidents.
+// Engine runs the detection pipeline with a configurable scorer.
+type Engine struct {
+	scorer scoring.Scorer
+}
+
+// New creates an engine that scores incidents with the given scorer. A nil
+// scorer selects the deterministic scorer.
+func New(scorer scoring.Scorer) *Engine {
+	if scorer == nil {
+		scorer = scoring.DeterministicScorer{}
+	}
+	return &Engine{scorer: scorer}
+}
+
+// defaultEngine backs DetectFrustration.
+var defaultEngine = New(nil)
+
+// DetectFrustration processes a session with the deterministic scorer and
+// returns detected incidents.
 // This is a pure function with no side effects beyond metric counters.
 func DetectFrustration(session types.Session) []*types.Incident {
+	return defaultEngine.Detect(session)
+}
+
+// Detect processes a session and returns detected incidents.
+// It has no side effects beyond metric counters.
+func (e *Engine) Detect(session types.Session) []*types.Incident {
 	start := time.Now()
 	defer func() {
 		metrics.ProcessingLatency.Observe(time.Since(start).Seconds())
@@ -80,7 +109,7 @@ func DetectFrustration(session types.Session) []*types.Incident {
 	// Step 5–6: score and emit
 	var incidents []*types.Incident
 	for _, group := range groups {
-		score := scoring.CalculateScore(group, oldSession.StartTime, oldSession.EndTime)
+		result := e.scorer.Score(group, oldSession.StartTime, oldSession.EndTime)
 		severity := scoring.DetermineSeverityType(group)
 		confidence := scoring.EvaluateConfidence(group)
 
@@ -99,7 +128,8 @@ func DetectFrustration(session types.Session) []*types.Incident {
 			session.SessionID,
 			session.ProjectID,
 			group,
-			score,
+			result.Score,
+			scoring.CalculateConfidenceScore(group, oldSession).Score,
 			severity,
 			failurePoint,
 		)
@@ -109,12 +139,34 @@ func DetectFrustration(session types.Session) []*types.Incident {
 		}
 
 		metrics.IncidentsDetected.Inc()
-		incidents = append(incidents, fromOldIncident(oldIncident))
+		incident := fromOldIncident(oldIncident)
+		incident.ErrorFingerprint = errorFingerprint(classified, group)
+		incident.UserID = sessionUserID(session)
+		if client, ok := enrich.FromMetadata(session.Metadata); ok {
+			incident.Client = &client
+		}
+		applyScoreResult(incident, result)
+		incidents = append(incidents, incident)
 	}
 
 	return incidents
 }
 
+// applyScoreResult records the scorer, its feature vector and the
+// per-feature breakdown on the incident and appends a summary to the
+// explanation.
+func applyScoreResult(incident *types.Incident, result scoring.ScoreResult) {
+	incident.ScoreModel = result.Model
+	incident.ScoreFeatures = result.Features
+	incident.ScoreContributions = make([]types.ScoreContribution, len(result.Contributions))
+	for i, c := range result.Contributions {
+		incident.ScoreContributions[i] = types.ScoreContribution(c)
+	}
+	if len(result.Contributions) > 0 {
+		incident.Explanation = strings.TrimSpace(incident.Explanation + " " + result.Summary() + ".")
+	}
+}
+
 // classifyEvents converts raw events into classified events for signal detection.
 func classifyEvents(events []oldtypes.Event) []signals.ClassifiedEvent {
 	classified := make([]signals.ClassifiedEvent, 0, len(events))
@@ -161,9 +213,9 @@ func parseEventTimestamp(raw string) (time.Time, bool) {
 
 func classifyEventType(eventType string, metadata map[string]interface{}) string {
 	switch eventType {
-	case "click", "input", "scroll", "form_submit":
+	case "click", "input", "scroll", "form_submit", "input_focus", "input_blur", "input_paste":
 		return signals.CategoryInteraction
-	case "error", "network_error", "network_success", "slow_response":
+	case "error", "network_error", "network_success", "slow_response", "input_invalid", "validation_error":
 		return signals.CategorySystemFeedback
 	case "navigation", "route_change":
 		return signals.CategoryNavigation
@@ -182,6 +234,77 @@ func classifyEventType(eventType string, metadata map[string]interface{}) string
 	}
 }
 
+// errorFeedbackWindow bounds how far around a correlated group an error
+// event may be to contribute the group's error fingerprint.
+const errorFeedbackWindow = 10 * time.Second
+
+var (
+	errorNumbers = regexp.MustCompile(`\b(0x)?[0-9a-fA-F]*\d[0-9a-fA-F]*\b`)
+	errorQuoted  = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+)
+
+// errorFingerprint returns a stable hash of the first error message seen on
+// the group's route around the group's signals, or "" if there is none.
+// Numbers, hex IDs and quoted values are masked so the same error from
+// different sessions produces the same fingerprint.
+func errorFingerprint(classified []signals.ClassifiedEvent, group correlation.CorrelatedGroup) string {
+	if len(group.Signals) == 0 {
+		return ""
+	}
+	start, end := group.Signals[0].Timestamp, group.Signals[0].Timestamp
+	for _, s := range group.Signals {
+		if s.Timestamp.Before(start) {
+			start = s.Timestamp
+		}
+		if s.Timestamp.After(end) {
+			end = s.Timestamp
+		}
+	}
+	start = start.Add(-errorFeedbackWindow)
+	end = end.Add(group.TimeWindow + errorFeedbackWindow)
+
+	for _, ce := range classified {
+		if ce.Category != signals.CategorySystemFeedback || ce.Route != group.Route {
+			continue
+		}
+		if ce.Timestamp.Before(start) || ce.Timestamp.After(end) {
+			continue
+		}
+		message := errorMessage(ce.Event)
+		status, _ := ce.Event.Metadata["status"].(float64)
+		if message == "" && status < 400 {
+			continue
+		}
+		message = errorQuoted.ReplaceAllString(message, "?")
+		message = errorNumbers.ReplaceAllString(message, "N")
+		key := ce.Event.EventType + "|" + strconv.Itoa(int(status)) + "|" + strings.ToLower(strings.TrimSpace(message))
+		sum := sha256.Sum256([]byte(key))
+		return hex.EncodeToString(sum[:6])
+	}
+	return ""
+}
+
+// errorMessage returns a system feedback event's message. Ingest upcasts
+// older aliases (error, errorMessage) to message, so no other key is read.
+func errorMessage(e oldtypes.Event) string {
+	v, _ := e.Metadata["message"].(string)
+	return v
+}
+
+// sessionUserID returns the application user ID for a session, if the SDK
+// or the host application attached one.
+func sessionUserID(s types.Session) string {
+	if v, ok := s.Metadata["userId"].(string); ok {
+		return v
+	}
+	for _, e := range s.Events {
+		if v, ok := e.Metadata["userId"].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 // --- type conversion helpers ---
 
 func toOldSession(s types.Session) oldtypes.Session {
@@ -196,6 +319,7 @@ func toOldSession(s types.Session) oldtypes.Session {
 			Metadata:       e.Metadata,
 			Environment:    e.Environment,
 			IdempotencyKey: e.IdempotencyKey,
+			SchemaVersion:  e.SchemaVersion,
 		}
 	}
 
@@ -246,6 +370,7 @@ func fromOldIncident(old *oldtypes.Incident) *types.Incident {
 		Status:              old.Status,
 		ConfidenceScore:     old.ConfidenceScore,
 		Suppressed:          old.Suppressed,
+		IssueID:             old.IssueID,
 		CreatedAt:           old.CreatedAt,
 		UpdatedAt:           old.UpdatedAt,
 	}
@@ -2,9 +2,12 @@ package engine
 
 import (
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	oldtypes "github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/ufse/scoring"
 	"github.com/your-org/frustration-engine/pkg/types"
 )
 
@@ -120,7 +123,7 @@ func TestDetectFrustration_RageClicks(t *testing.T) {
 		Timestamp: start.Add(5 * time.Second).Format(time.RFC3339),
 		SessionID: "test-rage",
 		Route:     "/checkout",
-		Metadata:  map[string]interface{}{"error": "Payment failed"},
+		Metadata:  map[string]interface{}{"message": "Payment failed"},
 	})
 
 	session := types.Session{
@@ -172,3 +175,38 @@ func TestClassifyEventType(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyScoreResult_RecordsBreakdown(t *testing.T) {
+	incident := &types.Incident{Explanation: "Rage clicks on #pay-btn."}
+	applyScoreResult(incident, scoring.ScoreResult{
+		Model:    scoring.ScorerLogistic,
+		Score:    73,
+		Features: scoring.Features{scoring.FeatureSignalCount: 2},
+		Contributions: []scoring.Contribution{
+			{Feature: scoring.FeatureSignalCount, Value: 2, Weight: 0.5, Contribution: 1},
+		},
+	})
+
+	if incident.ScoreModel != scoring.ScorerLogistic {
+		t.Errorf("ScoreModel = %q, want %q", incident.ScoreModel, scoring.ScorerLogistic)
+	}
+	if incident.ScoreFeatures[scoring.FeatureSignalCount] != 2 {
+		t.Errorf("ScoreFeatures not recorded: %v", incident.ScoreFeatures)
+	}
+	if len(incident.ScoreContributions) != 1 || incident.ScoreContributions[0].Contribution != 1 {
+		t.Errorf("ScoreContributions = %+v", incident.ScoreContributions)
+	}
+	if !strings.Contains(incident.Explanation, "signal_count +1.00") {
+		t.Errorf("Explanation missing breakdown: %q", incident.Explanation)
+	}
+}
+
+func TestErrorMessage_ReadsOnlyCanonicalMessage(t *testing.T) {
+	if got := errorMessage(oldtypes.Event{Metadata: map[string]interface{}{"message": "Payment failed"}}); got != "Payment failed" {
+		t.Errorf("errorMessage(message) = %q, want %q", got, "Payment failed")
+	}
+	// Aliases are upcast at ingest, so the engine never sees them
+	if got := errorMessage(oldtypes.Event{Metadata: map[string]interface{}{"error": "Payment failed"}}); got != "" {
+		t.Errorf("errorMessage(error) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,153 @@
+// Package enrich derives client context for ingested events on the server.
+//
+// The user agent is parsed into browser, OS and device class and given a bot
+// likelihood; the release comes from the X-App-Version header and the SDK
+// version from the ingest request; with an offline database the client IP
+// resolves to a country. The IP itself is not kept.
+//
+// The result is written to each event's metadata under ClientKey, replacing
+// whatever the client sent there. Sessions carry it in their metadata and
+// incidents as Incident.Client, so incidents can be filtered by it.
+package enrich
+
+import (
+	"context"
+	"net"
+	"net/netip"
+
+	"github.com/your-org/frustration-engine/internal/types"
+	pkgtypes "github.com/your-org/frustration-engine/pkg/types"
+)
+
+// ClientKey is the event and session metadata key holding the client context.
+const ClientKey = "client"
+
+// ReleaseHeader is the request header naming the app release.
+const ReleaseHeader = "X-App-Version"
+
+// Source is what the ingest request says about its client.
+type Source struct {
+	UserAgent  string
+	IP         string // with or without a port
+	Release    string
+	SDKVersion string
+}
+
+type sourceKey struct{}
+
+// WithSource returns a context carrying the request's Source for Enrich.
+func WithSource(ctx context.Context, src Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, src)
+}
+
+// SourceFrom returns the Source carried by ctx, if any.
+func SourceFrom(ctx context.Context) (Source, bool) {
+	src, ok := ctx.Value(sourceKey{}).(Source)
+	return src, ok
+}
+
+// Enricher attaches client context to events.
+type Enricher struct {
+	geo *GeoDB
+}
+
+// New creates an enricher. geo may be nil to skip country lookups.
+func New(geo *GeoDB) *Enricher {
+	return &Enricher{geo: geo}
+}
+
+// Enrich sets the client context of events from the Source in ctx. An event
+// whose metadata has a userAgent (e.g. forwarded by a backend) is parsed
+// from that instead of the request's.
+func (e *Enricher) Enrich(ctx context.Context, events []types.Event) {
+	src, _ := SourceFrom(ctx)
+	country := e.country(src.IP)
+
+	parsed := make(map[string]UserAgent)
+	for i := range events {
+		ua := src.UserAgent
+		if v, ok := events[i].Metadata["userAgent"].(string); ok && v != "" {
+			ua = v
+		}
+		info, ok := parsed[ua]
+		if !ok {
+			info = ParseUserAgent(ua)
+			parsed[ua] = info
+		}
+
+		client := pkgtypes.ClientContext{
+			Browser:        info.Browser,
+			BrowserVersion: info.BrowserVersion,
+			OS:             info.OS,
+			OSVersion:      info.OSVersion,
+			DeviceClass:    info.DeviceClass,
+			BotLikelihood:  info.BotLikelihood,
+			Release:        src.Release,
+			SDKVersion:     src.SDKVersion,
+			Country:        country,
+		}
+		if events[i].Metadata == nil {
+			events[i].Metadata = make(map[string]interface{})
+		}
+		events[i].Metadata[ClientKey] = ToMetadata(client)
+	}
+}
+
+func (e *Enricher) country(ip string) string {
+	if e.geo == nil || ip == "" {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	return e.geo.Country(addr)
+}
+
+// ToMetadata converts a client context to its metadata form, a map that
+// round-trips through JSON unchanged.
+func ToMetadata(c pkgtypes.ClientContext) map[string]interface{} {
+	m := make(map[string]interface{})
+	for key, v := range map[string]string{
+		"browser":        c.Browser,
+		"browserVersion": c.BrowserVersion,
+		"os":             c.OS,
+		"osVersion":      c.OSVersion,
+		"deviceClass":    c.DeviceClass,
+		"botLikelihood":  c.BotLikelihood,
+		"release":        c.Release,
+		"sdkVersion":     c.SDKVersion,
+		"country":        c.Country,
+	} {
+		if v != "" {
+			m[key] = v
+		}
+	}
+	return m
+}
+
+// FromMetadata reads the client context from event or session metadata.
+func FromMetadata(metadata map[string]interface{}) (pkgtypes.ClientContext, bool) {
+	m, ok := metadata[ClientKey].(map[string]interface{})
+	if !ok {
+		return pkgtypes.ClientContext{}, false
+	}
+	str := func(key string) string {
+		v, _ := m[key].(string)
+		return v
+	}
+	return pkgtypes.ClientContext{
+		Browser:        str("browser"),
+		BrowserVersion: str("browserVersion"),
+		OS:             str("os"),
+		OSVersion:      str("osVersion"),
+		DeviceClass:    str("deviceClass"),
+		BotLikelihood:  str("botLikelihood"),
+		Release:        str("release"),
+		SDKVersion:     str("sdkVersion"),
+		Country:        str("country"),
+	}, true
+}
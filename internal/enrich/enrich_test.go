@@ -0,0 +1,118 @@
+package enrich
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	tests := []struct {
+		name string
+		ua   string
+		want UserAgent
+	}{
+		{
+			"chrome on windows",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			UserAgent{"Chrome", "120", "Windows", "10", DeviceDesktop, BotLow},
+		},
+		{
+			"edge is not chrome",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.91",
+			UserAgent{"Edge", "120", "Windows", "10", DeviceDesktop, BotLow},
+		},
+		{
+			"safari on iphone",
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+			UserAgent{"Safari", "17", "iOS", "17.1", DeviceMobile, BotLow},
+		},
+		{
+			"chrome on android tablet",
+			"Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36",
+			UserAgent{"Chrome", "119", "Android", "13", DeviceTablet, BotLow},
+		},
+		{
+			"firefox on macos",
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:121.0) Gecko/20100101 Firefox/121.0",
+			UserAgent{"Firefox", "121", "macOS", "10.15", DeviceDesktop, BotLow},
+		},
+		{
+			"crawler",
+			"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			UserAgent{"", "", "", "", DeviceBot, BotHigh},
+		},
+		{
+			"headless browser",
+			"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/120.0.0.0 Safari/537.36",
+			UserAgent{"Chrome", "120", "Linux", "", DeviceBot, BotHigh},
+		},
+		{"http library", "python-requests/2.31.0", UserAgent{"", "", "", "", DeviceUnknown, BotMedium}},
+		{"empty", "", UserAgent{"", "", "", "", DeviceUnknown, BotMedium}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseUserAgent(tt.ua); got != tt.want {
+				t.Errorf("ParseUserAgent() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeoDB(t *testing.T) {
+	db, err := ReadGeoDB(strings.NewReader(`ip_start,ip_end,country
+1.0.0.0,1.0.0.255,au
+81.2.69.0,81.2.69.255,GB
+2001:db8::,2001:db8::ffff,DE
+`))
+	if err != nil {
+		t.Fatalf("ReadGeoDB: %v", err)
+	}
+	for ip, want := range map[string]string{
+		"1.0.0.7":            "AU",
+		"81.2.69.142":        "GB",
+		"::ffff:81.2.69.142": "GB",
+		"2001:db8::1":        "DE",
+		"1.0.1.0":            "",
+		"0.0.0.1":            "",
+	} {
+		if got := db.Country(netip.MustParseAddr(ip)); got != want {
+			t.Errorf("Country(%s) = %q, want %q", ip, got, want)
+		}
+	}
+
+	if _, err := ReadGeoDB(strings.NewReader("1.0.0.0,1.0.0.255,AU\nnot,an,ip\n")); err == nil {
+		t.Error("ReadGeoDB accepted an invalid range")
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	geo, _ := ReadGeoDB(strings.NewReader("81.2.69.0,81.2.69.255,GB\n"))
+	ctx := WithSource(context.Background(), Source{
+		UserAgent:  "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		IP:         "81.2.69.142:51234",
+		Release:    "2026.10.1",
+		SDKVersion: "1.4.0",
+	})
+	events := []types.Event{
+		{EventType: "click"},
+		{EventType: "click", Metadata: map[string]interface{}{
+			"userAgent": "Mozilla/5.0 (compatible; bingbot/2.0)",
+			"client":    map[string]interface{}{"botLikelihood": "low"}, // not trusted
+		}},
+	}
+	New(geo).Enrich(ctx, events)
+
+	first, ok := FromMetadata(events[0].Metadata)
+	if !ok || first.Browser != "Chrome" || first.Country != "GB" || first.Release != "2026.10.1" ||
+		first.SDKVersion != "1.4.0" || first.BotLikelihood != BotLow {
+		t.Errorf("first client = %+v", first)
+	}
+	// The event's own user agent wins over the request's
+	if second, _ := FromMetadata(events[1].Metadata); second.BotLikelihood != BotHigh || second.Country != "GB" {
+		t.Errorf("second client = %+v, want a bot from GB", second)
+	}
+}
@@ -0,0 +1,87 @@
+package enrich
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+// GeoDB resolves IP addresses to countries from an offline range database.
+type GeoDB struct {
+	ranges []ipRange // sorted by start, non-overlapping
+}
+
+type ipRange struct {
+	start, end netip.Addr
+	country    string
+}
+
+// LoadGeoDB reads a country database in the CSV layout of the DB-IP "IP to
+// Country Lite" download: one "first_ip,last_ip,country_code" range per
+// line, IPv4 or IPv6. Extra columns and a header line are ignored.
+func LoadGeoDB(path string) (*GeoDB, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open geo database: %w", err)
+	}
+	defer f.Close()
+	db, err := ReadGeoDB(f)
+	if err != nil {
+		return nil, fmt.Errorf("read geo database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// ReadGeoDB reads a country database in LoadGeoDB's format from r.
+func ReadGeoDB(r io.Reader) (*GeoDB, error) {
+	cr := csv.NewReader(bufio.NewReader(r))
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	db := &GeoDB{}
+	for line := 1; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) < 3 {
+			return nil, fmt.Errorf("line %d: want first_ip,last_ip,country", line)
+		}
+		start, startErr := netip.ParseAddr(strings.TrimSpace(rec[0]))
+		end, endErr := netip.ParseAddr(strings.TrimSpace(rec[1]))
+		if startErr != nil || endErr != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: invalid IP range %q-%q", line, rec[0], rec[1])
+		}
+		if start.Is4() != end.Is4() || end.Less(start) {
+			return nil, fmt.Errorf("line %d: invalid IP range %s-%s", line, start, end)
+		}
+		db.ranges = append(db.ranges, ipRange{start: start, end: end, country: strings.ToUpper(strings.TrimSpace(rec[2]))})
+	}
+
+	sort.Slice(db.ranges, func(i, j int) bool { return db.ranges[i].start.Less(db.ranges[j].start) })
+	return db, nil
+}
+
+// Country returns the country code of addr ("" when unknown).
+func (db *GeoDB) Country(addr netip.Addr) string {
+	addr = addr.Unmap()
+	// The last range starting at or before addr is the only one that can
+	// contain it
+	i := sort.Search(len(db.ranges), func(i int) bool { return addr.Less(db.ranges[i].start) }) - 1
+	if i < 0 || db.ranges[i].end.Less(addr) {
+		return ""
+	}
+	return db.ranges[i].country
+}
@@ -0,0 +1,146 @@
+package enrich
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Device classes.
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+	DeviceUnknown = "unknown"
+)
+
+// Bot likelihoods.
+const (
+	BotHigh   = "high"   // crawlers, monitors and headless browsers
+	BotMedium = "medium" // HTTP libraries and missing or non-browser user agents
+	BotLow    = "low"    // browsers
+)
+
+// UserAgent is what a User-Agent string says about the client.
+type UserAgent struct {
+	Browser        string
+	BrowserVersion string
+	OS             string
+	OSVersion      string
+	DeviceClass    string
+	BotLikelihood  string
+}
+
+// botTokens mark automated clients; libraryTokens mark HTTP libraries,
+// which are usually but not always automated.
+var (
+	botTokens = []string{
+		"bot", "crawler", "spider", "slurp", "scraper", "headlesschrome", "phantomjs",
+		"puppeteer", "playwright", "selenium", "webdriver", "lighthouse", "pingdom", "uptime",
+	}
+	libraryTokens = []string{
+		"curl/", "wget/", "python-requests", "python-urllib", "go-http-client", "axios/",
+		"node-fetch", "okhttp", "java/", "libwww", "httpclient",
+	}
+)
+
+// browserPatterns are tried in order: Chromium-based browsers name Chrome
+// and Safari too, so they come before them.
+var browserPatterns = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"Edge", regexp.MustCompile(`(?:Edg|Edge|EdgA|EdgiOS)/(\d+)`)},
+	{"Opera", regexp.MustCompile(`(?:OPR|Opera)/(\d+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/(\d+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/(\d+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/(\d+)`)},
+	{"Safari", regexp.MustCompile(`Version/(\d+).*Safari/`)},
+	{"Internet Explorer", regexp.MustCompile(`(?:MSIE |Trident/.*rv:)(\d+)`)},
+}
+
+var (
+	windowsVersion = regexp.MustCompile(`Windows NT (\d+\.\d+)`)
+	iosVersion     = regexp.MustCompile(`(?:iPhone OS|CPU OS) (\d+)[_.](\d+)`)
+	macVersion     = regexp.MustCompile(`Mac OS X (\d+)[_.](\d+)`)
+	androidVersion = regexp.MustCompile(`Android (\d+(?:\.\d+)?)`)
+)
+
+// windowsVersions names Windows NT versions (Windows 11 also reports 10.0).
+var windowsVersions = map[string]string{
+	"10.0": "10", "6.3": "8.1", "6.2": "8", "6.1": "7",
+}
+
+// ParseUserAgent parses a User-Agent header. Unrecognized parts are left
+// empty; the device class falls back to DeviceUnknown.
+func ParseUserAgent(ua string) UserAgent {
+	info := UserAgent{BotLikelihood: botLikelihood(ua)}
+
+	for _, p := range browserPatterns {
+		if m := p.re.FindStringSubmatch(ua); m != nil {
+			info.Browser, info.BrowserVersion = p.name, m[1]
+			break
+		}
+	}
+
+	switch {
+	case strings.Contains(ua, "Windows"):
+		info.OS = "Windows"
+		if m := windowsVersion.FindStringSubmatch(ua); m != nil {
+			info.OSVersion = windowsVersions[m[1]]
+		}
+	case strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPad") || strings.Contains(ua, "iPod"):
+		info.OS = "iOS"
+		if m := iosVersion.FindStringSubmatch(ua); m != nil {
+			info.OSVersion = m[1] + "." + m[2]
+		}
+	case strings.Contains(ua, "Android"):
+		info.OS = "Android"
+		if m := androidVersion.FindStringSubmatch(ua); m != nil {
+			info.OSVersion = m[1]
+		}
+	case strings.Contains(ua, "CrOS"):
+		info.OS = "ChromeOS"
+	case strings.Contains(ua, "Mac OS X"):
+		info.OS = "macOS"
+		if m := macVersion.FindStringSubmatch(ua); m != nil {
+			info.OSVersion = m[1] + "." + m[2]
+		}
+	case strings.Contains(ua, "Linux"):
+		info.OS = "Linux"
+	}
+
+	switch {
+	case info.BotLikelihood == BotHigh:
+		info.DeviceClass = DeviceBot
+	case strings.Contains(ua, "iPad") || strings.Contains(ua, "Tablet") ||
+		(info.OS == "Android" && !strings.Contains(ua, "Mobile")):
+		info.DeviceClass = DeviceTablet
+	case strings.Contains(ua, "Mobi") || strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPod"):
+		info.DeviceClass = DeviceMobile
+	case info.OS != "" && info.Browser != "":
+		info.DeviceClass = DeviceDesktop
+	default:
+		info.DeviceClass = DeviceUnknown
+	}
+	return info
+}
+
+// botLikelihood rates how likely a user agent is automated.
+func botLikelihood(ua string) string {
+	lower := strings.ToLower(ua)
+	for _, token := range botTokens {
+		if strings.Contains(lower, token) {
+			return BotHigh
+		}
+	}
+	if !strings.HasPrefix(ua, "Mozilla/") {
+		return BotMedium
+	}
+	for _, token := range libraryTokens {
+		if strings.Contains(lower, token) {
+			return BotMedium
+		}
+	}
+	return BotLow
+}
@@ -15,6 +15,8 @@
 package exporter
 
 import (
+	"math"
+
 	"github.com/your-org/frustration-engine/internal/types"
 )
 
@@ -41,6 +43,12 @@ func NewEligibilityChecker(exportThreshold float64, rateLimiter *RateLimiter) *E
 	}
 }
 
+// MinConfidence returns the lowest confidence score (0-100) IsEligible
+// accepts, so stores can leave the rest out of their candidates
+func (e *EligibilityChecker) MinConfidence() float64 {
+	return math.Max(minConfidenceForExport, e.exportThreshold) * 100
+}
+
 // IsEligible checks if incident is eligible for export
 func (e *EligibilityChecker) IsEligible(incident types.Incident) (bool, string) {
 	// Rule 1: status = confirmed
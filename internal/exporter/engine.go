@@ -9,11 +9,15 @@ package exporter
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/your-org/frustration-engine/internal/adapters"
 	"github.com/your-org/frustration-engine/internal/observability"
+	"github.com/your-org/frustration-engine/internal/resilience"
 	"github.com/your-org/frustration-engine/internal/types"
 )
 
@@ -25,9 +29,31 @@ type Engine struct {
 	priorityMapper  *PriorityMapper
 	adapter         adapters.Adapter
 	rateLimiter     *RateLimiter
+	issues          IssueSource
+	queue           JobQueue
+	retry           RetryConfig
+	now             func() time.Time
+
+	breakersMu sync.Mutex
+	breakers   map[string]*resilience.CircuitBreaker
 }
 
-// NewEngine creates a new exporter engine
+// Circuit breaker settings (per adapter)
+const (
+	breakerFailureThreshold = 5
+	breakerSuccessThreshold = 2
+	breakerResetTimeout     = time.Minute
+)
+
+// IssueSource resolves the cross-session issue an incident belongs to. When
+// set, the exporter files one ticket per issue and links later incidents of
+// the same issue to the existing ticket instead of filing duplicates
+type IssueSource interface {
+	GetIssueRef(ctx context.Context, issueID string) (types.IssueRef, bool, error)
+	MarkIssueExported(ctx context.Context, issueID, externalTicketID, externalSystem string) error
+}
+
+// NewEngine creates a new exporter engine with an in-memory job queue
 func NewEngine(store Store, adapter adapters.Adapter, exportThreshold float64, maxPerMinute int) *Engine {
 	rateLimiter := NewRateLimiter(maxPerMinute)
 	eligibility := NewEligibilityChecker(exportThreshold, rateLimiter)
@@ -41,24 +67,151 @@ func NewEngine(store Store, adapter adapters.Adapter, exportThreshold float64, m
 		priorityMapper: priorityMapper,
 		adapter:     adapter,
 		rateLimiter: rateLimiter,
+		queue:       NewMemoryJobQueue(),
+		retry:       DefaultRetryConfig(),
+		now:         time.Now,
+		breakers:    make(map[string]*resilience.CircuitBreaker),
+	}
+}
+
+// MinConfidence returns the lowest confidence score (0-100) the exporter
+// files tickets for
+func (e *Engine) MinConfidence() float64 {
+	return e.eligibility.MinConfidence()
+}
+
+// SetIssueSource makes issues, rather than individual incidents, the unit
+// that is turned into tickets
+func (e *Engine) SetIssueSource(issues IssueSource) {
+	e.issues = issues
+}
+
+// SetQueue replaces the job queue (e.g. with a FileJobQueue so jobs
+// survive restarts)
+func (e *Engine) SetQueue(queue JobQueue) {
+	e.queue = queue
+}
+
+// SetRetryConfig replaces the retry configuration
+func (e *Engine) SetRetryConfig(retry RetryConfig) {
+	e.retry = retry
+}
+
+// SetRateLimit allows max tickets per project in each period, replacing
+// the per-minute limit given to NewEngine
+func (e *Engine) SetRateLimit(max int, period time.Duration) {
+	e.rateLimiter.SetRate(max, period)
+}
+
+// SetTemplates renders tickets from per-project/adapter templates
+func (e *Engine) SetTemplates(templates *TicketTemplates) {
+	e.formatter.SetTemplates(templates)
+}
+
+// TicketPreview is a rendered ticket that was not exported
+type TicketPreview struct {
+	IncidentID  string            `json:"incidentId"`
+	IssueID     string            `json:"issueId,omitempty"`
+	Adapter     string            `json:"adapter"`
+	Priority    PriorityLevel     `json:"priority"`
+	Template    string            `json:"template"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Labels      []string          `json:"labels"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+// PreviewTicket renders the ticket that would be filed for an incident
+// through adapter (the engine's adapter if empty) without queuing it.
+// Eligibility and rate limits are not applied
+func (e *Engine) PreviewTicket(ctx context.Context, incident types.Incident, adapter string) (TicketPreview, error) {
+	if adapter == "" {
+		adapter = e.adapter.Name()
+	}
+	evidence := IncidentEvidence(incident)
+	issueID := ""
+	if ref, ok := e.issueRef(ctx, incident); ok {
+		evidence = ref.Evidence
+		issueID = ref.IssueID
+	}
+
+	priority := e.priorityMapper.GetPriorityForIncident(incident)
+	ticket, template, err := e.formatter.RenderTicket(adapter, incident, priority, evidence)
+	if err != nil {
+		return TicketPreview{}, err
 	}
+	return TicketPreview{
+		IncidentID:  incident.IncidentID,
+		IssueID:     issueID,
+		Adapter:     adapter,
+		Priority:    priority,
+		Template:    template,
+		Title:       ticket.Title,
+		Description: ticket.Description,
+		Labels:      ticket.Labels,
+		Metadata:    ticket.Metadata,
+	}, nil
 }
 
-// ExportEligible exports eligible incidents (up to max count)
+// Queue returns the job queue, for inspection
+func (e *Engine) Queue() JobQueue {
+	return e.queue
+}
+
+// ExportEligible queues newly eligible incidents, then runs up to maxCount
+// due export jobs
 func (e *Engine) ExportEligible(maxCount int) {
 	ctx := context.Background()
+	e.EnqueueEligible(ctx)
+	e.ProcessDue(ctx, maxCount)
+}
 
+// EnqueueEligible queues an export job for every eligible incident that
+// does not have one yet. It returns the number of jobs queued
+func (e *Engine) EnqueueEligible(ctx context.Context) int {
 	// Get eligible incidents
 	incidents, err := e.store.GetEligibleIncidents(ctx)
 	if err != nil {
 		log.Printf("[Ticket Exporter] Failed to get eligible incidents: %v", err)
-		return
+		return 0
 	}
 
-	exported := 0
+	queued := 0
 	for _, incident := range incidents {
-		if exported >= maxCount {
-			break // Rate limit reached
+		// Resolve the issue (one issue = one ticket)
+		evidence := IncidentEvidence(incident)
+		idempotencyKey := generateIdempotencyKey(incident)
+		issueRef, hasIssue := e.issueRef(ctx, incident)
+		if hasIssue {
+			if issueRef.ExternalTicketID != "" && issueRef.Regressed {
+				// Issue recurred after its ticket was resolved: reopen it
+				if e.enqueueReopen(ctx, incident, issueRef) {
+					queued++
+				}
+				continue
+			}
+			if issueRef.ExternalTicketID != "" {
+				// Issue already has a ticket: link this incident to it
+				if err := e.store.MarkExported(ctx, incident.IncidentID, issueRef.ExternalTicketID, issueRef.ExternalSystem); err != nil {
+					log.Printf("[Ticket Exporter] Failed to link incident %s to issue ticket: %v", incident.IncidentID, err)
+				}
+				observability.ExportsSkipped.WithLabelValues("issue_already_exported").Inc()
+				continue
+			}
+			evidence = issueRef.Evidence
+			idempotencyKey = "issue_" + issueRef.IssueID
+		}
+
+		// Already queued (or dead): don't spend rate limit on it again
+		if job, exists, _ := e.queue.Get(idempotencyKey); exists {
+			if job.State == JobDead {
+				// Its ticket will never be filed: stop offering the incident
+				if err := e.store.MarkExportFailed(ctx, incident.IncidentID); err != nil {
+					log.Printf("[Ticket Exporter] Failed to mark incident %s export failed: %v", incident.IncidentID, err)
+				}
+			}
+			observability.ExportsSkipped.WithLabelValues("already_queued").Inc()
+			continue
 		}
 
 		// Check eligibility
@@ -69,60 +222,233 @@ func (e *Engine) ExportEligible(maxCount int) {
 			continue
 		}
 
-		// Track export attempt
-		observability.ExportAttempts.Inc()
-
-		// Map priority (confidence + severity)
+		// Map priority (confidence + severity) and format ticket
 		priority := e.priorityMapper.GetPriorityForIncident(incident)
+		ticket, _, err := e.formatter.RenderTicket(e.adapter.Name(), incident, priority, evidence)
+		if err != nil {
+			log.Printf("[Ticket Exporter] Ticket template failed for incident %s, using built-in format: %v", incident.IncidentID, err)
+		}
 
-		// Format ticket (includes priority in metadata)
-		ticket := e.formatter.FormatTicket(incident, priority)
+		now := e.now()
+		job := Job{
+			ID:            idempotencyKey,
+			IncidentID:    incident.IncidentID,
+			Adapter:       e.adapter.Name(),
+			Incident:      incident,
+			Ticket:        ticket,
+			State:         JobPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if hasIssue {
+			job.IssueID = issueRef.IssueID
+		}
+		added, err := e.queue.Enqueue(job)
+		if err != nil {
+			log.Printf("[Ticket Exporter] Failed to queue export for incident %s: %v", incident.IncidentID, err)
+			continue
+		}
+		if added {
+			queued++
+		}
+	}
+	return queued
+}
 
-		// Generate idempotency key (one incident = one ticket)
-		idempotencyKey := generateIdempotencyKey(incident)
+// enqueueReopen queues a job that reopens a regressed issue's ticket, once
+// per regression. Incidents that arrive after it was queued, or that are not
+// eligible themselves, are linked to the ticket as before
+func (e *Engine) enqueueReopen(ctx context.Context, incident types.Incident, issueRef types.IssueRef) bool {
+	id := fmt.Sprintf("reopen_%s_%d", issueRef.IssueID, issueRef.RegressionCount)
+	_, exists, _ := e.queue.Get(id)
+	eligible, reason := false, "regression_already_reopened"
+	if !exists {
+		eligible, reason = e.eligibility.IsEligible(incident)
+	}
+	if !eligible {
+		if err := e.store.MarkExported(ctx, incident.IncidentID, issueRef.ExternalTicketID, issueRef.ExternalSystem); err != nil {
+			log.Printf("[Ticket Exporter] Failed to link incident %s to issue ticket: %v", incident.IncidentID, err)
+		}
+		observability.ExportsSkipped.WithLabelValues(reason).Inc()
+		return false
+	}
 
-		// Export to adapter with retry
-		var externalTicketID string
-		var exportErr error
-		
-		// Retry logic with exponential backoff
-		maxRetries := 3
-		backoff := 100 * time.Millisecond
-		for attempt := 0; attempt <= maxRetries; attempt++ {
-			externalTicketID, exportErr = e.adapter.CreateTicket(ctx, incident, ticket, idempotencyKey)
-			if exportErr == nil {
-				break // Success
-			}
-			
-			if attempt < maxRetries {
-				// Exponential backoff
-				time.Sleep(backoff)
-				backoff = backoff * 2
-				if backoff > 5*time.Second {
-					backoff = 5 * time.Second
-				}
+	now := e.now()
+	added, err := e.queue.Enqueue(Job{
+		ID:               id,
+		Kind:             JobReopen,
+		IncidentID:       incident.IncidentID,
+		IssueID:          issueRef.IssueID,
+		Adapter:          e.adapter.Name(),
+		Incident:         incident,
+		ExternalTicketID: issueRef.ExternalTicketID,
+		State:            JobPending,
+		NextAttemptAt:    now,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	})
+	if err != nil {
+		log.Printf("[Ticket Exporter] Failed to queue reopen of %s for incident %s: %v", issueRef.ExternalTicketID, incident.IncidentID, err)
+		return false
+	}
+	return added
+}
+
+// ProcessDue runs up to maxCount due export jobs. It returns the number of
+// jobs that succeeded
+func (e *Engine) ProcessDue(ctx context.Context, maxCount int) int {
+	jobs, err := e.queue.Due(e.now(), maxCount)
+	if err != nil {
+		log.Printf("[Ticket Exporter] Failed to read export queue: %v", err)
+		return 0
+	}
+
+	exported := 0
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		if e.runJob(ctx, job) {
+			exported++
+		}
+	}
+	return exported
+}
+
+// runJob makes one attempt at a job: create (or reopen) the ticket unless an
+// earlier attempt already did, then write it back to the incident store
+func (e *Engine) runJob(ctx context.Context, job Job) bool {
+	breaker := e.breaker(e.adapter.Name())
+	needsAdapter := job.ExternalTicketID == "" || (job.Kind == JobReopen && !job.Reopened)
+	if needsAdapter && !breaker.Allow() {
+		// Adapter is failing: leave the job due and try again next tick
+		observability.ExportsSkipped.WithLabelValues("circuit_open").Inc()
+		return false
+	}
+
+	job.State = JobInFlight
+	job.Attempts++
+	job.UpdatedAt = e.now()
+	e.updateJob(job)
+
+	if needsAdapter {
+		// Track export attempt
+		observability.ExportAttempts.Inc()
+
+		err := resilience.Retry(ctx, func() error {
+			return markRetryable(e.callAdapter(ctx, &job))
+		}, e.attemptRetryConfig())
+		if err != nil {
+			if adapters.IsRetryable(err) {
+				breaker.RecordFailure()
 			}
+			e.handleJobFailure(ctx, job, err)
+			return false
 		}
-		
-		if exportErr != nil {
-			// Track failure
-			observability.ExportFailures.Inc()
-			
-			// Handle failure (retry, mark failed)
-			HandleExportFailure(ctx, incident, exportErr, e.store)
-			continue
+		breaker.RecordSuccess()
+	}
+
+	if err := e.writeBack(ctx, job); err != nil {
+		// The ticket exists; the retry only repeats the write-back
+		e.handleJobFailure(ctx, job, err)
+		return false
+	}
+
+	job.State = JobSucceeded
+	job.LastError = ""
+	job.UpdatedAt = e.now()
+	e.updateJob(job)
+
+	// Track successful export
+	observability.ExportsSuccessful.Inc()
+	return true
+}
+
+// callAdapter creates the job's ticket, or reopens it for a reopen job.
+// Adapters that cannot reopen tickets leave the ticket as it is
+func (e *Engine) callAdapter(ctx context.Context, job *Job) error {
+	if job.Kind != JobReopen {
+		id, err := e.adapter.CreateTicket(ctx, job.Incident, job.Ticket, job.ID)
+		if err != nil {
+			return err
 		}
+		job.ExternalTicketID = id
+		return nil
+	}
+
+	reopener, ok := e.adapter.(adapters.TicketReopener)
+	if !ok {
+		log.Printf("[Ticket Exporter] %s cannot reopen tickets; linking regression of %s only", e.adapter.Name(), job.ExternalTicketID)
+		job.Reopened = true
+		return nil
+	}
+	if err := reopener.ReopenTicket(ctx, job.ExternalTicketID, regressionComment(job.Incident)); err != nil {
+		return err
+	}
+	job.Reopened = true
+	return nil
+}
+
+// regressionComment explains a regression on the reopened ticket
+func regressionComment(incident types.Incident) string {
+	return fmt.Sprintf("HawkEye: this issue recurred after it was resolved.\n\n"+
+		"New incident %s on %s (session %s, confidence %.0f%%), signals: %s.",
+		incident.IncidentID, incident.PrimaryFailurePoint, incident.SessionID,
+		incident.ConfidenceScore, strings.Join(incident.TriggeringSignals, ", "))
+}
 
-		// Mark as exported in store
-		if err := e.store.MarkExported(ctx, incident.IncidentID, externalTicketID, e.adapter.Name()); err != nil {
-			log.Printf("[Ticket Exporter] Failed to mark incident as exported: %v", err)
-			// Continue - ticket was created, just metadata update failed
+// writeBack records the ticket on the incident (and its issue)
+func (e *Engine) writeBack(ctx context.Context, job Job) error {
+	if err := e.store.MarkExported(ctx, job.IncidentID, job.ExternalTicketID, job.Adapter); err != nil {
+		return fmt.Errorf("write back ticket %s: %w", job.ExternalTicketID, err)
+	}
+	if job.IssueID != "" && e.issues != nil {
+		if err := e.issues.MarkIssueExported(ctx, job.IssueID, job.ExternalTicketID, job.Adapter); err != nil {
+			return fmt.Errorf("write back ticket %s to issue %s: %w", job.ExternalTicketID, job.IssueID, err)
 		}
+	}
+	return nil
+}
 
-		// Track successful export
-		observability.ExportsSuccessful.Inc()
-		exported++
+// attemptRetryConfig is the in-process retry config; only errors marked
+// retryable are retried
+func (e *Engine) attemptRetryConfig() resilience.RetryConfig {
+	cfg := e.retry.Attempt
+	cfg.RetryableErrors = []error{adapters.ErrRetryable}
+	return cfg
+}
+
+// breaker returns the circuit breaker for an adapter
+func (e *Engine) breaker(adapter string) *resilience.CircuitBreaker {
+	e.breakersMu.Lock()
+	defer e.breakersMu.Unlock()
+	cb, ok := e.breakers[adapter]
+	if !ok {
+		cb = resilience.NewCircuitBreaker("exporter-"+adapter, breakerFailureThreshold, breakerSuccessThreshold, breakerResetTimeout)
+		e.breakers[adapter] = cb
+	}
+	return cb
+}
+
+// updateJob persists a job's new state
+func (e *Engine) updateJob(job Job) {
+	if err := e.queue.Update(job); err != nil {
+		log.Printf("[Ticket Exporter] Failed to update export job %s: %v", job.ID, err)
+	}
+}
+
+// issueRef looks up the issue for an incident, if issues are configured
+func (e *Engine) issueRef(ctx context.Context, incident types.Incident) (types.IssueRef, bool) {
+	if e.issues == nil || incident.IssueID == "" {
+		return types.IssueRef{}, false
+	}
+	ref, ok, err := e.issues.GetIssueRef(ctx, incident.IssueID)
+	if err != nil {
+		log.Printf("[Ticket Exporter] Failed to resolve issue %s: %v", incident.IssueID, err)
+		return types.IssueRef{}, false
 	}
+	return ref, ok
 }
 
 // generateIdempotencyKey generates idempotency key for incident
@@ -0,0 +1,298 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/adapters"
+	"github.com/your-org/frustration-engine/internal/resilience"
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// fakeStore is an in-memory Store that records write-backs
+type fakeStore struct {
+	mu           sync.Mutex
+	incidents    []types.Incident
+	exported     map[string]string
+	failed       map[string]bool
+	writeBackErr error
+}
+
+func newFakeStore(incidents ...types.Incident) *fakeStore {
+	return &fakeStore{incidents: incidents, exported: make(map[string]string), failed: make(map[string]bool)}
+}
+
+func (s *fakeStore) GetEligibleIncidents(ctx context.Context) ([]types.Incident, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []types.Incident
+	for _, inc := range s.incidents {
+		if s.exported[inc.IncidentID] == "" {
+			out = append(out, inc)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeStore) MarkExported(ctx context.Context, incidentID, externalTicketID, externalSystem string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.writeBackErr != nil {
+		return s.writeBackErr
+	}
+	s.exported[incidentID] = externalTicketID
+	return nil
+}
+
+func (s *fakeStore) MarkExportFailed(ctx context.Context, incidentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed[incidentID] = true
+	return nil
+}
+
+// fakeAdapter fails with err (if set) and otherwise files numbered tickets
+type fakeAdapter struct {
+	calls int
+	err   error
+}
+
+func (a *fakeAdapter) Name() string { return "fake" }
+
+func (a *fakeAdapter) CreateTicket(ctx context.Context, incident types.Incident, ticket types.Ticket, idempotencyKey string) (string, error) {
+	a.calls++
+	if a.err != nil {
+		return "", a.err
+	}
+	return fmt.Sprintf("FAKE-%d", a.calls), nil
+}
+
+func (a *fakeAdapter) GetTicket(ctx context.Context, ticketID string) (*adapters.TicketInfo, error) {
+	return nil, nil
+}
+
+func confirmedIncident(id string) types.Incident {
+	return types.Incident{
+		IncidentID:      id,
+		ProjectID:       "web",
+		Status:          "confirmed",
+		ConfidenceScore: 92,
+		SeverityType:    "Bug",
+		Timestamp:       time.Now(),
+	}
+}
+
+// newTestEngine returns an engine with no in-process retries and a
+// controllable clock
+func newTestEngine(store Store, adapter adapters.Adapter) (*Engine, *time.Time) {
+	e := NewEngine(store, adapter, 0.7, 100)
+	retry := DefaultRetryConfig()
+	retry.MaxAttempts = 3
+	retry.Attempt = resilience.RetryConfig{MaxRetries: 0}
+	e.SetRetryConfig(retry)
+	clock := time.Now()
+	e.now = func() time.Time { return clock }
+	return e, &clock
+}
+
+func TestEngine_RetryableFailureIsRescheduledThenWrittenBack(t *testing.T) {
+	store := newFakeStore(confirmedIncident("inc-1"))
+	adapter := &fakeAdapter{err: &adapters.HTTPError{System: "fake", StatusCode: 503}}
+	e, clock := newTestEngine(store, adapter)
+	ctx := context.Background()
+
+	e.ExportEligible(10)
+	job, _, _ := e.Queue().Get("incident_inc-1")
+	if job.State != JobFailed || !job.NextAttemptAt.After(*clock) {
+		t.Fatalf("after 503: job = %+v, want failed with a future retry", job)
+	}
+
+	// Not due yet: no new attempt
+	e.ExportEligible(10)
+	if adapter.calls != 1 {
+		t.Fatalf("retried before NextAttemptAt (%d calls)", adapter.calls)
+	}
+
+	adapter.err = nil
+	*clock = job.NextAttemptAt
+	if n := e.ProcessDue(ctx, 10); n != 1 {
+		t.Fatalf("ProcessDue = %d, want 1", n)
+	}
+	if store.exported["inc-1"] != "FAKE-2" {
+		t.Errorf("ticket not written back: %v", store.exported)
+	}
+	job, _, _ = e.Queue().Get("incident_inc-1")
+	if job.State != JobSucceeded || job.Attempts != 2 {
+		t.Errorf("job = %+v, want succeeded after 2 attempts", job)
+	}
+}
+
+func TestEngine_PermanentFailureIsDead(t *testing.T) {
+	store := newFakeStore(confirmedIncident("inc-1"))
+	adapter := &fakeAdapter{err: fmt.Errorf("jira: %w: bad field", adapters.ErrPermanent)}
+	e, _ := newTestEngine(store, adapter)
+
+	e.ExportEligible(10)
+	e.ExportEligible(10)
+
+	job, _, _ := e.Queue().Get("incident_inc-1")
+	if job.State != JobDead || adapter.calls != 1 {
+		t.Errorf("job = %+v after %d calls, want dead after 1", job, adapter.calls)
+	}
+	if !store.failed["inc-1"] {
+		t.Error("incident should be marked export_failed")
+	}
+}
+
+func TestEngine_WriteBackFailureDoesNotRecreateTicket(t *testing.T) {
+	store := newFakeStore(confirmedIncident("inc-1"))
+	store.writeBackErr = errors.New("incident store unavailable")
+	adapter := &fakeAdapter{}
+	e, clock := newTestEngine(store, adapter)
+	ctx := context.Background()
+
+	e.ExportEligible(10)
+	job, _, _ := e.Queue().Get("incident_inc-1")
+	if job.State != JobFailed || job.ExternalTicketID != "FAKE-1" {
+		t.Fatalf("job = %+v, want failed with the created ticket kept", job)
+	}
+
+	store.writeBackErr = nil
+	*clock = job.NextAttemptAt
+	e.ProcessDue(ctx, 10)
+	if adapter.calls != 1 || store.exported["inc-1"] != "FAKE-1" {
+		t.Errorf("calls = %d, exported = %v; want the original ticket written back", adapter.calls, store.exported)
+	}
+}
+
+func TestEngine_CircuitBreakerStopsCallingFailingAdapter(t *testing.T) {
+	var incidents []types.Incident
+	for i := 0; i < breakerFailureThreshold+3; i++ {
+		inc := confirmedIncident(fmt.Sprintf("inc-%d", i))
+		inc.ProjectID = inc.IncidentID // one project each, so the export rate limit doesn't interfere
+		incidents = append(incidents, inc)
+	}
+	adapter := &fakeAdapter{err: errors.New("connection refused")}
+	e, _ := newTestEngine(newFakeStore(incidents...), adapter)
+
+	e.ExportEligible(len(incidents))
+	if adapter.calls != breakerFailureThreshold {
+		t.Errorf("adapter called %d times, want %d before the breaker opens", adapter.calls, breakerFailureThreshold)
+	}
+	pending, _ := e.Queue().List(JobPending)
+	if len(pending) != 3 {
+		t.Errorf("%d jobs left pending, want 3", len(pending))
+	}
+}
+
+func TestFileJobQueue_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export-queue.json")
+	q, err := NewFileJobQueue(path)
+	if err != nil {
+		t.Fatalf("NewFileJobQueue: %v", err)
+	}
+	q.Enqueue(Job{ID: "incident_a", State: JobPending})
+	q.Enqueue(Job{ID: "incident_b", State: JobPending})
+	q.Update(Job{ID: "incident_b", State: JobInFlight})
+	if added, _ := q.Enqueue(Job{ID: "incident_a"}); added {
+		t.Error("duplicate job should not be enqueued")
+	}
+
+	reopened, err := NewFileJobQueue(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	due, _ := reopened.Due(time.Now(), 0)
+	if len(due) != 2 {
+		t.Errorf("due after restart = %d, want 2 (in-flight job reset to pending)", len(due))
+	}
+}
+
+// fakeIssues is an IssueSource with a single issue
+type fakeIssues struct {
+	ref types.IssueRef
+}
+
+func (f *fakeIssues) GetIssueRef(ctx context.Context, issueID string) (types.IssueRef, bool, error) {
+	return f.ref, issueID == f.ref.IssueID, nil
+}
+
+func (f *fakeIssues) MarkIssueExported(ctx context.Context, issueID, externalTicketID, externalSystem string) error {
+	return nil
+}
+
+// reopenAdapter records reopened tickets
+type reopenAdapter struct {
+	fakeAdapter
+	reopened []string
+}
+
+func (a *reopenAdapter) ReopenTicket(ctx context.Context, ticketID, comment string) error {
+	a.reopened = append(a.reopened, ticketID)
+	return nil
+}
+
+func TestEngine_RegressionReopensOriginalTicketOnce(t *testing.T) {
+	first, second := confirmedIncident("inc-1"), confirmedIncident("inc-2")
+	first.IssueID, second.IssueID = "issue-1", "issue-1"
+	second.ProjectID = "other" // avoid the per-project rate limit
+	store := newFakeStore(first, second)
+	adapter := &reopenAdapter{}
+	e, _ := newTestEngine(store, adapter)
+	issues := &fakeIssues{ref: types.IssueRef{IssueID: "issue-1", ExternalTicketID: "FAKE-7", ExternalSystem: "fake", Regressed: true, RegressionCount: 1}}
+	e.SetIssueSource(issues)
+
+	e.ExportEligible(10)
+	if adapter.calls != 0 || len(adapter.reopened) != 1 || adapter.reopened[0] != "FAKE-7" {
+		t.Fatalf("created %d tickets, reopened %v; want FAKE-7 reopened once and nothing created", adapter.calls, adapter.reopened)
+	}
+	if store.exported["inc-1"] != "FAKE-7" || store.exported["inc-2"] != "FAKE-7" {
+		t.Errorf("incidents not linked to the original ticket: %v", store.exported)
+	}
+
+	// The next regression reopens it again
+	issues.ref.RegressionCount = 2
+	third := confirmedIncident("inc-3")
+	third.IssueID, third.ProjectID = "issue-1", "third"
+	store.incidents = append(store.incidents, third)
+	e.ExportEligible(10)
+	if len(adapter.reopened) != 2 {
+		t.Errorf("second regression: reopened %v, want 2 reopens", adapter.reopened)
+	}
+}
+
+func TestRetryConfig_JitterOnlySpreadsScheduledAttempts(t *testing.T) {
+	c := DefaultRetryConfig()
+	base := resilience.Backoff(c.Schedule, 1)
+
+	c.Jitter = nil
+	if got := c.nextAttemptDelay(2, errors.New("boom")); got != base {
+		t.Errorf("delay without jitter = %v, want %v", got, base)
+	}
+	c.Jitter = func() float64 { return 0.5 }
+	if got, want := c.nextAttemptDelay(2, errors.New("boom")), base+time.Duration(float64(base)*0.1); got != want {
+		t.Errorf("delay with jitter = %v, want %v", got, want)
+	}
+	// The shared backoff stays deterministic for every other caller
+	if again := resilience.Backoff(c.Schedule, 1); again != base {
+		t.Errorf("resilience.Backoff changed between calls: %v then %v", base, again)
+	}
+}
+
+func TestRateLimiter_SetRateSpreadsLimitOverPeriod(t *testing.T) {
+	r := NewRateLimiter(10)
+	r.SetRate(10, 5*time.Minute)
+	if r.minInterval != 30*time.Second {
+		t.Errorf("10 per 5m spaces exports %s apart, want 30s", r.minInterval)
+	}
+
+	r.SetRate(1, time.Hour)
+	if r.minInterval != time.Hour {
+		t.Errorf("1 per hour spaces exports %s apart, want 1h", r.minInterval)
+	}
+}
@@ -1,50 +1,41 @@
 /**
  * Failure Handling
- * 
- * Author: Grace Lee (Team Beta)
+ *
  * Responsibility: Handle export failures gracefully
- * 
+ *
  * Rules:
- * - On API failure: retry with backoff
+ * - On API failure: schedule a retry with backoff
  * - On partial success: recover via idempotency
- * - On permanent failure: mark export_failed
+ * - On permanent failure (or retries exhausted): mark export_failed
  */
 
 package exporter
 
 import (
 	"context"
-	"errors"
 	"log"
 
-	"github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/adapters"
+	"github.com/your-org/frustration-engine/internal/observability"
 )
 
-var (
-	ErrExportFailed = errors.New("export failed")
-	ErrPermanentFailure = errors.New("permanent export failure")
-)
-
-// HandleExportFailure handles export failure
-func HandleExportFailure(
-	ctx context.Context,
-	incident types.Incident,
-	err error,
-	store Store,
-) {
-	// Log failure
-	log.Printf("[Ticket Exporter] Export failed for incident %s: %v", incident.IncidentID, err)
-
-	// Retry with exponential backoff
-	retryErr := RetryWithBackoff(ctx, func() error {
-		// Attempt export again (idempotency key prevents duplicates)
-		return err // Placeholder - would retry actual export
-	})
+// handleJobFailure records a failed attempt. The job is rescheduled, or
+// moved to dead (and the incident marked export_failed) when the failure is
+// permanent or its attempts are exhausted
+func (e *Engine) handleJobFailure(ctx context.Context, job Job, err error) {
+	log.Printf("[Ticket Exporter] Export failed for incident %s (attempt %d): %v", job.IncidentID, job.Attempts, err)
+	observability.ExportFailures.Inc()
 
-	if retryErr != nil {
-		// Permanent failure - mark in store
-		if markErr := store.MarkExportFailed(ctx, incident.IncidentID); markErr != nil {
+	job.LastError = err.Error()
+	job.UpdatedAt = e.now()
+	if !adapters.IsRetryable(err) || job.Attempts >= e.retry.MaxAttempts {
+		job.State = JobDead
+		if markErr := e.store.MarkExportFailed(ctx, job.IncidentID); markErr != nil {
 			log.Printf("[Ticket Exporter] Failed to mark export_failed: %v", markErr)
 		}
+	} else {
+		job.State = JobFailed
+		job.NextAttemptAt = job.UpdatedAt.Add(e.retry.nextAttemptDelay(job.Attempts, err))
 	}
-}
\ No newline at end of file
+	e.updateJob(job)
+}
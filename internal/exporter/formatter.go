@@ -12,11 +12,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/your-org/frustration-engine/internal/route"
 	"github.com/your-org/frustration-engine/internal/types"
 )
 
 // Formatter formats incidents into ticket content
 type Formatter struct {
+	templates *TicketTemplates
 }
 
 // NewFormatter creates a new formatter
@@ -24,13 +26,82 @@ func NewFormatter() *Formatter {
 	return &Formatter{}
 }
 
+// SetTemplates renders tickets from templates where one matches the
+// incident's project and adapter; others keep the built-in content
+func (f *Formatter) SetTemplates(templates *TicketTemplates) {
+	f.templates = templates
+}
+
+// RenderTicket formats the ticket for an adapter, using the matching template
+// if any. It returns the template used (BuiltInTemplate if none). On a
+// template error the built-in ticket is returned with the error
+func (f *Formatter) RenderTicket(adapter string, incident types.Incident, priority PriorityLevel, evidence types.Evidence) (types.Ticket, string, error) {
+	if f.templates == nil {
+		return f.FormatIssueTicket(incident, priority, evidence), BuiltInTemplate, nil
+	}
+	tmpl, ok := f.templates.match(incident.ProjectID, adapter)
+	if !ok {
+		return f.FormatIssueTicket(incident, priority, evidence), BuiltInTemplate, nil
+	}
+
+	link, err := f.templates.SessionLink(incident)
+	if err != nil {
+		return f.FormatIssueTicket(incident, priority, evidence), BuiltInTemplate, err
+	}
+	data := f.ticketData(adapter, incident, priority, evidence, link)
+	ticket, err := tmpl.render(data)
+	if err != nil {
+		return data.Default, BuiltInTemplate, err
+	}
+	return ticket, tmpl.Name, nil
+}
+
+// ticketData builds the data model templates are executed against
+func (f *Formatter) ticketData(adapter string, incident types.Incident, priority PriorityLevel, evidence types.Evidence, sessionLink string) TicketData {
+	route := extractRoute(incident.PrimaryFailurePoint)
+	sessionCount := evidence.AffectedSessions
+	if sessionCount == 0 {
+		sessionCount = 1
+	}
+	return TicketData{
+		Incident: incident,
+		Issue: TicketIssue{
+			ID:               incident.IssueID,
+			AffectedSessions: sessionCount,
+			TimeWindow:       evidence.TimeWindow,
+			ExampleSessions:  f.getExampleSessions(incident, evidence),
+		},
+		Priority:   priority,
+		Adapter:    adapter,
+		Route:      route,
+		Feature:    getFeatureName(route),
+		SignalType: getPrimarySignalType(incident.TriggeringSignals),
+		Reasoning: TicketReasoning{
+			Explanation:      incident.Explanation,
+			ConfidenceScore:  incident.ConfidenceScore,
+			ConfidenceReason: f.getConfidenceReason(incident, evidence),
+			Signals:          incident.SignalDetails,
+			ScoreModel:       incident.ScoreModel,
+			ScoreFeatures:    incident.ScoreFeatures,
+		},
+		SessionLink: sessionLink,
+		Default:     f.FormatIssueTicket(incident, priority, evidence),
+	}
+}
+
 // FormatTicket formats incident into ticket content
 func (f *Formatter) FormatTicket(incident types.Incident, priority PriorityLevel) types.Ticket {
+	return f.FormatIssueTicket(incident, priority, IncidentEvidence(incident))
+}
+
+// FormatIssueTicket formats a ticket for the issue an incident belongs to,
+// using cross-session evidence (affected sessions, example sessions)
+func (f *Formatter) FormatIssueTicket(incident types.Incident, priority PriorityLevel, evidence types.Evidence) types.Ticket {
 	// Generate title (short, specific, non-AI-sounding)
 	title := f.generateTitle(incident)
 
 	// Generate structured description
-	description := f.generateDescription(incident)
+	description := f.generateDescription(incident, evidence)
 
 	// Generate labels/metadata (includes priority)
 	labels := f.generateLabels(incident)
@@ -74,6 +145,9 @@ func (f *Formatter) generateTitle(incident types.Incident) string {
 	case "confusion":
 		// Example: "Users repeatedly exit and re-enter security settings"
 		return fmt.Sprintf("Users repeatedly exit and re-enter %s", getFeatureName(route))
+	case "input_struggle":
+		// Example: "Users struggle to fill #email on /signup"
+		return fmt.Sprintf("Users struggle to fill %s on %s", getComponentName(incident), route)
 	default:
 		// Example: "Profile save fails without user-visible error"
 		return fmt.Sprintf("User action fails without feedback on %s", route)
@@ -81,7 +155,7 @@ func (f *Formatter) generateTitle(incident types.Incident) string {
 }
 
 // generateDescription generates structured description (matching gold-standard examples)
-func (f *Formatter) generateDescription(incident types.Incident) string {
+func (f *Formatter) generateDescription(incident types.Incident, evidence types.Evidence) string {
 	var parts []string
 
 	// 1. Summary (matching example format exactly)
@@ -104,13 +178,12 @@ func (f *Formatter) generateDescription(incident types.Incident) string {
 
 	// 4. Evidence (matching example format exactly)
 	parts = append(parts, "## Evidence")
-	evidence := f.formatEvidence(incident)
-	parts = append(parts, evidence)
+	parts = append(parts, f.formatEvidence(incident, evidence))
 	parts = append(parts, "")
 
 	// 5. Confidence (matching example format, transparency rule)
 	parts = append(parts, "## Confidence")
-	confidence := fmt.Sprintf("%.2f — %s", incident.ConfidenceScore/100.0, f.getConfidenceReason(incident))
+	confidence := fmt.Sprintf("%.2f — %s", incident.ConfidenceScore/100.0, f.getConfidenceReason(incident, evidence))
 	parts = append(parts, confidence)
 	parts = append(parts, "")
 
@@ -146,6 +219,8 @@ func (f *Formatter) generateSummary(incident types.Incident) string {
 		return fmt.Sprintf("Users abandon a workflow after encountering friction, without completing the intended action.")
 	case "confusion":
 		return fmt.Sprintf("Users appear unable to locate expected functionality and repeatedly navigate away and back to the same page.")
+	case "input_struggle":
+		return fmt.Sprintf("Users repeatedly edit, clear, or fail validation on the same form field without moving on.")
 	default:
 		return fmt.Sprintf("Users experience frustration when attempting to complete an action on %s.", route)
 	}
@@ -197,6 +272,8 @@ func (f *Formatter) describeFailure(incident types.Incident) string {
 	case "confusion":
 		// Example: "Users navigate into the Security Settings page, scroll without interacting, exit, and re-enter multiple times without completing an action."
 		return "Users navigate to the page, scroll or interact minimally, exit, and re-enter multiple times without completing an action."
+	case "input_struggle":
+		return "Users keep re-entering the value of a single field: clearing and retyping it, pasting and deleting, or hitting the same validation error repeatedly."
 	default:
 		// Example: "After clicking 'Save', no success or error message is shown and changes are not persisted."
 		return "After attempting the action, no success or error message is shown and the expected outcome does not occur."
@@ -204,18 +281,20 @@ func (f *Formatter) describeFailure(incident types.Incident) string {
 }
 
 // formatEvidence formats evidence section (matching example format exactly)
-func (f *Formatter) formatEvidence(incident types.Incident) string {
+func (f *Formatter) formatEvidence(incident types.Incident, evidence types.Evidence) string {
 	var parts []string
 
 	// Number of affected sessions (matching example format)
-	sessionCount := len(incident.SignalDetails)
+	sessionCount := evidence.AffectedSessions
 	if sessionCount == 0 {
 		sessionCount = 1
 	}
 	parts = append(parts, fmt.Sprintf("- %d affected user sessions", sessionCount))
 
 	// Time window (matching example format)
-	if len(incident.SignalDetails) > 0 {
+	if evidence.TimeWindow != "" {
+		parts = append(parts, fmt.Sprintf("- Observed %s", evidence.TimeWindow))
+	} else if len(incident.SignalDetails) > 0 {
 		firstSignal := incident.SignalDetails[0].Timestamp
 		lastSignal := incident.SignalDetails[len(incident.SignalDetails)-1].Timestamp
 
@@ -242,13 +321,16 @@ func (f *Formatter) formatEvidence(incident types.Incident) string {
 	case "confusion":
 		parts = append(parts, "- Repeated page entry within short time windows")
 		parts = append(parts, "- No settings changed during sessions")
+	case "input_struggle":
+		parts = append(parts, "- Repeated clear-and-retype or validation errors on one field")
+		parts = append(parts, "- Only value lengths and edit counts were captured")
 	default:
 		parts = append(parts, "- Action triggered but expected outcome not observed")
 		parts = append(parts, "- No error feedback shown")
 	}
 
 	// Example session references (matching example format)
-	exampleSessions := f.getExampleSessions(incident)
+	exampleSessions := f.getExampleSessions(incident, evidence)
 	if len(exampleSessions) > 0 {
 		parts = append(parts, fmt.Sprintf("- Example sessions: %s", strings.Join(exampleSessions, ", ")))
 	} else {
@@ -259,11 +341,11 @@ func (f *Formatter) formatEvidence(incident types.Incident) string {
 }
 
 // getExampleSessions gets example session IDs (up to 3)
-func (f *Formatter) getExampleSessions(incident types.Incident) []string {
-	sessions := []string{incident.SessionID}
-
-	// If we have signal details, we could extract more session IDs
-	// For now, just return the main session ID
+func (f *Formatter) getExampleSessions(incident types.Incident, evidence types.Evidence) []string {
+	sessions := evidence.ExampleSessionRefs
+	if len(sessions) == 0 {
+		sessions = []string{incident.SessionID}
+	}
 	if len(sessions) > 3 {
 		return sessions[:3]
 	}
@@ -287,9 +369,9 @@ func formatDate(t time.Time) string {
 }
 
 // getConfidenceReason gets confidence reason (matching example format)
-func (f *Formatter) getConfidenceReason(incident types.Incident) string {
+func (f *Formatter) getConfidenceReason(incident types.Incident, evidence types.Evidence) string {
 	signalCount := len(incident.TriggeringSignals)
-	sessionCount := len(incident.SignalDetails)
+	sessionCount := evidence.AffectedSessions
 	if sessionCount == 0 {
 		sessionCount = 1
 	}
@@ -300,9 +382,21 @@ func (f *Formatter) getConfidenceReason(incident types.Incident) string {
 	if sessionCount > 10 {
 		return fmt.Sprintf("repeated patterns suggest clear issue rather than isolated incident")
 	}
+	if sessionCount == 1 {
+		return fmt.Sprintf("repeated patterns observed within a single session")
+	}
 	return fmt.Sprintf("repeated patterns observed across multiple sessions")
 }
 
+// IncidentEvidence builds single-session evidence for an incident that does
+// not belong to a cross-session issue
+func IncidentEvidence(incident types.Incident) types.Evidence {
+	return types.Evidence{
+		AffectedSessions:   1,
+		ExampleSessionRefs: []string{incident.SessionID},
+	}
+}
+
 // shouldIncludeNotes determines if notes should be included
 func (f *Formatter) shouldIncludeNotes(incident types.Incident) bool {
 	// Include notes if we have a hypothesis or if confidence is medium
@@ -321,6 +415,8 @@ func (f *Formatter) generateNotes(incident types.Incident) string {
 		return "Possible client-side validation or API error not surfaced to the user."
 	case "confusion":
 		return "May indicate unclear labeling or missing affordances."
+	case "input_struggle":
+		return "Field format requirements may be unclear or validation messages unhelpful."
 	default:
 		return "Backend errors may not be surfaced to the UI."
 	}
@@ -360,17 +456,13 @@ func (f *Formatter) generateMetadata(incident types.Incident, priority PriorityL
 
 // Helper functions
 func extractRoute(failurePoint string) string {
-	parts := strings.Split(failurePoint, ":")
-	if len(parts) > 0 {
-		return parts[0]
-	}
-	return failurePoint
+	return route.SplitFailurePoint(failurePoint)[0]
 }
 
 func extractAction(failurePoint string) string {
-	parts := strings.Split(failurePoint, ":")
+	parts := route.SplitFailurePoint(failurePoint)
 	if len(parts) >= 3 {
-		return parts[2]
+		return parts[len(parts)-1]
 	}
 	return "action"
 }
@@ -431,10 +523,9 @@ func getFeatureName(route string) string {
 // getComponentName extracts component name from incident
 func getComponentName(incident types.Incident) string {
 	// Try to extract from failure point
-	failurePoint := incident.PrimaryFailurePoint
-	parts := strings.Split(failurePoint, ":")
+	parts := route.SplitFailurePoint(incident.PrimaryFailurePoint)
 	if len(parts) >= 2 {
-		return parts[1]
+		return strings.Join(parts[1:max(2, len(parts)-1)], ":")
 	}
 
 	// Try to extract from signal details
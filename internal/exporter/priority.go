@@ -120,24 +120,31 @@ func (p *PriorityMapper) GetJiraPriority(priority PriorityLevel) string {
 	}
 }
 
-// GetLinearPriority maps priority to Linear priority (0-4 scale)
+// GetLinearPriority maps priority to Linear priority
+// (0 = none, 1 = urgent, 2 = high, 3 = medium, 4 = low)
 func (p *PriorityMapper) GetLinearPriority(priority PriorityLevel) int {
 	switch priority {
 	case PriorityP0:
-		return 4 // Urgent
+		return 1 // Urgent
 	case PriorityP1:
-		return 3 // High
+		return 2 // High
 	case PriorityP2:
-		return 2 // Medium
+		return 3 // Medium
 	case PriorityP3:
-		return 1 // Low
+		return 4 // Low
 	case PriorityP4:
-		return 0 // Backlog
+		return 0 // Backlog (no priority)
 	default:
-		return 2
+		return 3
 	}
 }
 
+// LinearPriority maps a priority string (as stored in ticket metadata) to
+// Linear priority; it matches adapters.LinearConfig.Priority
+func (p *PriorityMapper) LinearPriority(priority string) int {
+	return p.GetLinearPriority(PriorityLevel(priority))
+}
+
 // GetPriorityForIncident gets priority for an incident
 func (p *PriorityMapper) GetPriorityForIncident(incident types.Incident) PriorityLevel {
 	return p.MapPriority(incident.ConfidenceScore, incident.SeverityType)
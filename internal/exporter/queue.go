@@ -0,0 +1,281 @@
+/**
+ * Export Job Queue
+ *
+ * Responsibility: Durable queue of ticket export jobs
+ *
+ * Lifecycle:
+ * - pending: enqueued, waiting for its first attempt
+ * - in_flight: an attempt is running
+ * - failed: the last attempt failed, a retry is scheduled at NextAttemptAt
+ * - succeeded: the ticket exists and was written back to the incident store
+ * - dead: permanent failure or retries exhausted; needs an operator
+ *
+ * Jobs are keyed by idempotency key, so an incident (or issue) is only ever
+ * queued once. Reopen jobs are keyed by issue and regression number, so each
+ * regression reopens the original ticket once
+ */
+
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// maxSucceededJobs caps how many succeeded jobs are retained; the oldest are
+// dropped first. Written-back incidents are no longer eligible, so dropping
+// their jobs cannot cause a duplicate export
+const maxSucceededJobs = 1000
+
+// JobState is the state of an export job
+type JobState string
+
+const (
+	JobPending   JobState = "pending"
+	JobInFlight  JobState = "in_flight"
+	JobFailed    JobState = "failed"
+	JobSucceeded JobState = "succeeded"
+	JobDead      JobState = "dead"
+)
+
+// JobKind is what an export job does with the ticket system
+type JobKind string
+
+const (
+	// JobCreate files a new ticket (the zero value, for jobs queued before
+	// kinds existed)
+	JobCreate JobKind = ""
+	// JobReopen reopens (or comments on) ExternalTicketID after a regression
+	JobReopen JobKind = "reopen"
+)
+
+// Job is a queued ticket export
+type Job struct {
+	ID         string         `json:"id"` // idempotency key
+	Kind       JobKind        `json:"kind,omitempty"`
+	IncidentID string         `json:"incidentId"`
+	IssueID    string         `json:"issueId,omitempty"`
+	Adapter    string         `json:"adapter"`
+	Incident   types.Incident `json:"incident"`
+	Ticket     types.Ticket   `json:"ticket"`
+	State      JobState       `json:"state"`
+	Attempts   int            `json:"attempts"`
+	LastError  string         `json:"lastError,omitempty"`
+	// ExternalTicketID is set as soon as the adapter has created the ticket,
+	// so a failed write-back is retried without creating the ticket again
+	ExternalTicketID string `json:"externalTicketId,omitempty"`
+	// Reopened is set once a reopen job's ticket was reopened
+	Reopened      bool      `json:"reopened,omitempty"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// JobQueue stores export jobs
+type JobQueue interface {
+	// Enqueue adds a job; it returns false if a job with the same ID exists
+	Enqueue(job Job) (bool, error)
+	// Update replaces a job by ID
+	Update(job Job) error
+	Get(id string) (Job, bool, error)
+	// Due returns up to limit pending or failed jobs whose NextAttemptAt has
+	// passed, oldest first
+	Due(now time.Time, limit int) ([]Job, error)
+	// List returns jobs in a state (all if empty), newest first
+	List(state JobState) ([]Job, error)
+}
+
+// MemoryJobQueue is an in-memory JobQueue. It is lost on restart
+type MemoryJobQueue struct {
+	mu   sync.RWMutex
+	jobs map[string]Job
+}
+
+// NewMemoryJobQueue creates an empty in-memory job queue
+func NewMemoryJobQueue() *MemoryJobQueue {
+	return &MemoryJobQueue{jobs: make(map[string]Job)}
+}
+
+// Enqueue adds a job unless one with the same ID exists
+func (q *MemoryJobQueue) Enqueue(job Job) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, exists := q.jobs[job.ID]; exists {
+		return false, nil
+	}
+	q.jobs[job.ID] = job
+	return true, nil
+}
+
+// Update replaces a job
+func (q *MemoryJobQueue) Update(job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, exists := q.jobs[job.ID]; !exists {
+		return fmt.Errorf("export job %s not found", job.ID)
+	}
+	q.jobs[job.ID] = job
+	if job.State == JobSucceeded {
+		q.prune()
+	}
+	return nil
+}
+
+// prune drops the oldest succeeded jobs beyond maxSucceededJobs. Callers
+// must hold the write lock
+func (q *MemoryJobQueue) prune() {
+	var succeeded []Job
+	for _, job := range q.jobs {
+		if job.State == JobSucceeded {
+			succeeded = append(succeeded, job)
+		}
+	}
+	if len(succeeded) <= maxSucceededJobs {
+		return
+	}
+	sort.Slice(succeeded, func(i, j int) bool { return succeeded[i].UpdatedAt.Before(succeeded[j].UpdatedAt) })
+	for _, job := range succeeded[:len(succeeded)-maxSucceededJobs] {
+		delete(q.jobs, job.ID)
+	}
+}
+
+// Get returns a job by ID
+func (q *MemoryJobQueue) Get(id string) (Job, bool, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	job, ok := q.jobs[id]
+	return job, ok, nil
+}
+
+// Due returns jobs ready for an attempt, oldest first
+func (q *MemoryJobQueue) Due(now time.Time, limit int) ([]Job, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	var due []Job
+	for _, job := range q.jobs {
+		if (job.State == JobPending || job.State == JobFailed) && !job.NextAttemptAt.After(now) {
+			due = append(due, job)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].CreatedAt.Before(due[j].CreatedAt) })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// List returns jobs in a state (or all), newest first
+func (q *MemoryJobQueue) List(state JobState) ([]Job, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	out := make([]Job, 0, len(q.jobs))
+	for _, job := range q.jobs {
+		if state == "" || job.State == state {
+			out = append(out, job)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+// FileJobQueue is a JobQueue persisted as a JSON file, so queued and dead
+// jobs survive restarts. The file is rewritten atomically on every change
+type FileJobQueue struct {
+	path string
+	mem  *MemoryJobQueue
+	mu   sync.Mutex // serializes writes to path
+}
+
+// NewFileJobQueue opens (or creates) a job queue at path. Jobs left
+// in_flight by a crash are reset to pending; the idempotency key makes the
+// repeated attempt safe
+func NewFileJobQueue(path string) (*FileJobQueue, error) {
+	q := &FileJobQueue{path: path, mem: NewMemoryJobQueue()}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read export queue: %w", err)
+	}
+	var jobs []Job
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &jobs); err != nil {
+			return nil, fmt.Errorf("parse export queue: %w", err)
+		}
+	}
+	for _, job := range jobs {
+		if job.State == JobInFlight {
+			job.State = JobPending
+		}
+		q.mem.jobs[job.ID] = job
+	}
+	return q, nil
+}
+
+// Enqueue adds a job unless one with the same ID exists, and persists
+func (q *FileJobQueue) Enqueue(job Job) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	added, _ := q.mem.Enqueue(job)
+	if !added {
+		return false, nil
+	}
+	return true, q.flush()
+}
+
+// Update replaces a job and persists
+func (q *FileJobQueue) Update(job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.mem.Update(job); err != nil {
+		return err
+	}
+	return q.flush()
+}
+
+// Get returns a job by ID
+func (q *FileJobQueue) Get(id string) (Job, bool, error) {
+	return q.mem.Get(id)
+}
+
+// Due returns jobs ready for an attempt, oldest first
+func (q *FileJobQueue) Due(now time.Time, limit int) ([]Job, error) {
+	return q.mem.Due(now, limit)
+}
+
+// List returns jobs in a state (or all), newest first
+func (q *FileJobQueue) List(state JobState) ([]Job, error) {
+	return q.mem.List(state)
+}
+
+// flush writes the queue to a temp file and renames it over path
+func (q *FileJobQueue) flush() error {
+	jobs, _ := q.mem.List("")
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(q.path), filepath.Base(q.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write export queue: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write export queue: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write export queue: %w", err)
+	}
+	return os.Rename(tmp.Name(), q.path)
+}
@@ -14,24 +14,32 @@ import (
 
 // RateLimiter limits export rate per project
 type RateLimiter struct {
-	mu           sync.RWMutex
-	lastExport   map[string]time.Time
-	maxPerMinute int
-	minInterval  time.Duration
+	mu          sync.RWMutex
+	lastExport  map[string]time.Time
+	minInterval time.Duration
 }
 
 // NewRateLimiter creates a new rate limiter
 func NewRateLimiter(maxPerMinute int) *RateLimiter {
-	minInterval := time.Minute / time.Duration(maxPerMinute)
+	r := &RateLimiter{lastExport: make(map[string]time.Time)}
+	r.SetRate(maxPerMinute, time.Minute)
+	return r
+}
+
+// SetRate allows max exports per project in each period, spaced evenly
+// (at most one per second)
+func (r *RateLimiter) SetRate(max int, period time.Duration) {
+	if max <= 0 {
+		max = 1
+	}
+	minInterval := period / time.Duration(max)
 	if minInterval < time.Second {
 		minInterval = time.Second
 	}
 
-	return &RateLimiter{
-		lastExport:   make(map[string]time.Time),
-		maxPerMinute: maxPerMinute,
-		minInterval:  minInterval,
-	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.minInterval = minInterval
 }
 
 // Allow checks if export is allowed for project
@@ -5,55 +5,79 @@
  * Responsibility: Retry with exponential backoff
  *
  * Rules:
- * - Exponential backoff
+ * - Exponential backoff with jitter
  * - Max retry cap
  * - Never create duplicates
+ *
+ * Two layers: a few quick in-process retries absorb blips within one
+ * attempt; anything longer is a scheduled retry of the queued job, so the
+ * exporter never blocks a tick waiting out an outage
  */
 
 package exporter
 
 import (
-	"context"
+	"errors"
+	"fmt"
+	"math/rand"
 	"time"
-)
 
-const (
-	maxRetries     = 3
-	initialBackoff = 1 * time.Second
+	"github.com/your-org/frustration-engine/internal/adapters"
+	"github.com/your-org/frustration-engine/internal/resilience"
 )
 
 // RetryConfig holds retry configuration
 type RetryConfig struct {
-	MaxRetries   int
-	InitialDelay time.Duration
+	// MaxAttempts is the number of scheduled attempts before a job is dead
+	MaxAttempts int
+	// Attempt retries transient failures within a single attempt
+	Attempt resilience.RetryConfig
+	// Schedule spaces scheduled attempts of a failed job
+	Schedule resilience.RetryConfig
+	// Jitter returns a random fraction in [0, 1) of the 20% jitter added to
+	// each scheduled delay, so jobs that failed together do not all retry
+	// together. Nil adds none
+	Jitter func() float64
 }
 
-// RetryWithBackoff retries operation with exponential backoff
-func RetryWithBackoff(ctx context.Context, fn func() error) error {
-	var lastErr error
-	delay := initialBackoff
-
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		err := fn()
-		if err == nil {
-			return nil // Success
-		}
-
-		lastErr = err
-
-		// Don't retry on last attempt
-		if attempt == maxRetries-1 {
-			break
-		}
+// DefaultRetryConfig returns the default export retry configuration
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts: 8,
+		Attempt: resilience.RetryConfig{
+			MaxRetries:        2,
+			InitialBackoff:    100 * time.Millisecond,
+			MaxBackoff:        time.Second,
+			BackoffMultiplier: 2,
+		},
+		Schedule: resilience.RetryConfig{
+			InitialBackoff:    30 * time.Second,
+			MaxBackoff:        time.Hour,
+			BackoffMultiplier: 2,
+		},
+		Jitter: rand.Float64,
+	}
+}
 
-		// Exponential backoff
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(delay):
-			delay *= 2 // Double the delay
-		}
+// nextAttemptDelay returns how long to wait before the next scheduled
+// attempt, honouring a ticket system's Retry-After if it asks for longer
+func (c RetryConfig) nextAttemptDelay(attempts int, err error) time.Duration {
+	delay := resilience.Backoff(c.Schedule, attempts-1)
+	if c.Jitter != nil {
+		delay += time.Duration(float64(delay) * 0.2 * c.Jitter())
+	}
+	var httpErr *adapters.HTTPError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > delay {
+		delay = httpErr.RetryAfter
 	}
+	return delay
+}
 
-	return lastErr
+// markRetryable makes unclassified errors (network failures) match
+// adapters.ErrRetryable, so resilience.Retry retries them
+func markRetryable(err error) error {
+	if err != nil && adapters.IsRetryable(err) && !errors.Is(err, adapters.ErrRetryable) {
+		return fmt.Errorf("%w: %v", adapters.ErrRetryable, err)
+	}
+	return err
 }
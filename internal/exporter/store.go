@@ -13,10 +13,12 @@
 package exporter
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/your-org/frustration-engine/internal/types"
@@ -87,18 +89,16 @@ func (s *IncidentStore) GetEligibleIncidents(ctx context.Context) ([]types.Incid
 	return result.Incidents, nil
 }
 
-// MarkExported marks incident as exported
+// MarkExported writes the external ticket back to the incident
 func (s *IncidentStore) MarkExported(ctx context.Context, incidentID, externalTicketID, externalSystem string) error {
 	if s.incidentStoreURL == "" {
 		fmt.Printf("[Ticket Exporter] Incident Store URL not configured, logging export: %s -> %s\n", incidentID, externalTicketID)
 		return nil
 	}
-
-	// Update via Incident Store API (would need PATCH endpoint)
-	// For now, just log
-	fmt.Printf("[Ticket Exporter] Marked incident %s as exported (ticket: %s, system: %s)\n", 
-		incidentID, externalTicketID, externalSystem)
-	return nil
+	return s.patchExport(ctx, incidentID, types.ExportUpdateRequest{
+		ExternalTicketID: externalTicketID,
+		ExternalSystem:   externalSystem,
+	})
 }
 
 // MarkExportFailed marks incident export as failed
@@ -107,9 +107,29 @@ func (s *IncidentStore) MarkExportFailed(ctx context.Context, incidentID string)
 		fmt.Printf("[Ticket Exporter] Incident Store URL not configured, logging export failure: %s\n", incidentID)
 		return nil
 	}
+	return s.patchExport(ctx, incidentID, types.ExportUpdateRequest{ExportFailed: true})
+}
 
-	// Update via Incident Store API (would need PATCH endpoint)
-	// For now, just log
-	fmt.Printf("[Ticket Exporter] Marked incident %s export as failed\n", incidentID)
+// patchExport sends an export update to the Incident Store
+func (s *IncidentStore) patchExport(ctx context.Context, incidentID string, update types.ExportUpdateRequest) error {
+	body, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	endpoint := s.incidentStoreURL + "/v1/incidents/" + url.PathEscape(incidentID) + "/export"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("update incident %s: %w", incidentID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("update incident %s: Incident Store returned status %d", incidentID, resp.StatusCode)
+	}
 	return nil
 }
@@ -0,0 +1,317 @@
+/**
+ * Ticket Status Sync
+ *
+ * Responsibility: Reflect external ticket status back onto incidents
+ *
+ * Mapping:
+ * - open (any status not listed below): no change
+ * - resolved (done, fixed, completed, closed): incident status "resolved"
+ * - won't fix (declined, not planned, canceled): incident suppressed
+ * - invalid (cannot reproduce, not a bug, duplicate): incident suppressed
+ *   and recorded as a false alarm
+ *
+ * Suppressed incidents are negatives for cmd/calibrate, so ticket triage
+ * feeds back into scoring. Resolved tickets also resolve their issues (as of
+ * the ticket's resolution time), so a recurrence can be detected as a
+ * regression
+ *
+ * Status arrives by polling (SyncOnce) or pushed by the ticket system's
+ * signed webhooks (ParseTicketWebhook + ApplyTicketStatus)
+ */
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/adapters"
+	"github.com/your-org/frustration-engine/internal/observability"
+	"github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/ufse"
+)
+
+// TicketOutcome is how a ticket ended up, independent of the ticket system
+type TicketOutcome string
+
+const (
+	TicketOpen     TicketOutcome = "open"
+	TicketResolved TicketOutcome = "resolved"
+	TicketWontFix  TicketOutcome = "wont_fix"
+	TicketInvalid  TicketOutcome = "invalid"
+)
+
+// Incident statuses set by the sync
+const (
+	StatusResolved   = "resolved"
+	StatusSuppressed = "suppressed"
+)
+
+// Ticket states by outcome, in normalizeTicketState form. Invalid and
+// won't-fix are checked before resolved, since most systems close those
+// tickets too
+var (
+	invalidStates  = []string{"invalid", "cannot reproduce", "cant reproduce", "not a bug", "works as designed", "false positive", "duplicate"}
+	wontFixStates  = []string{"wont fix", "wontfix", "wont do", "not planned", "canceled", "cancelled", "declined", "obsolete", "rejected"}
+	resolvedStates = []string{"done", "resolved", "fixed", "completed", "closed"}
+)
+
+// ErrTicketWebhooksUnsupported is returned by ParseTicketWebhook when the
+// adapter's ticket system cannot push ticket changes
+var ErrTicketWebhooksUnsupported = errors.New("ticket system does not push ticket changes")
+
+// SyncStore reads and updates incidents that have tickets
+type SyncStore interface {
+	// GetExportedIncidents returns incidents with a ticket whose outcome is
+	// not yet known (not resolved or suppressed)
+	GetExportedIncidents(ctx context.Context) ([]types.Incident, error)
+	UpdateIncidentStatus(ctx context.Context, incidentID, status string, suppressed bool) error
+}
+
+// IssueResolver records that an issue's ticket was resolved
+type IssueResolver interface {
+	ResolveIssue(ctx context.Context, issueID string, resolvedAt time.Time) error
+}
+
+// SyncResult summarizes a sync run
+type SyncResult struct {
+	Tickets  int `json:"tickets"`
+	Resolved int `json:"resolved"`
+	WontFix  int `json:"wontFix"`
+	Invalid  int `json:"invalid"`
+	Errors   int `json:"errors"`
+}
+
+// Syncer polls the ticket system for incidents exported through adapter
+type Syncer struct {
+	store   SyncStore
+	adapter adapters.Adapter
+	issues  IssueResolver
+}
+
+// NewSyncer creates a ticket status syncer
+func NewSyncer(store SyncStore, adapter adapters.Adapter) *Syncer {
+	return &Syncer{store: store, adapter: adapter}
+}
+
+// SetIssueResolver resolves the issues of incidents whose tickets are fixed
+func (s *Syncer) SetIssueResolver(issues IssueResolver) {
+	s.issues = issues
+}
+
+// Start runs SyncOnce every interval until ctx is cancelled
+func (s *Syncer) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := s.SyncOnce(ctx)
+			if err != nil {
+				log.Printf("[exporter] ticket sync failed: %v", err)
+				continue
+			}
+			if result.Resolved+result.WontFix+result.Invalid > 0 {
+				log.Printf("[exporter] ticket sync: %d tickets, %d resolved, %d won't fix, %d invalid",
+					result.Tickets, result.Resolved, result.WontFix, result.Invalid)
+			}
+		}
+	}
+}
+
+// SyncOnce fetches each open ticket once and applies its outcome to every
+// incident linked to it
+func (s *Syncer) SyncOnce(ctx context.Context) (SyncResult, error) {
+	var result SyncResult
+
+	byTicket, err := s.incidentsByTicket(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for ticketID, incidents := range byTicket {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		result.Tickets++
+
+		info, err := s.adapter.GetTicket(ctx, ticketID)
+		if err != nil || info == nil {
+			log.Printf("[exporter] get ticket %s: %v", ticketID, err)
+			observability.TicketSyncOutcomes.WithLabelValues("error").Inc()
+			result.Errors++
+			continue
+		}
+		s.apply(ctx, *info, ticketID, incidents, &result)
+	}
+	return result, nil
+}
+
+// ParseTicketWebhook verifies a ticket change pushed by the ticket system
+// against secret and decodes it (see adapters.TicketWebhookParser). A nil
+// ticket means the delivery carried no ticket change
+func (s *Syncer) ParseTicketWebhook(header http.Header, body []byte, secret string) (*adapters.TicketInfo, error) {
+	parser, ok := s.adapter.(adapters.TicketWebhookParser)
+	if !ok {
+		return nil, ErrTicketWebhooksUnsupported
+	}
+	return parser.ParseTicketWebhook(header, body, secret)
+}
+
+// ApplyTicketStatus applies a status pushed by the ticket system (e.g. an
+// adapter webhook) instead of waiting for the next poll
+func (s *Syncer) ApplyTicketStatus(ctx context.Context, info adapters.TicketInfo) (SyncResult, error) {
+	var result SyncResult
+
+	byTicket, err := s.incidentsByTicket(ctx)
+	if err != nil {
+		return result, err
+	}
+	incidents, ok := byTicket[info.ID]
+	if !ok {
+		return result, nil
+	}
+	result.Tickets = 1
+	s.apply(ctx, info, info.ID, incidents, &result)
+	return result, nil
+}
+
+// incidentsByTicket groups this adapter's open exported incidents by ticket
+func (s *Syncer) incidentsByTicket(ctx context.Context) (map[string][]types.Incident, error) {
+	incidents, err := s.store.GetExportedIncidents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get exported incidents: %w", err)
+	}
+	byTicket := make(map[string][]types.Incident)
+	for _, incident := range incidents {
+		if incident.ExternalTicketID == "" || incident.ExternalSystem != s.adapter.Name() {
+			continue
+		}
+		byTicket[incident.ExternalTicketID] = append(byTicket[incident.ExternalTicketID], incident)
+	}
+	return byTicket, nil
+}
+
+// apply writes a ticket's outcome onto its incidents and records the
+// feedback
+func (s *Syncer) apply(ctx context.Context, info adapters.TicketInfo, ticketID string, incidents []types.Incident, result *SyncResult) {
+	outcome := ClassifyTicket(info)
+	resolvedAt := info.ResolvedAt
+	if resolvedAt.IsZero() {
+		// The ticket system did not report when: it was closed by now
+		resolvedAt = time.Now()
+	}
+
+	var status string
+	var suppressed bool
+	switch outcome {
+	case TicketResolved:
+		status = StatusResolved
+	case TicketWontFix, TicketInvalid:
+		status, suppressed = StatusSuppressed, true
+	default:
+		return
+	}
+
+	resolvedIssues := make(map[string]bool)
+	for _, incident := range incidents {
+		if err := s.store.UpdateIncidentStatus(ctx, incident.IncidentID, status, suppressed); err != nil {
+			log.Printf("[exporter] update incident %s from ticket %s: %v", incident.IncidentID, ticketID, err)
+			observability.TicketSyncOutcomes.WithLabelValues("error").Inc()
+			result.Errors++
+			continue
+		}
+		observability.TicketSyncOutcomes.WithLabelValues(string(outcome)).Inc()
+
+		switch outcome {
+		case TicketResolved:
+			result.Resolved++
+			if incident.IssueID != "" && s.issues != nil && !resolvedIssues[incident.IssueID] {
+				resolvedIssues[incident.IssueID] = true
+				if err := s.issues.ResolveIssue(ctx, incident.IssueID, resolvedAt); err != nil {
+					log.Printf("[exporter] resolve issue %s from ticket %s: %v", incident.IssueID, ticketID, err)
+				}
+			}
+		case TicketWontFix:
+			result.WontFix++
+			recordTicketSuppression(incident, ufse.ReasonTicketWontFix, outcome)
+		case TicketInvalid:
+			result.Invalid++
+			recordTicketSuppression(incident, ufse.ReasonFalseAlarmDetected, outcome)
+		}
+	}
+}
+
+// recordTicketSuppression adds a ticket-driven suppression to the audit log
+func recordTicketSuppression(incident types.Incident, reason ufse.SuppressionReason, outcome TicketOutcome) {
+	ufse.RecordGlobalSuppression(ufse.SuppressionEvent{
+		SessionID:        incident.SessionID,
+		Route:            incident.PrimaryFailurePoint,
+		SignalType:       strings.Join(incident.TriggeringSignals, ","),
+		Reason:           reason,
+		ReasonDetails:    fmt.Sprintf("%s ticket %s closed as %s", incident.ExternalSystem, incident.ExternalTicketID, outcome),
+		DetectorName:     "ticket_sync",
+		ConfidenceLevel:  incident.ConfidenceLevel,
+		FrustrationScore: incident.FrustrationScore,
+		RelatedSignals:   incident.TriggeringSignals,
+		Metadata: map[string]interface{}{
+			"incidentId":       incident.IncidentID,
+			"externalTicketId": incident.ExternalTicketID,
+		},
+	})
+}
+
+// ClassifyTicket maps a ticket's resolution, status and labels to an outcome.
+// An invalid or won't-fix resolution or status wins over a resolved one;
+// labels only refine a ticket that is already closed
+func ClassifyTicket(info adapters.TicketInfo) TicketOutcome {
+	resolution := normalizeTicketState(info.Resolution)
+	status := normalizeTicketState(info.Status)
+
+	if matchesTicketState(resolution, invalidStates) || matchesTicketState(status, invalidStates) {
+		return TicketInvalid
+	}
+	if matchesTicketState(resolution, wontFixStates) || matchesTicketState(status, wontFixStates) {
+		return TicketWontFix
+	}
+
+	if !matchesTicketState(resolution, resolvedStates) && !matchesTicketState(status, resolvedStates) {
+		return TicketOpen
+	}
+	for _, label := range info.Labels {
+		label = normalizeTicketState(label)
+		if matchesTicketState(label, invalidStates) {
+			return TicketInvalid
+		}
+		if matchesTicketState(label, wontFixStates) {
+			return TicketWontFix
+		}
+	}
+	return TicketResolved
+}
+
+// normalizeTicketState lower-cases a state and drops apostrophes and
+// turns underscores and hyphens into spaces, so "Won't Fix", "wont_fix" and
+// "won't-fix" all read "wont fix"
+func normalizeTicketState(state string) string {
+	state = strings.ToLower(strings.TrimSpace(state))
+	state = strings.NewReplacer("'", "", "’", "", "_", " ", "-", " ").Replace(state)
+	return state
+}
+
+func matchesTicketState(state string, states []string) bool {
+	for _, s := range states {
+		if state == s {
+			return true
+		}
+	}
+	return false
+}
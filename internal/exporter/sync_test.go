@@ -0,0 +1,163 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/adapters"
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// fakeSyncStore records incident status updates
+type fakeSyncStore struct {
+	mu        sync.Mutex
+	incidents []types.Incident
+	updates   map[string]string
+}
+
+func (s *fakeSyncStore) GetExportedIncidents(ctx context.Context) ([]types.Incident, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []types.Incident
+	for _, inc := range s.incidents {
+		if s.updates[inc.IncidentID] == "" {
+			out = append(out, inc)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeSyncStore) UpdateIncidentStatus(ctx context.Context, incidentID, status string, suppressed bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updates[incidentID] = status
+	return nil
+}
+
+// ticketAdapter serves fixed ticket states and counts lookups
+type ticketAdapter struct {
+	fakeAdapter
+	tickets map[string]adapters.TicketInfo
+	lookups int
+}
+
+func (a *ticketAdapter) GetTicket(ctx context.Context, ticketID string) (*adapters.TicketInfo, error) {
+	a.lookups++
+	info := a.tickets[ticketID]
+	return &info, nil
+}
+
+func exportedIncident(id, ticketID string) types.Incident {
+	inc := confirmedIncident(id)
+	inc.ExternalTicketID = ticketID
+	inc.ExternalSystem = "fake"
+	return inc
+}
+
+func TestClassifyTicket(t *testing.T) {
+	cases := []struct {
+		info adapters.TicketInfo
+		want TicketOutcome
+	}{
+		{adapters.TicketInfo{Status: "In Progress"}, TicketOpen},
+		{adapters.TicketInfo{Status: "open", Labels: []string{"invalid"}}, TicketOpen},
+		{adapters.TicketInfo{Status: "Done", Resolution: "Done"}, TicketResolved},
+		{adapters.TicketInfo{Status: "Done", Resolution: "Won't Do"}, TicketWontFix},
+		{adapters.TicketInfo{Status: "Closed", Resolution: "Cannot Reproduce"}, TicketInvalid},
+		{adapters.TicketInfo{Status: "Canceled", Resolution: "canceled"}, TicketWontFix},  // Linear
+		{adapters.TicketInfo{Status: "Duplicate", Resolution: "canceled"}, TicketInvalid}, // Linear
+		{adapters.TicketInfo{Status: "closed", Resolution: "not_planned"}, TicketWontFix}, // GitHub
+		{adapters.TicketInfo{Status: "closed", Resolution: "completed"}, TicketResolved},
+		{adapters.TicketInfo{Status: "closed", Labels: []string{"bug", "invalid"}}, TicketInvalid},
+		{adapters.TicketInfo{Status: "closed", Labels: []string{"wontfix"}}, TicketWontFix},
+	}
+	for _, c := range cases {
+		if got := ClassifyTicket(c.info); got != c.want {
+			t.Errorf("ClassifyTicket(%+v) = %s, want %s", c.info, got, c.want)
+		}
+	}
+}
+
+func TestSyncer_AppliesTicketOutcomes(t *testing.T) {
+	store := &fakeSyncStore{
+		incidents: []types.Incident{
+			exportedIncident("inc-1", "T-1"),
+			exportedIncident("inc-2", "T-1"), // same issue, same ticket
+			exportedIncident("inc-3", "T-2"),
+			exportedIncident("inc-4", "T-3"),
+			exportedIncident("inc-5", "T-4"),
+		},
+		updates: make(map[string]string),
+	}
+	other := exportedIncident("inc-6", "T-5")
+	other.ExternalSystem = "jira"
+	store.incidents = append(store.incidents, other)
+
+	adapter := &ticketAdapter{tickets: map[string]adapters.TicketInfo{
+		"T-1": {ID: "T-1", Status: "Done", Resolution: "Fixed"},
+		"T-2": {ID: "T-2", Status: "Closed", Resolution: "Won't Fix"},
+		"T-3": {ID: "T-3", Status: "Closed", Resolution: "Not a Bug"},
+		"T-4": {ID: "T-4", Status: "In Progress"},
+	}}
+
+	result, err := NewSyncer(store, adapter).SyncOnce(context.Background())
+	if err != nil {
+		t.Fatalf("SyncOnce: %v", err)
+	}
+	if adapter.lookups != 4 {
+		t.Errorf("GetTicket called %d times, want once per ticket of this adapter (4)", adapter.lookups)
+	}
+	if result.Resolved != 2 || result.WontFix != 1 || result.Invalid != 1 {
+		t.Errorf("result = %+v", result)
+	}
+
+	want := map[string]string{
+		"inc-1": StatusResolved,
+		"inc-2": StatusResolved,
+		"inc-3": StatusSuppressed,
+		"inc-4": StatusSuppressed,
+	}
+	for id, status := range want {
+		if store.updates[id] != status {
+			t.Errorf("%s = %q, want %q", id, store.updates[id], status)
+		}
+	}
+	if _, ok := store.updates["inc-5"]; ok {
+		t.Error("incident with an open ticket should not change")
+	}
+}
+
+// fakeIssueResolver records when each issue was resolved
+type fakeIssueResolver map[string]time.Time
+
+func (r fakeIssueResolver) ResolveIssue(ctx context.Context, issueID string, resolvedAt time.Time) error {
+	r[issueID] = resolvedAt
+	return nil
+}
+
+func TestSyncer_ResolvesIssueAtTicketResolutionTime(t *testing.T) {
+	inc := exportedIncident("inc-1", "T-1")
+	inc.IssueID = "iss-1"
+	store := &fakeSyncStore{incidents: []types.Incident{inc}, updates: make(map[string]string)}
+	syncer := NewSyncer(store, &ticketAdapter{})
+	issues := fakeIssueResolver{}
+	syncer.SetIssueResolver(issues)
+
+	closedAt := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	if _, err := syncer.ApplyTicketStatus(context.Background(), adapters.TicketInfo{ID: "T-1", Status: "Done", ResolvedAt: closedAt}); err != nil {
+		t.Fatalf("ApplyTicketStatus: %v", err)
+	}
+	if !issues["iss-1"].Equal(closedAt) {
+		t.Errorf("issue resolved at %v, want the ticket's %v", issues["iss-1"], closedAt)
+	}
+}
+
+func TestSyncer_ParseTicketWebhookNeedsParser(t *testing.T) {
+	syncer := NewSyncer(&fakeSyncStore{}, &ticketAdapter{})
+	if _, err := syncer.ParseTicketWebhook(nil, []byte(`{}`), "s"); !errors.Is(err, ErrTicketWebhooksUnsupported) {
+		t.Errorf("err = %v, want ErrTicketWebhooksUnsupported", err)
+	}
+}
@@ -0,0 +1,324 @@
+/**
+ * Ticket Templates
+ *
+ * Responsibility: Render ticket content from text/template templates
+ * configured per project and adapter
+ *
+ * Template file (JSON):
+ *
+ *	{
+ *	  "sessionLink": "https://replay.example.com/{{.ProjectID}}/sessions/{{.SessionID}}",
+ *	  "templates": [
+ *	    {"project": "*", "adapter": "jira", "title": "...", "description": "...", "labels": ["..."]},
+ *	    {"project": "web", "adapter": "*", "title": "..."}
+ *	  ]
+ *	}
+ *
+ * The most specific template wins: project and adapter, then project, then
+ * adapter, then a wildcard. Empty fields fall back to the built-in content,
+ * which templates can also reuse via {{.Default.Description}}
+ *
+ * Templates are executed against TicketData (see below). All templates are
+ * executed against a sample incident at load time, so unknown fields and
+ * functions are rejected before the first export. Map keys (ScoreFeatures)
+ * depend on the incident, so a missing key renders its zero value rather
+ * than failing
+ */
+
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// BuiltInTemplate names the hard-coded formatter in previews
+const BuiltInTemplate = "built-in"
+
+// TicketData is the data model templates are executed against
+type TicketData struct {
+	// Incident is the incident being exported (all incident fields)
+	Incident types.Incident
+	// Issue is the cross-session issue the incident belongs to; for an
+	// incident without an issue it describes the single session
+	Issue TicketIssue
+	// Priority is the mapped priority ("P0" to "P4")
+	Priority PriorityLevel
+	// Adapter is the ticket system ("jira", "linear", "github", ...)
+	Adapter string
+	// Route, Feature and SignalType are derived from the incident
+	Route      string
+	Feature    string
+	SignalType string
+	// Reasoning is why the incident was detected and scored as it was
+	Reasoning TicketReasoning
+	// SessionLink links to the example session ("" without sessionLink)
+	SessionLink string
+	// Default is the built-in ticket content
+	Default types.Ticket
+}
+
+// TicketIssue describes the issue cluster behind a ticket
+type TicketIssue struct {
+	ID               string
+	AffectedSessions int
+	TimeWindow       string
+	ExampleSessions  []string
+}
+
+// TicketReasoning is the reasoning trace behind an incident
+type TicketReasoning struct {
+	Explanation      string
+	ConfidenceScore  float64 // 0-100
+	ConfidenceReason string
+	Signals          []types.SignalDetail
+	ScoreModel       string
+	ScoreFeatures    map[string]float64
+}
+
+// TicketTemplate is one configured template. Project and Adapter select
+// where it applies ("" or "*" = any)
+type TicketTemplate struct {
+	Name        string   `json:"name,omitempty"`
+	Project     string   `json:"project,omitempty"`
+	Adapter     string   `json:"adapter,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+// TicketTemplateConfig is the template file format
+type TicketTemplateConfig struct {
+	SessionLink string           `json:"sessionLink,omitempty"`
+	Templates   []TicketTemplate `json:"templates"`
+}
+
+// TicketTemplates is a validated set of parsed templates
+type TicketTemplates struct {
+	sessionLink *template.Template
+	templates   []parsedTemplate
+}
+
+type parsedTemplate struct {
+	TicketTemplate
+	title       *template.Template
+	description *template.Template
+	labels      []*template.Template
+}
+
+// templateFuncs are available to every template
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	// truncate keeps the first n characters (runes, not bytes)
+	"truncate": func(n int, s string) string {
+		runes := []rune(s)
+		if n < 0 {
+			n = 0
+		}
+		if len(runes) <= n {
+			return s
+		}
+		return string(runes[:n])
+	},
+	"percent": func(score float64) string {
+		return fmt.Sprintf("%.0f%%", score)
+	},
+	"date": func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04 MST")
+	},
+}
+
+// LoadTicketTemplatesFile reads and validates a template file
+func LoadTicketTemplatesFile(path string) (*TicketTemplates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read ticket templates: %w", err)
+	}
+	var cfg TicketTemplateConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse ticket templates: %w", err)
+	}
+	return NewTicketTemplates(cfg)
+}
+
+// NewTicketTemplates parses every template and executes it against a sample
+// incident, returning the first error
+func NewTicketTemplates(cfg TicketTemplateConfig) (*TicketTemplates, error) {
+	t := &TicketTemplates{}
+	sample := sampleTicketData()
+
+	if cfg.SessionLink != "" {
+		tmpl, err := parseTicketTemplate("sessionLink", cfg.SessionLink)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := execute(tmpl, sample.Incident); err != nil {
+			return nil, err
+		}
+		t.sessionLink = tmpl
+	}
+
+	for i, raw := range cfg.Templates {
+		if raw.Name == "" {
+			raw.Name = fmt.Sprintf("%s/%s", wildcard(raw.Project), wildcard(raw.Adapter))
+		}
+		p := parsedTemplate{TicketTemplate: raw}
+		var err error
+		if raw.Title != "" {
+			if p.title, err = parseTicketTemplate(fmt.Sprintf("templates[%d] %s title", i, raw.Name), raw.Title); err != nil {
+				return nil, err
+			}
+		}
+		if raw.Description != "" {
+			if p.description, err = parseTicketTemplate(fmt.Sprintf("templates[%d] %s description", i, raw.Name), raw.Description); err != nil {
+				return nil, err
+			}
+		}
+		for j, label := range raw.Labels {
+			tmpl, err := parseTicketTemplate(fmt.Sprintf("templates[%d] %s labels[%d]", i, raw.Name, j), label)
+			if err != nil {
+				return nil, err
+			}
+			p.labels = append(p.labels, tmpl)
+		}
+		if _, err := p.render(sample); err != nil {
+			return nil, err
+		}
+		t.templates = append(t.templates, p)
+	}
+	return t, nil
+}
+
+// match returns the most specific template for a project and adapter
+func (t *TicketTemplates) match(projectID, adapter string) (*parsedTemplate, bool) {
+	best, bestScore := -1, -1
+	for i, p := range t.templates {
+		score := 0
+		switch wildcard(p.Project) {
+		case "*":
+		case projectID:
+			score += 2
+		default:
+			continue
+		}
+		switch wildcard(p.Adapter) {
+		case "*":
+		case adapter:
+			score++
+		default:
+			continue
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return nil, false
+	}
+	return &t.templates[best], true
+}
+
+// SessionLink renders the session link for an incident ("" if unset)
+func (t *TicketTemplates) SessionLink(incident types.Incident) (string, error) {
+	if t == nil || t.sessionLink == nil {
+		return "", nil
+	}
+	return execute(t.sessionLink, incident)
+}
+
+// render executes a template; empty fields keep the built-in content
+func (p *parsedTemplate) render(data TicketData) (types.Ticket, error) {
+	ticket := data.Default
+	if p.title != nil {
+		title, err := execute(p.title, data)
+		if err != nil {
+			return ticket, err
+		}
+		title = strings.TrimSpace(title)
+		if title == "" {
+			return ticket, fmt.Errorf("ticket template %s: title rendered empty", p.Name)
+		}
+		ticket.Title = title
+	}
+	if p.description != nil {
+		description, err := execute(p.description, data)
+		if err != nil {
+			return ticket, err
+		}
+		ticket.Description = description
+	}
+	if len(p.labels) > 0 {
+		ticket.Labels = nil
+		for _, tmpl := range p.labels {
+			label, err := execute(tmpl, data)
+			if err != nil {
+				return ticket, err
+			}
+			if label = strings.TrimSpace(label); label != "" {
+				ticket.Labels = append(ticket.Labels, label)
+			}
+		}
+	}
+	return ticket, nil
+}
+
+func parseTicketTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("ticket template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func execute(tmpl *template.Template, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("ticket template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func wildcard(s string) string {
+	if s == "" {
+		return "*"
+	}
+	return s
+}
+
+// sampleTicketData is the incident templates are validated against
+func sampleTicketData() TicketData {
+	now := time.Now()
+	incident := types.Incident{
+		IncidentID:          "sample-incident",
+		SessionID:           "sample-session",
+		ProjectID:           "sample-project",
+		FrustrationScore:    8,
+		ConfidenceLevel:     "high",
+		TriggeringSignals:   []string{"rage", "blocked"},
+		PrimaryFailurePoint: "/checkout:submit:form_submit",
+		SeverityType:        "Bug",
+		Timestamp:           now,
+		Explanation:         "Repeated submit attempts without navigation",
+		SignalDetails:       []types.SignalDetail{{Type: "rage", Timestamp: now, Route: "/checkout", Details: "5 clicks in 2s"}},
+		Status:              "confirmed",
+		ConfidenceScore:     92,
+		IssueID:             "sample-issue",
+		ScoreModel:          "deterministic",
+		ScoreFeatures:       map[string]float64{"rage": 1},
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+	evidence := types.Evidence{AffectedSessions: 12, TimeWindow: "over the last 24 hours", ExampleSessionRefs: []string{"sample-session"}}
+	f := NewFormatter()
+	return f.ticketData("sample", incident, PriorityP1, evidence, "https://example.com/sessions/sample-session")
+}
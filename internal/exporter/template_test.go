@@ -0,0 +1,100 @@
+package exporter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewTicketTemplates_RejectsInvalidTemplates(t *testing.T) {
+	cases := []TicketTemplateConfig{
+		{Templates: []TicketTemplate{{Title: "{{.Incident.NoSuchField}}"}}},
+		{Templates: []TicketTemplate{{Title: "{{.Route"}}},
+		{Templates: []TicketTemplate{{Description: "{{nosuchfunc .Route}}"}}},
+		{Templates: []TicketTemplate{{Title: "{{if false}}x{{end}}"}}}, // renders empty
+		{SessionLink: "https://replay.example.com/{{.Session}}"},
+	}
+	for i, cfg := range cases {
+		if _, err := NewTicketTemplates(cfg); err == nil {
+			t.Errorf("case %d: expected a validation error", i)
+		}
+	}
+}
+
+func TestFormatter_RenderTicketPicksMostSpecificTemplate(t *testing.T) {
+	templates, err := NewTicketTemplates(TicketTemplateConfig{
+		SessionLink: "https://replay.example.com/{{.ProjectID}}/{{.SessionID}}",
+		Templates: []TicketTemplate{
+			{Title: "any: {{.Route}}"},
+			{Adapter: "jira", Title: "jira: {{.Route}}"},
+			{Name: "web-jira", Project: "web", Adapter: "jira",
+				Title:       "[{{.Priority}}] {{.Feature}} {{.SignalType}} ({{.Issue.AffectedSessions}} sessions)",
+				Description: "{{.Default.Description}}\n\nReplay: {{.SessionLink}}\nWhy: {{.Reasoning.Explanation}}",
+				Labels:      []string{"hawkeye", "{{lower .Incident.SeverityType}}", "{{if .Issue.ID}}issue{{end}}"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewTicketTemplates: %v", err)
+	}
+	f := NewFormatter()
+	f.SetTemplates(templates)
+
+	incident := confirmedIncident("inc-1")
+	incident.SessionID = "sess-1"
+	incident.PrimaryFailurePoint = "/checkout:submit:form_submit"
+	incident.TriggeringSignals = []string{"blocked"}
+	incident.Explanation = "submit loop"
+	evidence := IncidentEvidence(incident)
+	evidence.AffectedSessions = 7
+
+	ticket, name, err := f.RenderTicket("jira", incident, PriorityP1, evidence)
+	if err != nil {
+		t.Fatalf("RenderTicket: %v", err)
+	}
+	if name != "web-jira" || ticket.Title != "[P1] checkout blocked (7 sessions)" {
+		t.Errorf("template %q rendered title %q", name, ticket.Title)
+	}
+	if !strings.HasPrefix(ticket.Description, "## Summary") || !strings.Contains(ticket.Description, "Replay: https://replay.example.com/web/sess-1") {
+		t.Errorf("description = %q", ticket.Description)
+	}
+	if strings.Join(ticket.Labels, ",") != "hawkeye,bug" {
+		t.Errorf("labels = %v (empty labels should be dropped)", ticket.Labels)
+	}
+	if ticket.Metadata["incident_id"] != "inc-1" {
+		t.Errorf("built-in metadata not kept: %v", ticket.Metadata)
+	}
+
+	incident.ProjectID = "mobile"
+	if ticket, name, _ := f.RenderTicket("jira", incident, PriorityP1, evidence); name != "*/jira" || ticket.Title != "jira: /checkout" {
+		t.Errorf("mobile/jira used %q: %q", name, ticket.Title)
+	}
+	if ticket, name, _ := f.RenderTicket("linear", incident, PriorityP1, evidence); name != "*/*" || ticket.Title != "any: /checkout" {
+		t.Errorf("mobile/linear used %q: %q", name, ticket.Title)
+	}
+}
+
+func TestNewTicketTemplates_AcceptsAnyMapKey(t *testing.T) {
+	// The sample incident only has a "rage" feature; real incidents differ
+	templates, err := NewTicketTemplates(TicketTemplateConfig{Templates: []TicketTemplate{
+		{Title: "blocked={{.Reasoning.ScoreFeatures.blocked}} {{.Route}}"},
+	}})
+	if err != nil {
+		t.Fatalf("NewTicketTemplates: %v", err)
+	}
+	ticket, err := templates.templates[0].render(sampleTicketData())
+	if err != nil || !strings.HasPrefix(ticket.Title, "blocked=0 ") {
+		t.Errorf("title = %q, err = %v", ticket.Title, err)
+	}
+}
+
+func TestTruncate_KeepsWholeRunes(t *testing.T) {
+	truncate := templateFuncs["truncate"].(func(int, string) string)
+	if got := truncate(3, "café au lait"); got != "caf" {
+		t.Errorf("truncate(3) = %q", got)
+	}
+	if got := truncate(4, "café au lait"); got != "café" {
+		t.Errorf("truncate(4) = %q, want the whole é", got)
+	}
+	if got := truncate(10, "日本語"); got != "日本語" {
+		t.Errorf("truncate(10) = %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+// beaconPath accepts batches sent with navigator.sendBeacon on page unload.
+const beaconPath = "/v1/events/beacon"
+
+// Beacon error codes reported in IngestResponse.Error.
+const (
+	errCodeOriginNotAllowed       = "origin_not_allowed"
+	errCodeInvalidAPIKey          = "invalid_api_key"
+	errCodeUnsupportedContentType = "unsupported_content_type"
+)
+
+// SetBeaconOrigins sets the page origins allowed to post to the beacon route:
+// exact origins ("https://shop.example.com"), subdomain patterns
+// ("https://*.example.com") or "*". Without any, only dev mode accepts
+// beacons (from any origin).
+func (s *Server) SetBeaconOrigins(origins []string) {
+	s.beaconOrigins = origins
+}
+
+// handleBeacon ingests a batch sent by navigator.sendBeacon, which cannot set
+// headers: the body may be text/plain JSON, and the API key comes from
+// ?api_key= or the payload's api_key. Since the key ships in page source,
+// the request must also come from an allowed origin (browsers don't let
+// pages forge Origin or Referer).
+func (s *Server) handleBeacon(w http.ResponseWriter, r *http.Request) {
+	origin := requestOrigin(r)
+	if !s.beaconOriginAllowed(origin) {
+		writeIngestError(w, http.StatusForbidden, errCodeOriginNotAllowed, "origin not allowed to send beacons", 0)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Add("Vary", "Origin")
+
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != "text/plain" && mediaType != "application/json") {
+			writeIngestError(w, http.StatusUnsupportedMediaType, errCodeUnsupportedContentType,
+				"beacon body must be text/plain or application/json", 0)
+			return
+		}
+	}
+
+	var req types.IngestRequest
+	if !decodeIngestRequest(w, r, &req) {
+		return
+	}
+	key := r.URL.Query().Get("api_key")
+	if key == "" {
+		key = req.APIKey
+	}
+	projectID, ok := s.keyProject(key)
+	if !ok {
+		writeIngestError(w, http.StatusUnauthorized, errCodeInvalidAPIKey, "invalid API key", 0)
+		return
+	}
+
+	r = r.WithContext(context.WithValue(r.Context(), projectIDKey, projectID))
+	r = withEnrichSource(r, req.SDKVersion)
+	s.ingestBatch(w, r, ingestProjectID(r, req.AppID), req.Events)
+}
+
+// requestOrigin is the request's Origin header, or else the origin of its
+// Referer ("" when neither is set).
+func requestOrigin(r *http.Request) string {
+	if origin := r.Header.Get("Origin"); origin != "" && origin != "null" {
+		return origin
+	}
+	if ref, err := url.Parse(r.Header.Get("Referer")); err == nil && ref.Scheme != "" && ref.Host != "" {
+		return ref.Scheme + "://" + ref.Host
+	}
+	return ""
+}
+
+// beaconOriginAllowed matches an origin against the beacon origins.
+func (s *Server) beaconOriginAllowed(origin string) bool {
+	if len(s.beaconOrigins) == 0 {
+		return s.devMode
+	}
+	if origin == "" {
+		return false
+	}
+	origin = strings.ToLower(origin)
+	for _, allowed := range s.beaconOrigins {
+		allowed = strings.ToLower(strings.TrimSuffix(allowed, "/"))
+		switch {
+		case allowed == "*", allowed == origin:
+			return true
+		case strings.Contains(allowed, "://*."):
+			scheme, domain, _ := strings.Cut(allowed, "://*")
+			if strings.HasPrefix(origin, scheme+"://") && strings.HasSuffix(origin, domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/your-org/frustration-engine/internal/validation"
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+// Ingest error codes reported in IngestResponse.Error.
+const (
+	errCodeInvalidBody         = "invalid_body"
+	errCodePayloadTooLarge     = "payload_too_large"
+	errCodeBatchTooLarge       = "batch_too_large"
+	errCodeUnsupportedEncoding = "unsupported_encoding"
+)
+
+var (
+	errPayloadTooLarge     = errors.New("payload too large")
+	errUnsupportedEncoding = errors.New("unsupported content encoding")
+)
+
+// requestBody returns the request body decompressed according to its
+// Content-Encoding (gzip, deflate, br or identity). Both the body on the wire
+// and the decompressed body are capped at limit bytes (0 = uncapped, for
+// streams that bound memory themselves); reads past the cap fail with
+// errPayloadTooLarge, which guards against decompression bombs.
+func requestBody(w http.ResponseWriter, r *http.Request, limit int64) (io.ReadCloser, error) {
+	raw := r.Body
+	if limit > 0 {
+		raw = http.MaxBytesReader(w, r.Body, limit)
+	}
+
+	var body io.ReadCloser
+	switch encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+		body = raw
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(raw)
+		if err != nil {
+			return nil, payloadError(err)
+		}
+		body = readCloser{zr, raw}
+	case "deflate":
+		body = readCloser{newDeflateReader(raw), raw}
+	case "br":
+		body = readCloser{brotli.NewReader(raw), raw}
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedEncoding, encoding)
+	}
+	if limit <= 0 {
+		return body, nil
+	}
+	return readCloser{&limitedReader{r: body, n: limit}, body}, nil
+}
+
+// newDeflateReader reads "deflate" bodies, which should be zlib-wrapped but
+// some clients send as raw DEFLATE.
+func newDeflateReader(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if header, err := br.Peek(2); err == nil && isZlibHeader(header) {
+		if zr, err := zlib.NewReader(br); err == nil {
+			return zr
+		}
+	}
+	return flate.NewReader(br)
+}
+
+// isZlibHeader reports whether b starts a zlib stream (RFC 1950).
+func isZlibHeader(b []byte) bool {
+	return b[0]&0x0f == 8 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0
+}
+
+// limitedReader fails with errPayloadTooLarge once more than n bytes are read.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, errPayloadTooLarge
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, errPayloadTooLarge
+	}
+	return n, err
+}
+
+// readCloser reads from one reader and closes another.
+type readCloser struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (rc readCloser) Close() error {
+	return rc.closer.Close()
+}
+
+// payloadError maps http.MaxBytesReader's error to errPayloadTooLarge.
+func payloadError(err error) error {
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return errPayloadTooLarge
+	}
+	return err
+}
+
+// decodeIngestRequest reads an ingest batch, enforcing the payload and batch
+// limits. On failure it writes the error response and returns false.
+func decodeIngestRequest(w http.ResponseWriter, r *http.Request, req *types.IngestRequest) bool {
+	body, err := requestBody(w, r, validation.MaxPayloadSize)
+	if err == nil {
+		defer body.Close()
+		err = json.NewDecoder(body).Decode(req)
+	}
+	if err != nil {
+		writeBodyError(w, payloadError(err))
+		return false
+	}
+	if len(req.Events) > validation.MaxBatchSize {
+		writeIngestError(w, http.StatusRequestEntityTooLarge, errCodeBatchTooLarge,
+			fmt.Sprintf("batch has %d events, maximum is %d", len(req.Events), validation.MaxBatchSize),
+			validation.MaxBatchSize)
+		return false
+	}
+	return true
+}
+
+// writeBodyError responds to a request body that could not be read.
+func writeBodyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errPayloadTooLarge):
+		writeIngestError(w, http.StatusRequestEntityTooLarge, errCodePayloadTooLarge,
+			fmt.Sprintf("payload exceeds %d bytes", validation.MaxPayloadSize), validation.MaxPayloadSize)
+	case errors.Is(err, errUnsupportedEncoding):
+		writeIngestError(w, http.StatusUnsupportedMediaType, errCodeUnsupportedEncoding,
+			err.Error()+" (use gzip, deflate or br)", 0)
+	default:
+		writeIngestError(w, http.StatusBadRequest, errCodeInvalidBody, "invalid request body", 0)
+	}
+}
+
+// writeIngestError writes a batch-level ingest failure.
+func writeIngestError(w http.ResponseWriter, status int, code, message string, limit int64) {
+	writeJSON(w, status, types.IngestResponse{
+		Success: false,
+		Message: message,
+		Error:   &types.IngestError{Code: code, Limit: limit},
+	})
+}
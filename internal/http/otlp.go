@@ -0,0 +1,173 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/your-org/frustration-engine/internal/otlp"
+	"github.com/your-org/frustration-engine/internal/validation"
+)
+
+// OTLP/HTTP receiver paths, the defaults OpenTelemetry exporters append to
+// their endpoint.
+const (
+	otlpTracesPath = "/v1/traces"
+	otlpLogsPath   = "/v1/logs"
+)
+
+// maxOTLPPayloadSize caps an OTLP request body (decompressed). OTLP batches
+// are larger than SDK batches: spans carry many attributes that the mapping
+// drops.
+const maxOTLPPayloadSize = 4 << 20
+
+// gRPC status codes used in OTLP error responses.
+const (
+	rpcInvalidArgument   = 3
+	rpcResourceExhausted = 8
+	rpcInternal          = 13
+	rpcUnavailable       = 14
+)
+
+// otlpStatus is the google.rpc.Status body of an OTLP error response.
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// SetOTLPMapper enables the OTLP/HTTP receiver (POST /v1/traces and
+// POST /v1/logs), mapping spans and log records with m.
+func (s *Server) SetOTLPMapper(m *otlp.Mapper) {
+	s.otlp = m
+}
+
+// handleOTLPTraces ingests an OTLP ExportTraceServiceRequest.
+func (s *Server) handleOTLPTraces(w http.ResponseWriter, r *http.Request) {
+	if s.otlp == nil {
+		writeOTLPError(w, http.StatusServiceUnavailable, rpcUnavailable, "OTLP ingestion is not enabled")
+		return
+	}
+	var req otlp.TracesRequest
+	if !decodeOTLPRequest(w, r, &req) {
+		return
+	}
+	rejected, message, ok := s.ingestOTLP(w, r, s.otlp.Traces(req), "spans")
+	if !ok {
+		return
+	}
+
+	var resp otlp.ExportTraceServiceResponse
+	if rejected > 0 {
+		resp.PartialSuccess = &otlp.ExportTracePartialSuccess{RejectedSpans: int64(rejected), ErrorMessage: message}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleOTLPLogs ingests an OTLP ExportLogsServiceRequest.
+func (s *Server) handleOTLPLogs(w http.ResponseWriter, r *http.Request) {
+	if s.otlp == nil {
+		writeOTLPError(w, http.StatusServiceUnavailable, rpcUnavailable, "OTLP ingestion is not enabled")
+		return
+	}
+	var req otlp.LogsRequest
+	if !decodeOTLPRequest(w, r, &req) {
+		return
+	}
+	rejected, message, ok := s.ingestOTLP(w, r, s.otlp.Logs(req), "log records")
+	if !ok {
+		return
+	}
+
+	var resp otlp.ExportLogsServiceResponse
+	if rejected > 0 {
+		resp.PartialSuccess = &otlp.ExportLogsPartialSuccess{RejectedLogRecords: int64(rejected), ErrorMessage: message}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ingestOTLP ingests mapped events in validation-sized batches and returns
+// how many records were rejected (no session ID, invalid, or not stored),
+// with a message for the OTLP partial success. When storing a batch fails
+// and nothing was stored yet, it writes a retryable error response and
+// returns false. Once earlier batches are stored, a retry of the whole
+// request would duplicate them, so the failed batch and the rest are
+// reported as rejected instead.
+func (s *Server) ingestOTLP(w http.ResponseWriter, r *http.Request, result otlp.Result, records string) (int, string, bool) {
+	r = withEnrichSource(r, "")
+	pid := ingestProjectID(r, "")
+	if len(result.Events) > 0 && !s.allowIngest(w, r, pid, len(result.Events)) {
+		return 0, "", false
+	}
+
+	invalid, unstored := 0, 0
+	for start := 0; start < len(result.Events); start += validation.MaxBatchSize {
+		end := start + validation.MaxBatchSize
+		if end > len(result.Events) {
+			end = len(result.Events)
+		}
+		ingested, err := s.ingest.Ingest(r.Context(), pid, result.Events[start:end])
+		s.releaseEvents(pid, ingested.Unaccepted(end-start, err))
+		if err != nil {
+			// The remaining batches are never ingested
+			s.releaseEvents(pid, len(result.Events)-end)
+			if start == 0 {
+				writeOTLPError(w, http.StatusInternalServerError, rpcInternal, "storage error")
+				return 0, "", false
+			}
+			unstored = len(result.Events) - start
+			break
+		}
+		invalid += len(ingested.Rejected)
+	}
+
+	var reasons []string
+	if result.Rejected > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d %s without a session ID", result.Rejected, records))
+	}
+	if invalid > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d events failed validation", invalid))
+	}
+	if unstored > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d events not stored (storage error)", unstored))
+	}
+	return result.Rejected + invalid + unstored, strings.Join(reasons, "; "), true
+}
+
+// decodeOTLPRequest reads an OTLP/HTTP JSON request body into v. On failure
+// it writes the error response and returns false.
+func decodeOTLPRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || mediaType != "application/json" {
+			writeOTLPError(w, http.StatusUnsupportedMediaType, rpcInvalidArgument,
+				"only the OTLP/HTTP JSON encoding (application/json) is supported")
+			return false
+		}
+	}
+
+	body, err := requestBody(w, r, maxOTLPPayloadSize)
+	if err == nil {
+		defer body.Close()
+		err = json.NewDecoder(body).Decode(v)
+	}
+	switch err = payloadError(err); {
+	case err == nil:
+		return true
+	case errors.Is(err, errPayloadTooLarge):
+		writeOTLPError(w, http.StatusRequestEntityTooLarge, rpcResourceExhausted,
+			fmt.Sprintf("payload exceeds %d bytes", maxOTLPPayloadSize))
+	case errors.Is(err, errUnsupportedEncoding):
+		writeOTLPError(w, http.StatusUnsupportedMediaType, rpcInvalidArgument, err.Error()+" (use gzip, deflate or br)")
+	default:
+		writeOTLPError(w, http.StatusBadRequest, rpcInvalidArgument, "invalid OTLP request body")
+	}
+	return false
+}
+
+// writeOTLPError writes an OTLP error response.
+func writeOTLPError(w http.ResponseWriter, status, code int, message string) {
+	writeJSON(w, status, otlpStatus{Code: code, Message: message})
+}
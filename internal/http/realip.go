@@ -0,0 +1,88 @@
+package http
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// SetTrustedProxies sets the proxies (IP addresses or CIDR ranges) whose
+// X-Forwarded-For and X-Real-IP headers name the client. Headers from any
+// other peer are ignored, since clients could otherwise pick their own IP
+// (and so their enriched country). Without any, the peer address is used.
+func (s *Server) SetTrustedProxies(proxies []string) error {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, p := range proxies {
+		prefix, err := netip.ParsePrefix(p)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(p)
+			if addrErr != nil {
+				return fmt.Errorf("trusted proxy %q is not an IP address or CIDR range", p)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	s.trustedProxies = prefixes
+	return nil
+}
+
+// realIP replaces r.RemoteAddr with the client address forwarded by a
+// trusted proxy. X-Forwarded-For is read right to left, skipping trusted
+// proxies, so a client cannot prepend an address of its choice.
+func (s *Server) realIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if peer, ok := parseIP(r.RemoteAddr); ok && s.trustedProxy(peer) {
+			if client := s.forwardedClient(r); client.IsValid() {
+				r.RemoteAddr = client.String()
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// forwardedClient is the client address named by a trusted proxy's
+// forwarding headers (invalid when they name none).
+func (s *Server) forwardedClient(r *http.Request) netip.Addr {
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		var client netip.Addr
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr, ok := parseIP(strings.TrimSpace(hops[i]))
+			if !ok {
+				break
+			}
+			client = addr
+			if !s.trustedProxy(addr) {
+				break
+			}
+		}
+		return client
+	}
+	addr, _ := parseIP(strings.TrimSpace(r.Header.Get("X-Real-IP")))
+	return addr
+}
+
+// trustedProxy reports whether addr is one of the trusted proxies.
+func (s *Server) trustedProxy(addr netip.Addr) bool {
+	for _, prefix := range s.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIP parses an IP address, with or without a port.
+func parseIP(s string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/your-org/frustration-engine/internal/schema"
+	"github.com/your-org/frustration-engine/internal/validation"
+)
+
+// schemaContentType is the media type of a single JSON Schema document.
+const schemaContentType = "application/schema+json"
+
+// schemaIndex is the response of GET /v1/schema.
+type schemaIndex struct {
+	Current    int      `json:"current"`
+	Versions   []int    `json:"versions"`
+	EventTypes []string `json:"eventTypes"`
+}
+
+// handleSchemaIndex lists the supported schema versions and event types.
+func (s *Server) handleSchemaIndex(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, schemaIndex{
+		Current:    schema.Current,
+		Versions:   schema.Versions(),
+		EventTypes: validation.EventTypes(),
+	})
+}
+
+// handleSchemaBundle returns every event type's schema in a version.
+func (s *Server) handleSchemaBundle(w http.ResponseWriter, r *http.Request) {
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown schema version"})
+		return
+	}
+	bundle, ok := schema.Bundle(version)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown schema version"})
+		return
+	}
+	writeJSON(w, http.StatusOK, bundle)
+}
+
+// handleSchema returns one event type's schema in a version.
+func (s *Server) handleSchema(w http.ResponseWriter, r *http.Request) {
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown schema version"})
+		return
+	}
+	doc, ok := schema.Lookup(version, chi.URLParam(r, "eventType"))
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown schema version or event type"})
+		return
+	}
+	w.Header().Set("Content-Type", schemaContentType)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(doc)
+}
@@ -1,18 +1,48 @@
 // Package http provides the HTTP server and routing for HawkEye.
 //
-// All routes are mounted on a single port. The server exposes:
+// All routes are mounted on a single port. Ingest bodies may be gzip,
+// deflate or Brotli encoded; payloads over validation.MaxPayloadSize
+// (decompressed) or batches over validation.MaxBatchSize get 413. Ingestion is subject to the
+// per-project rate limits and quotas set with SetRateLimiter: requests over
+// a limit get 429 with Retry-After and X-RateLimit-* headers.
+//
+// Operator endpoints (marked admin below) require the admin key set with
+// SetAdminKey in X-Admin-Key; the SDK API key does not grant them.
+//
+// The server exposes:
 //   - POST /v1/events    — event ingestion from SDK
-//   - GET  /v1/incidents — query detected incidents
+//   - POST /v1/events/stream — NDJSON event stream for replays and backfills
+//   - POST /v1/events/beacon — page-unload batches from navigator.sendBeacon
+//   - POST /v1/traces    — OTLP/HTTP JSON spans from OpenTelemetry browser SDKs
+//   - POST /v1/logs      — OTLP/HTTP JSON log records
+//   - GET  /v1/incidents — query the caller's project's incidents
+//   - GET  /v1/issues[/{issueID}] — query the caller's project's cross-session issues
+//   - POST /v1/export/trigger — run the ticket exporter now (admin, when enabled)
+//   - POST /v1/tickets/sync — apply a ticket change pushed by the ticket system's signed webhook
+//   - GET  /v1/export/preview/{incidentID} — render an incident's ticket without exporting it (admin)
+//   - POST /v1/admin/incidents/{incidentID}/confirm — confirm an incident for ticket export (admin)
+//   - GET  /v1/admin/dlq — list dead-lettered events (admin)
+//   - POST /v1/admin/dlq/replay — re-run dead-lettered events through ingestion (admin)
+//   - GET  /v1/admin/webhooks — list failed webhook deliveries (admin)
+//   - POST /v1/admin/webhooks/{deliveryID}/replay — re-send a webhook delivery (admin)
+//   - GET  /v1/usage     — the caller's project's event quota consumption
+//   - GET  /v1/schema    — supported event schema versions and event types
+//   - GET  /v1/schema/{version}[/{eventType}] — event JSON Schemas
 //   - GET  /health       — health check
 //   - GET  /metrics      — Prometheus metrics
 package http
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"net/http"
+	"net/netip"
 	"strconv"
 	"time"
 
@@ -20,12 +50,23 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/your-org/frustration-engine/internal/adapters"
+	"github.com/your-org/frustration-engine/internal/enrich"
+	"github.com/your-org/frustration-engine/internal/exporter"
 	"github.com/your-org/frustration-engine/internal/incident"
 	"github.com/your-org/frustration-engine/internal/ingest"
+	"github.com/your-org/frustration-engine/internal/ingestion"
+	"github.com/your-org/frustration-engine/internal/issue"
 	"github.com/your-org/frustration-engine/internal/metrics"
+	"github.com/your-org/frustration-engine/internal/otlp"
+	"github.com/your-org/frustration-engine/internal/ratelimit"
+	"github.com/your-org/frustration-engine/internal/webhook"
 	"github.com/your-org/frustration-engine/pkg/types"
 )
 
+// maxTicketWebhookSize caps ticket system webhook bodies.
+const maxTicketWebhookSize = 1 << 20
+
 type ctxKey int
 
 const projectIDKey ctxKey = iota
@@ -35,23 +76,41 @@ type Server struct {
 	router    chi.Router
 	ingest    *ingest.Handler
 	incidents *incident.Service
-	apiKey    string
+	issues    *issue.Service
+	exporter  *exporter.Engine
+	syncer    *exporter.Syncer
+	// syncSecret verifies ticket system webhooks on /v1/tickets/sync.
+	syncSecret string
+	limits     *ratelimit.ProjectLimiter
+	otlp       *otlp.Mapper
+	webhooks   *webhook.Outbox
+	apiKey     string
+	adminKey   string
+	// projectKeys maps project IDs to their own SDK keys.
+	projectKeys map[string]string
+	devMode     bool
+	// beaconOrigins may post to the beacon route (see beacon.go).
+	beaconOrigins []string
+	// trustedProxies may name the client in forwarding headers (see realip.go).
+	trustedProxies []netip.Prefix
 }
 
 // NewServer creates a new HTTP server with all routes configured.
-func NewServer(ingestHandler *ingest.Handler, incidentSvc *incident.Service, apiKey string, devMode bool) *Server {
+func NewServer(ingestHandler *ingest.Handler, incidentSvc *incident.Service, issueSvc *issue.Service, apiKey string, devMode bool) *Server {
 	s := &Server{
 		router:    chi.NewRouter(),
 		ingest:    ingestHandler,
 		incidents: incidentSvc,
+		issues:    issueSvc,
 		apiKey:    apiKey,
+		devMode:   devMode,
 	}
 
 	s.router.Use(middleware.RequestID)
-	s.router.Use(middleware.RealIP)
+	s.router.Use(s.realIP)
 	s.router.Use(middleware.Logger)
 	s.router.Use(middleware.Recoverer)
-	s.router.Use(middleware.Timeout(30 * time.Second))
+	s.router.Use(boundedExcept(30*time.Second, streamPath))
 	s.router.Use(s.metricsMiddleware)
 
 	if devMode {
@@ -61,19 +120,76 @@ func NewServer(ingestHandler *ingest.Handler, incidentSvc *incident.Service, api
 	// Public endpoints
 	s.router.Get("/health", s.handleHealth)
 	s.router.Handle("/metrics", promhttp.Handler())
+	s.router.Get("/v1/schema", s.handleSchemaIndex)
+	s.router.Get("/v1/schema/{version}", s.handleSchemaBundle)
+	s.router.Get("/v1/schema/{version}/{eventType}", s.handleSchema)
 
 	// Authenticated endpoints
 	s.router.Group(func(r chi.Router) {
 		r.Use(s.apiKeyAuth)
 		r.Post("/v1/events", s.handleIngest)
+		r.Post(streamPath, s.handleIngestStream)
+		r.Post(otlpTracesPath, s.handleOTLPTraces)
+		r.Post(otlpLogsPath, s.handleOTLPLogs)
+		r.Get("/v1/usage", s.handleUsage)
+		r.Get("/v1/incidents", s.handleQueryIncidents)
+		r.Get("/v1/issues", s.handleQueryIssues)
+		r.Get("/v1/issues/{issueID}", s.handleGetIssue)
 	})
 
-	// Incident query
-	s.router.Get("/v1/incidents", s.handleQueryIncidents)
+	// Operator endpoints
+	s.router.Group(func(r chi.Router) {
+		r.Use(s.adminAuth)
+		r.Post("/v1/export/trigger", s.handleExportTrigger)
+		r.Get("/v1/export/preview/{incidentID}", s.handleExportPreview)
+		r.Post("/v1/admin/incidents/{incidentID}/confirm", s.handleConfirmIncident)
+		r.Get("/v1/admin/dlq", s.handleListDeadLetters)
+		r.Post("/v1/admin/dlq/replay", s.handleReplayDeadLetters)
+		r.Get("/v1/admin/webhooks", s.handleListWebhookDeliveries)
+		r.Post("/v1/admin/webhooks/{deliveryID}/replay", s.handleReplayWebhookDelivery)
+	})
+
+	// Ticket system webhooks authenticate by signature
+	s.router.Post("/v1/tickets/sync", s.handleTicketSync)
+
+	// Beacon ingestion authenticates itself: sendBeacon cannot set headers
+	s.router.Post(beaconPath, s.handleBeacon)
 
 	return s
 }
 
+// SetAdminKey sets the key that guards the operator endpoints. It must
+// differ from the SDK API key, which is shipped to browsers; without it the
+// operator endpoints are disabled.
+func (s *Server) SetAdminKey(key string) {
+	s.adminKey = key
+}
+
+// SetProjectKeys gives projects their own SDK keys (project ID to key).
+// Traffic authenticated with a project's key is counted against that
+// project's limits; the API key passed to NewServer is the "default" project.
+func (s *Server) SetProjectKeys(keys map[string]string) {
+	s.projectKeys = keys
+}
+
+// SetExporter enables POST /v1/export/trigger.
+func (s *Server) SetExporter(exp *exporter.Engine) {
+	s.exporter = exp
+}
+
+// SetTicketSyncer enables POST /v1/tickets/sync for ticket system webhooks
+// signed with webhookSecret. Without a secret the route is disabled.
+func (s *Server) SetTicketSyncer(syncer *exporter.Syncer, webhookSecret string) {
+	s.syncer = syncer
+	s.syncSecret = webhookSecret
+}
+
+// SetRateLimiter enforces per-project ingestion limits and enables
+// GET /v1/usage.
+func (s *Server) SetRateLimiter(limits *ratelimit.ProjectLimiter) {
+	s.limits = limits
+}
+
 // ListenAndServe starts the HTTP server on the given address.
 func (s *Server) ListenAndServe(addr string) error {
 	srv := &http.Server{
@@ -103,40 +219,78 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleIngest(w http.ResponseWriter, r *http.Request) {
 	var req types.IngestRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, types.IngestResponse{
-			Success: false, Message: "invalid request body",
-		})
+	if !decodeIngestRequest(w, r, &req) {
 		return
 	}
 
-	pid, _ := r.Context().Value(projectIDKey).(string)
-	if pid == "" {
-		pid = req.AppID
-	}
-	if pid == "" {
-		pid = "default"
+	r = withEnrichSource(r, req.SDKVersion)
+	s.ingestBatch(w, r, ingestProjectID(r, req.AppID), req.Events)
+}
+
+// ingestBatch ingests a decoded batch and writes the response.
+func (s *Server) ingestBatch(w http.ResponseWriter, r *http.Request, pid string, events []types.Event) {
+	if !s.allowIngest(w, r, pid, len(events)) {
+		return
 	}
 
-	processed, err := s.ingest.Ingest(r.Context(), pid, req.Events)
+	result, err := s.ingest.Ingest(r.Context(), pid, events)
+	s.releaseEvents(pid, result.Unaccepted(len(events), err))
 	if err != nil {
 		writeJSON(w, http.StatusInternalServerError, types.IngestResponse{
-			Success: false, Message: "storage error",
+			Success: false, Message: "storage error", Rejected: result.Rejected,
 		})
 		return
 	}
 
+	// Rejected events are not retryable, so the batch still succeeds.
 	writeJSON(w, http.StatusOK, types.IngestResponse{
-		Success:   true,
-		Processed: processed,
+		Success:    true,
+		Processed:  result.Processed,
+		Rejected:   result.Rejected,
+		Redactions: result.Redactions,
 	})
 }
 
+// ingestProjectID is the project a batch is ingested for: the caller's
+// (set by authentication), else the app ID the batch names, else "default".
+func ingestProjectID(r *http.Request, appID string) string {
+	if pid, _ := r.Context().Value(projectIDKey).(string); pid != "" {
+		return pid
+	}
+	if appID != "" {
+		return appID
+	}
+	return "default"
+}
+
+// withEnrichSource records the client details that ingest enrichment reads
+// from the request: user agent, IP address (as resolved by realIP), release
+// header and SDK version.
+func withEnrichSource(r *http.Request, sdkVersion string) *http.Request {
+	return r.WithContext(enrich.WithSource(r.Context(), enrich.Source{
+		UserAgent:  r.UserAgent(),
+		IP:         r.RemoteAddr,
+		Release:    r.Header.Get(enrich.ReleaseHeader),
+		SDKVersion: sdkVersion,
+	}))
+}
+
 func (s *Server) handleQueryIncidents(w http.ResponseWriter, r *http.Request) {
+	pid, ok := callerProject(w, r)
+	if !ok {
+		return
+	}
 	q := r.URL.Query()
 	filter := types.QueryRequest{
-		ProjectID: q.Get("projectId"),
+		ProjectID: pid,
 		Status:    q.Get("status"),
+
+		Browser:       q.Get("browser"),
+		OS:            q.Get("os"),
+		DeviceClass:   q.Get("deviceClass"),
+		BotLikelihood: q.Get("botLikelihood"),
+		Release:       q.Get("release"),
+		Country:       q.Get("country"),
 	}
 	if v := q.Get("limit"); v != "" {
 		filter.Limit, _ = strconv.Atoi(v)
@@ -162,35 +316,426 @@ func (s *Server) handleQueryIncidents(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *Server) handleQueryIssues(w http.ResponseWriter, r *http.Request) {
+	pid, ok := callerProject(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	query := types.IssueQuery{
+		ProjectID: pid,
+		Status:    q.Get("status"),
+		Trend:     q.Get("trend"),
+	}
+	if v := q.Get("limit"); v != "" {
+		query.Limit, _ = strconv.Atoi(v)
+	}
+	if v := q.Get("offset"); v != "" {
+		query.Offset, _ = strconv.Atoi(v)
+	}
+	if query.Limit == 0 {
+		query.Limit = 100
+	}
+
+	issues, total, err := s.issues.Query(r.Context(), query)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "query failed"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, types.IssueQueryResponse{
+		Issues: issues,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+func (s *Server) handleGetIssue(w http.ResponseWriter, r *http.Request) {
+	iss, ok, err := s.issues.Get(r.Context(), chi.URLParam(r, "issueID"))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "query failed"})
+		return
+	}
+	// Other projects' issues are reported as missing, not forbidden, so
+	// issue IDs cannot be probed across projects.
+	if pid, _ := r.Context().Value(projectIDKey).(string); !ok || iss.ProjectID != pid {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "issue not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, iss)
+}
+
+// handleExportTrigger queues newly eligible incidents and runs up to max
+// (default 10) due export jobs.
+func (s *Server) handleExportTrigger(w http.ResponseWriter, r *http.Request) {
+	if s.exporter == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "ticket export is not enabled"})
+		return
+	}
+	max := 10
+	if v, err := strconv.Atoi(r.URL.Query().Get("max")); err == nil && v > 0 {
+		max = v
+	}
+
+	queued := s.exporter.EnqueueEligible(r.Context())
+	exported := s.exporter.ProcessDue(r.Context(), max)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":   "export triggered",
+		"queued":   queued,
+		"exported": exported,
+	})
+}
+
+// handleExportPreview renders the ticket the exporter would file for an
+// incident, through ?adapter= (default: the configured adapter), without
+// exporting it.
+func (s *Server) handleExportPreview(w http.ResponseWriter, r *http.Request) {
+	if s.exporter == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "ticket export is not enabled"})
+		return
+	}
+	inc, ok, err := s.incidents.Get(r.Context(), chi.URLParam(r, "incidentID"))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "query failed"})
+		return
+	}
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "incident not found"})
+		return
+	}
+
+	preview, err := s.exporter.PreviewTicket(r.Context(), incident.ToExporterIncident(inc), r.URL.Query().Get("adapter"))
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, preview)
+}
+
+// handleConfirmIncident marks a draft incident confirmed, which makes it
+// eligible for export when the exporter requires confirmation.
+func (s *Server) handleConfirmIncident(w http.ResponseWriter, r *http.Request) {
+	inc, ok, err := s.incidents.Get(r.Context(), chi.URLParam(r, "incidentID"))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "query failed"})
+		return
+	}
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "incident not found"})
+		return
+	}
+	if inc.Status != "draft" {
+		writeJSON(w, http.StatusConflict, map[string]string{"error": "incident is " + inc.Status})
+		return
+	}
+
+	inc.Status = "confirmed"
+	inc.UpdatedAt = time.Now().UTC()
+	if err := s.incidents.Store(r.Context(), inc); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "store failed"})
+		return
+	}
+	writeJSON(w, http.StatusOK, inc)
+}
+
+// handleTicketSync applies a ticket change pushed by the ticket system's
+// webhook (the native Jira, Linear or GitHub payload, signed with the sync
+// secret) to the incidents filed under that ticket, so they don't wait for
+// the next sync poll.
+func (s *Server) handleTicketSync(w http.ResponseWriter, r *http.Request) {
+	if s.syncer == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "ticket export is not enabled"})
+		return
+	}
+	if s.syncSecret == "" {
+		writeJSON(w, http.StatusForbidden, map[string]string{"error": "ticket sync webhooks are disabled"})
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxTicketWebhookSize))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "failed to read body"})
+		return
+	}
+
+	info, err := s.syncer.ParseTicketWebhook(r.Header, body, s.syncSecret)
+	switch {
+	case errors.Is(err, exporter.ErrTicketWebhooksUnsupported):
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		return
+	case errors.Is(err, adapters.ErrInvalidSignature):
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid signature"})
+		return
+	case err != nil:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	case info == nil:
+		// Verified, but not a ticket change (e.g. a ping)
+		writeJSON(w, http.StatusOK, exporter.SyncResult{})
+		return
+	}
+
+	result, err := s.syncer.ApplyTicketStatus(r.Context(), *info)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "ticket sync failed"})
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleListDeadLetters lists dead-lettered events, oldest first, filtered by
+// ?projectId=, ?reason=, ?since= and ?until= (RFC 3339) and ?limit= (default
+// 100).
+func (s *Server) handleListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	dlq := s.ingest.DeadLetterQueue()
+	if dlq == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "dead letter queue is not enabled"})
+		return
+	}
+	q := r.URL.Query()
+	filter := ingestion.DeadLetterFilter{
+		ProjectID: q.Get("projectId"),
+		Reason:    q.Get("reason"),
+		Limit:     100,
+	}
+	for name, t := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if v := q.Get(name); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": name + " must be an RFC 3339 time"})
+				return
+			}
+			*t = parsed
+		}
+	}
+	if v, err := strconv.Atoi(q.Get("limit")); err == nil && v > 0 {
+		filter.Limit = v
+	}
+
+	events, err := dlq.List(r.Context(), filter)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "query failed"})
+		return
+	}
+	if events == nil {
+		events = []ingestion.DeadLetterEvent{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"events": events,
+		"total":  len(events),
+	})
+}
+
+// handleReplayDeadLetters re-runs selected dead-lettered events through
+// ingestion, e.g. after a validation or storage fix.
+func (s *Server) handleReplayDeadLetters(w http.ResponseWriter, r *http.Request) {
+	dlq := s.ingest.DeadLetterQueue()
+	if dlq == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "dead letter queue is not enabled"})
+		return
+	}
+	var req types.DeadLetterReplayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil ||
+		(len(req.IDs) == 0 && req.ProjectID == "" && req.Reason == "" && req.Since.IsZero() && req.Until.IsZero()) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "ids or a projectId, reason, since or until filter is required"})
+		return
+	}
+
+	filter := ingestion.DeadLetterFilter{
+		ProjectID: req.ProjectID,
+		Reason:    req.Reason,
+		Since:     req.Since,
+		Until:     req.Until,
+	}
+	if len(req.IDs) == 0 {
+		filter.Limit = req.Limit
+	}
+	selected, err := dlq.List(r.Context(), filter)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "query failed"})
+		return
+	}
+	if len(req.IDs) > 0 {
+		selected = selectDeadLetters(selected, req.IDs)
+	}
+
+	result, err := s.ingest.Replay(r.Context(), selected)
+	if err != nil {
+		log.Printf("[http] dead letter replay: %v", err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "replay failed"})
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// selectDeadLetters keeps the events with the given IDs.
+func selectDeadLetters(events []ingestion.DeadLetterEvent, ids []string) []ingestion.DeadLetterEvent {
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	var selected []ingestion.DeadLetterEvent
+	for _, event := range events {
+		if want[event.ID] {
+			selected = append(selected, event)
+		}
+	}
+	return selected
+}
+
+// allowIngest checks a batch against the project's rate limits and quotas,
+// setting the X-RateLimit-* headers. Over a limit it writes 429 with
+// Retry-After and returns false.
+func (s *Server) allowIngest(w http.ResponseWriter, r *http.Request, projectID string, events int) bool {
+	if s.limits == nil {
+		return true
+	}
+	result := s.limits.Check(r.Context(), projectID, events)
+	setRateLimitHeaders(w, result)
+	if result.Allowed {
+		return true
+	}
+	writeJSON(w, http.StatusTooManyRequests, types.IngestResponse{
+		Success: false, Message: rateLimitMessage(result.Exceeded),
+	})
+	return false
+}
+
+// releaseEvents refunds the quota of admitted events that were not
+// ingested, so quotas count only accepted events.
+func (s *Server) releaseEvents(projectID string, events int) {
+	if s.limits != nil && events > 0 {
+		s.limits.ReleaseEvents(projectID, events)
+	}
+}
+
+// setRateLimitHeaders describes a rate limit check in X-RateLimit-* headers,
+// adding Retry-After (and counting the rejection) when it failed.
+func setRateLimitHeaders(w http.ResponseWriter, result ratelimit.Result) {
+	if result.Limit > 0 {
+		w.Header().Set("X-RateLimit-Limit", strconv.FormatInt(result.Limit, 10))
+		w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(result.Remaining, 10))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(result.Reset.Unix(), 10))
+	}
+	if result.Allowed {
+		return
+	}
+
+	metrics.RateLimited.WithLabelValues(result.Exceeded).Inc()
+	retryAfter := int64(math.Ceil(result.RetryAfter.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	w.Header().Set("X-RateLimit-Scope", result.Exceeded)
+}
+
+// rateLimitMessage explains which limit rejected a batch.
+func rateLimitMessage(exceeded string) string {
+	switch exceeded {
+	case ratelimit.QuotaDaily:
+		return "daily event quota exceeded"
+	case ratelimit.QuotaMonthly:
+		return "monthly event quota exceeded"
+	case ratelimit.LimitEvents:
+		return "event rate limit exceeded"
+	default:
+		return "request rate limit exceeded"
+	}
+}
+
+// handleUsage reports the caller's project's event consumption against its
+// limits and quotas. ?projectId= may only name the caller's own project.
+func (s *Server) handleUsage(w http.ResponseWriter, r *http.Request) {
+	if s.limits == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "rate limiting is not enabled"})
+		return
+	}
+	pid, ok := callerProject(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.limits.Usage(pid))
+}
+
+// callerProject returns the project of the request's API key. A ?projectId=
+// naming another project gets 403, since keys only read their own project.
+func callerProject(w http.ResponseWriter, r *http.Request) (string, bool) {
+	pid, _ := r.Context().Value(projectIDKey).(string)
+	if want := r.URL.Query().Get("projectId"); want != "" && want != pid {
+		writeJSON(w, http.StatusForbidden, map[string]string{"error": "API key is not for project " + want})
+		return "", false
+	}
+	return pid, true
+}
+
 // --- middleware ---
 
 func (s *Server) apiKeyAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Never from the query string: any page can send a query key in a
+		// cross-origin beacon, so that is left to the origin-checked beacon
+		// route.
 		key := r.Header.Get("X-API-Key")
-		if key == "" {
-			key = r.URL.Query().Get("api_key")
-		}
 		if key == "" {
 			if auth := r.Header.Get("Authorization"); len(auth) > 7 && auth[:7] == "Bearer " {
 				key = auth[7:]
 			}
 		}
 
-		if key != s.apiKey {
+		projectID, ok := s.keyProject(key)
+		if !ok {
 			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid API key"})
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), projectIDKey, "default")
+		ctx := context.WithValue(r.Context(), projectIDKey, projectID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// adminAuth guards the operator endpoints with the admin key, sent in
+// X-Admin-Key or as a bearer token (never in the query string).
+func (s *Server) adminAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.adminKey == "" {
+			writeJSON(w, http.StatusForbidden, map[string]string{"error": "admin API is disabled"})
+			return
+		}
+		key := r.Header.Get("X-Admin-Key")
+		if auth := r.Header.Get("Authorization"); key == "" && len(auth) > 7 && auth[:7] == "Bearer " {
+			key = auth[7:]
+		}
+		if subtle.ConstantTimeCompare([]byte(key), []byte(s.adminKey)) != 1 {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid admin key"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// keyProject returns the project an SDK key belongs to: the project whose
+// key it is, or "default" for the API key. ok is false for unknown keys.
+func (s *Server) keyProject(key string) (projectID string, ok bool) {
+	if key == "" {
+		return "", false
+	}
+	for pid, projectKey := range s.projectKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(projectKey)) == 1 {
+			return pid, true
+		}
+	}
+	if subtle.ConstantTimeCompare([]byte(key), []byte(s.apiKey)) == 1 {
+		return "default", true
+	}
+	return "", false
+}
+
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-API-Key, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-API-Key, Authorization, "+enrich.ReleaseHeader)
 		w.Header().Set("Access-Control-Max-Age", "86400")
 
 		if r.Method == "OPTIONS" {
@@ -208,7 +753,12 @@ func (s *Server) metricsMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(ww, r)
 		duration := time.Since(start).Seconds()
 
-		path := r.URL.Path
+		// Label by route pattern, not path, so IDs in paths don't each
+		// create a series. Requests matching no route share one label.
+		path := chi.RouteContext(r.Context()).RoutePattern()
+		if path == "" {
+			path = "unmatched"
+		}
 		metrics.HTTPRequestsTotal.WithLabelValues(r.Method, path, fmt.Sprintf("%d", ww.Status())).Inc()
 		metrics.HTTPRequestDuration.WithLabelValues(r.Method, path).Observe(duration)
 	})
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/your-org/frustration-engine/internal/ingest"
+	"github.com/your-org/frustration-engine/internal/ratelimit"
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+// streamPath accepts newline-delimited events.
+const streamPath = "/v1/events/stream"
+
+// streamIdleTimeout bounds how long a stream may go without reading a byte.
+// Streams are exempt from the request timeout, so this replaces it.
+const streamIdleTimeout = time.Minute
+
+// handleIngestStream ingests an NDJSON body of events (one types.Event per
+// line, optionally compressed) for ?appId= (default: the caller's project).
+// Events are validated per line and ingested in chunks as the body arrives;
+// the response summarizes every line. Rate limits apply per chunk: the event
+// rate holds the stream back, while an exhausted quota stops it with 429.
+func (s *Server) handleIngestStream(w http.ResponseWriter, r *http.Request) {
+	r = withEnrichSource(r, "")
+	pid := ingestProjectID(r, r.URL.Query().Get("appId"))
+	if !s.allowIngest(w, r, pid, 0) {
+		return
+	}
+
+	body, err := requestBody(w, r, 0)
+	if err != nil {
+		writeBodyError(w, payloadError(err))
+		return
+	}
+	defer body.Close()
+
+	result, err := s.ingest.Stream(r.Context(), pid, newDeadlineReader(w, body), ingest.StreamOptions{
+		Admit:   s.admitChunk(w, pid),
+		Release: func(events int) { s.releaseEvents(pid, events) },
+	})
+	resp := types.StreamIngestResponse{
+		Success:         err == nil,
+		Lines:           result.Lines,
+		Processed:       result.Processed,
+		Rejected:        result.Rejected,
+		DeadLettered:    result.DeadLettered,
+		Throttled:       result.Throttled,
+		Errors:          result.Errors,
+		ErrorCounts:     result.ErrorCounts,
+		ErrorsTruncated: result.ErrorsTruncated,
+		Redactions:      result.Redactions,
+	}
+
+	var limited *rateLimitError
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, resp)
+	case errors.As(err, &limited):
+		resp.Message = rateLimitMessage(limited.result.Exceeded)
+		resp.Error = &types.IngestError{Code: limited.result.Exceeded, Limit: limited.result.Limit}
+		writeJSON(w, http.StatusTooManyRequests, resp)
+	case errors.Is(err, ingest.ErrStreamRead):
+		resp.Message = "stream read failed: " + err.Error()
+		resp.Error = &types.IngestError{Code: errCodeInvalidBody}
+		writeJSON(w, http.StatusBadRequest, resp)
+	default:
+		log.Printf("[http] event stream for %s stopped after line %d: %v", pid, result.Lines, err)
+		resp.Message = "storage error"
+		writeJSON(w, http.StatusInternalServerError, resp)
+	}
+}
+
+// rateLimitError stops a stream at an exhausted quota.
+type rateLimitError struct {
+	result ratelimit.Result
+}
+
+func (e *rateLimitError) Error() string {
+	return rateLimitMessage(e.result.Exceeded)
+}
+
+// admitChunk counts each stream chunk against the project's event rate and
+// quotas. Over the event rate it waits for the bucket to refill; an
+// exhausted quota stops the stream.
+func (s *Server) admitChunk(w http.ResponseWriter, projectID string) func(context.Context, int) error {
+	if s.limits == nil {
+		return nil
+	}
+	return func(ctx context.Context, events int) error {
+		for {
+			result := s.limits.ReserveEvents(ctx, projectID, events)
+			if result.Allowed {
+				return nil
+			}
+			if result.Exceeded != ratelimit.LimitEvents {
+				setRateLimitHeaders(w, result)
+				return &rateLimitError{result: result}
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(result.RetryAfter):
+			}
+		}
+	}
+}
+
+// deadlineReader pushes the connection's read and write deadlines forward
+// as a long body streams in, so the server's timeouts bound idle time
+// rather than the whole request.
+type deadlineReader struct {
+	io.ReadCloser
+	rc       *http.ResponseController
+	extended time.Time
+}
+
+func newDeadlineReader(w http.ResponseWriter, body io.ReadCloser) *deadlineReader {
+	return &deadlineReader{ReadCloser: body, rc: http.NewResponseController(w)}
+}
+
+func (d *deadlineReader) Read(p []byte) (int, error) {
+	if now := time.Now(); now.Sub(d.extended) > time.Second {
+		// Not every ResponseWriter supports deadlines (e.g. in tests)
+		_ = d.rc.SetReadDeadline(now.Add(streamIdleTimeout))
+		_ = d.rc.SetWriteDeadline(now.Add(streamIdleTimeout))
+		d.extended = now
+	}
+	return d.ReadCloser.Read(p)
+}
+
+// boundedExcept applies the request timeout to every path but the given
+// streaming ones, which run for as long as the client keeps sending.
+func boundedExcept(timeout time.Duration, streaming ...string) func(http.Handler) http.Handler {
+	bound := middleware.Timeout(timeout)
+	return func(next http.Handler) http.Handler {
+		bounded := bound(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, path := range streaming {
+				if r.URL.Path == path {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			bounded.ServeHTTP(w, r)
+		})
+	}
+}
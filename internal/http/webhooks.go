@@ -0,0 +1,62 @@
+package http
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/your-org/frustration-engine/internal/webhook"
+)
+
+// SetWebhookOutbox enables GET /v1/admin/webhooks and
+// POST /v1/admin/webhooks/{deliveryID}/replay.
+func (s *Server) SetWebhookOutbox(outbox *webhook.Outbox) {
+	s.webhooks = outbox
+}
+
+// handleListWebhookDeliveries lists logged webhook deliveries, newest first,
+// filtered by ?status= (failed or replayed).
+func (s *Server) handleListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	if s.webhooks == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "webhooks are not enabled"})
+		return
+	}
+	deliveries, err := s.webhooks.Deliveries(r.URL.Query().Get("status"))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "query failed"})
+		return
+	}
+	if deliveries == nil {
+		deliveries = []webhook.Delivery{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deliveries": deliveries,
+		"total":      len(deliveries),
+	})
+}
+
+// handleReplayWebhookDelivery re-sends a logged delivery with a fresh
+// signature. The receiver's failure is reported as 502.
+func (s *Server) handleReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	if s.webhooks == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "webhooks are not enabled"})
+		return
+	}
+	deliveryID := chi.URLParam(r, "deliveryID")
+	resp, err := s.webhooks.Replay(r.Context(), deliveryID)
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "delivery not found"})
+		return
+	case err != nil:
+		log.Printf("[http] webhook replay %s: %v", deliveryID, err)
+		writeJSON(w, http.StatusBadGateway, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deliveryId": deliveryID,
+		"statusCode": resp.StatusCode,
+	})
+}
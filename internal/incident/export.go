@@ -0,0 +1,162 @@
+package incident
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	oldtypes "github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+// exportBatchSize caps how many incidents one export run considers.
+const exportBatchSize = 100
+
+// ExportStore exposes the Service to the ticket exporter in-process.
+// Implements exporter.Store.
+type ExportStore struct {
+	svc           *Service
+	autoConfirm   bool
+	minConfidence float64
+}
+
+// NewExportStore creates an exporter store over svc. With autoConfirm,
+// draft incidents are offered for export as if confirmed; otherwise only
+// confirmed incidents are.
+func NewExportStore(svc *Service, autoConfirm bool) *ExportStore {
+	return &ExportStore{svc: svc, autoConfirm: autoConfirm}
+}
+
+// SetMinConfidence leaves incidents below a confidence score (0-100), such
+// as the exporter's threshold, out of GetEligibleIncidents.
+func (s *ExportStore) SetMinConfidence(score float64) {
+	s.minConfidence = score
+}
+
+// GetEligibleIncidents returns unexported, unsuppressed incidents awaiting
+// export. The exporter still applies its own eligibility rules (and rate
+// limits), but everything the store can rule out is filtered in the query,
+// so incidents the exporter will never take cannot fill the batch and
+// starve newer ones.
+func (s *ExportStore) GetEligibleIncidents(ctx context.Context) ([]oldtypes.Incident, error) {
+	notSuppressed, notExported, notFailed := false, false, false
+	filter := types.Filter{
+		Status:        "confirmed",
+		MinConfidence: s.minConfidence,
+		Suppressed:    &notSuppressed,
+		Exported:      &notExported,
+		ExportFailed:  &notFailed,
+		Limit:         exportBatchSize,
+	}
+	if s.autoConfirm {
+		filter.Status = ""
+	}
+	incidents, _, err := s.svc.Query(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]oldtypes.Incident, 0, len(incidents))
+	for _, inc := range incidents {
+		old := ToExporterIncident(inc)
+		if s.autoConfirm && old.Status == "draft" {
+			old.Status = "confirmed"
+		}
+		out = append(out, old)
+	}
+	return out, nil
+}
+
+// MarkExported records the external ticket on an incident.
+func (s *ExportStore) MarkExported(ctx context.Context, incidentID, externalTicketID, externalSystem string) error {
+	return s.update(ctx, incidentID, func(inc *types.Incident) {
+		now := time.Now().UTC()
+		inc.ExternalTicketID = externalTicketID
+		inc.ExternalSystem = externalSystem
+		inc.ExportedAt = &now
+		inc.ExportFailed = false
+	})
+}
+
+// MarkExportFailed records that an incident could not be exported.
+func (s *ExportStore) MarkExportFailed(ctx context.Context, incidentID string) error {
+	return s.update(ctx, incidentID, func(inc *types.Incident) {
+		inc.ExportFailed = true
+	})
+}
+
+// GetExportedIncidents returns incidents that have a ticket and have not
+// yet been resolved or suppressed. Implements exporter.SyncStore.
+func (s *ExportStore) GetExportedIncidents(ctx context.Context) ([]oldtypes.Incident, error) {
+	notSuppressed, exported := false, true
+	incidents, _, err := s.svc.Query(ctx, types.Filter{
+		Suppressed: &notSuppressed,
+		Exported:   &exported,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]oldtypes.Incident, 0, len(incidents))
+	for _, inc := range incidents {
+		if inc.Status == "resolved" {
+			continue
+		}
+		out = append(out, ToExporterIncident(inc))
+	}
+	return out, nil
+}
+
+// UpdateIncidentStatus applies a status synced from the incident's ticket.
+func (s *ExportStore) UpdateIncidentStatus(ctx context.Context, incidentID, status string, suppressed bool) error {
+	return s.update(ctx, incidentID, func(inc *types.Incident) {
+		inc.Status = status
+		inc.Suppressed = suppressed
+	})
+}
+
+func (s *ExportStore) update(ctx context.Context, incidentID string, apply func(*types.Incident)) error {
+	inc, ok, err := s.svc.Get(ctx, incidentID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("incident %s not found", incidentID)
+	}
+	apply(&inc)
+	return s.svc.Store(ctx, inc)
+}
+
+// ToExporterIncident converts an incident to the exporter's internal/types form.
+func ToExporterIncident(inc types.Incident) oldtypes.Incident {
+	details := make([]oldtypes.SignalDetail, len(inc.SignalDetails))
+	for i, d := range inc.SignalDetails {
+		details[i] = oldtypes.SignalDetail(d)
+	}
+	return oldtypes.Incident{
+		IncidentID:          inc.IncidentID,
+		SessionID:           inc.SessionID,
+		ProjectID:           inc.ProjectID,
+		FrustrationScore:    inc.FrustrationScore,
+		ConfidenceLevel:     inc.ConfidenceLevel,
+		TriggeringSignals:   inc.TriggeringSignals,
+		PrimaryFailurePoint: inc.PrimaryFailurePoint,
+		SeverityType:        inc.SeverityType,
+		Timestamp:           inc.Timestamp,
+		Explanation:         inc.Explanation,
+		SignalDetails:       details,
+		Status:              inc.Status,
+		ConfidenceScore:     inc.ConfidenceScore,
+		Suppressed:          inc.Suppressed,
+		ExternalTicketID:    inc.ExternalTicketID,
+		ExternalSystem:      inc.ExternalSystem,
+		ExportedAt:          inc.ExportedAt,
+		ExportFailed:        inc.ExportFailed,
+		IssueID:             inc.IssueID,
+		Regression:          inc.Regression,
+		ScoreModel:          inc.ScoreModel,
+		ScoreFeatures:       inc.ScoreFeatures,
+		CreatedAt:           inc.CreatedAt,
+		UpdatedAt:           inc.UpdatedAt,
+	}
+}
@@ -0,0 +1,45 @@
+package incident
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	memstorage "github.com/your-org/frustration-engine/internal/storage/memory"
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+func TestGetEligibleIncidents_SkipsStaleIneligibleIncidents(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(memstorage.NewIncidentStore())
+	store := NewExportStore(svc, false)
+	store.SetMinConfidence(70)
+
+	// A full batch of older incidents the exporter will never take
+	for i := 0; i < exportBatchSize; i++ {
+		inc := types.Incident{
+			IncidentID:      fmt.Sprintf("old-%d", i),
+			Status:          "confirmed",
+			ConfidenceScore: 90,
+			Timestamp:       time.Now(),
+		}
+		if i%2 == 0 {
+			inc.ExportFailed = true
+		} else {
+			inc.ConfidenceScore = 40
+		}
+		if err := svc.Store(ctx, inc); err != nil {
+			t.Fatalf("Store: %v", err)
+		}
+	}
+	svc.Store(ctx, types.Incident{IncidentID: "new", Status: "confirmed", ConfidenceScore: 85, Timestamp: time.Now()})
+
+	incidents, err := store.GetEligibleIncidents(ctx)
+	if err != nil {
+		t.Fatalf("GetEligibleIncidents: %v", err)
+	}
+	if len(incidents) != 1 || incidents[0].IncidentID != "new" {
+		t.Errorf("eligible = %+v, want only the new incident", incidents)
+	}
+}
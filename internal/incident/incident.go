@@ -28,6 +28,11 @@ func (s *Service) Store(ctx context.Context, incident types.Incident) error {
 	return nil
 }
 
+// Get returns an incident by ID.
+func (s *Service) Get(ctx context.Context, incidentID string) (types.Incident, bool, error) {
+	return s.store.Get(ctx, incidentID)
+}
+
 // Query retrieves incidents matching the filter.
 func (s *Service) Query(ctx context.Context, filter types.Filter) ([]types.Incident, int, error) {
 	incidents, err := s.store.Query(ctx, filter)
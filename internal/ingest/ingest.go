@@ -1,45 +1,129 @@
 // Package ingest handles event ingestion from the SDK.
 //
-// It validates incoming events, stores them, and forwards them to the
-// session manager for aggregation.
+// It validates incoming events, normalizes their routes into templates,
+// stores them, and forwards them to the session manager for aggregation.
+//
+// Validation runs the internal/validation pipeline (schema, edge cases,
+// privacy). Rejected events are reported back per batch index with a reason
+// code and counted in hawkeye_events_rejected_total. PII in accepted events
+// is redacted rather than rejected, counted per rule in
+// hawkeye_pii_redactions_total. Before validation, events from older SDKs
+// are upcast to the current schema version (see internal/schema) and get the
+// client context of the request (see internal/enrich).
+//
+// With a dead letter queue, events that fail validation, storage or session
+// forwarding are kept there (redacted) and can be replayed through Replay
+// once the cause is fixed. Storage failures are then reported per event
+// instead of failing the batch, so clients don't retry what the queue holds.
 package ingest
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 
+	"github.com/your-org/frustration-engine/internal/enrich"
+	"github.com/your-org/frustration-engine/internal/ingestion"
 	"github.com/your-org/frustration-engine/internal/metrics"
+	"github.com/your-org/frustration-engine/internal/route"
+	"github.com/your-org/frustration-engine/internal/schema"
 	"github.com/your-org/frustration-engine/internal/storage"
 	"github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/validation"
 	pkgtypes "github.com/your-org/frustration-engine/pkg/types"
 )
 
+// Dead letter reasons besides the validation reason codes.
+const (
+	ReasonStorageError = "storage_error"
+	ReasonForwardError = "forward_error"
+)
+
 // SessionForwarder sends events to the session manager.
 type SessionForwarder interface {
-	AddEvents(projectID, sessionID string, events []types.Event)
+	TryAddEvents(projectID, sessionID string, events []types.Event) error
 }
 
 // Handler validates and processes incoming event batches.
 type Handler struct {
-	store     storage.EventStore
-	forwarder SessionForwarder
+	store      storage.EventStore
+	forwarder  SessionForwarder
+	normalizer *route.Normalizer
+	validator  *validation.Pipeline
+	enricher   *enrich.Enricher
+	dlq        *ingestion.DeadLetterQueue
+}
+
+// Result is the outcome of ingesting a batch.
+type Result struct {
+	Processed    int
+	Rejected     []pkgtypes.EventRejection
+	Redactions   map[string]int
+	DeadLettered int
+}
+
+// Unaccepted counts the events of an n-event batch that were not ingested:
+// those rejected by validation, or all of them when ingesting failed with
+// err. Events dead-lettered after a storage error count as accepted, since
+// replaying them ingests them without another quota reservation.
+func (r Result) Unaccepted(n int, err error) int {
+	if err != nil {
+		return n
+	}
+	unaccepted := 0
+	for _, rej := range r.Rejected {
+		if rej.Reason != ReasonStorageError {
+			unaccepted++
+		}
+	}
+	return unaccepted
+}
+
+// NewHandler creates a new ingest handler. PII is masked with the built-in
+// redaction rules until SetRedactor configures others, and events are
+// enriched without geo lookups until SetEnricher configures them.
+func NewHandler(store storage.EventStore, forwarder SessionForwarder, normalizer *route.Normalizer) *Handler {
+	validator := validation.NewPipeline()
+	redactor, _ := validation.NewRedactor(validation.RedactionConfig{})
+	validator.SetRedactor(redactor)
+	return &Handler{
+		store:      store,
+		forwarder:  forwarder,
+		normalizer: normalizer,
+		validator:  validator,
+		enricher:   enrich.New(nil),
+	}
+}
+
+// SetRedactor replaces the PII redaction rules.
+func (h *Handler) SetRedactor(r *validation.Redactor) {
+	h.validator.SetRedactor(r)
+}
+
+// SetEnricher replaces the client context enricher.
+func (h *Handler) SetEnricher(e *enrich.Enricher) {
+	h.enricher = e
 }
 
-// NewHandler creates a new ingest handler.
-func NewHandler(store storage.EventStore, forwarder SessionForwarder) *Handler {
-	return &Handler{store: store, forwarder: forwarder}
+// SetDeadLetterQueue keeps failed events for inspection and replay.
+func (h *Handler) SetDeadLetterQueue(dlq *ingestion.DeadLetterQueue) {
+	h.dlq = dlq
+}
+
+// DeadLetterQueue returns the dead letter queue (nil if none).
+func (h *Handler) DeadLetterQueue() *ingestion.DeadLetterQueue {
+	return h.dlq
 }
 
 // Ingest validates, stores, and forwards a batch of events.
 // Accepts pkg/types.Event from the HTTP layer, converts to internal types.
-func (h *Handler) Ingest(ctx context.Context, projectID string, events []pkgtypes.Event) (int, error) {
-	// Convert and validate
-	valid := make([]types.Event, 0, len(events))
-	for _, e := range events {
-		if e.EventType == "" || e.SessionID == "" {
-			continue
-		}
-		valid = append(valid, types.Event{
+// Rejected events are returned in the result; an error means the valid
+// events could not be stored (nor dead-lettered).
+func (h *Handler) Ingest(ctx context.Context, projectID string, events []pkgtypes.Event) (Result, error) {
+	converted := make([]types.Event, len(events))
+	for i, e := range events {
+		converted[i] = types.Event{
 			EventType:      e.EventType,
 			Timestamp:      e.Timestamp,
 			SessionID:      e.SessionID,
@@ -48,29 +132,214 @@ func (h *Handler) Ingest(ctx context.Context, projectID string, events []pkgtype
 			Metadata:       e.Metadata,
 			Environment:    e.Environment,
 			IdempotencyKey: e.IdempotencyKey,
-		})
+			SchemaVersion:  e.SchemaVersion,
+		}
 	}
+	return h.ingest(ctx, projectID, converted, batchOptions{})
+}
 
+// batchOptions describe a replayed batch.
+type batchOptions struct {
+	retries []int // previous dead letter retry count per event
+	stored  bool  // events are already in the event store
+}
+
+// ingest runs a batch through validation, storage and forwarding.
+func (h *Handler) ingest(ctx context.Context, projectID string, events []types.Event, opts batchOptions) (Result, error) {
+	for i := range events {
+		schema.Upcast(&events[i])
+	}
+	// Replayed events keep the client context of their original request
+	if opts.retries == nil {
+		h.enricher.Enrich(ctx, events)
+	}
+	validated := h.validator.Validate(projectID, events)
+	valid := validated.Valid
+	result := Result{Rejected: rejections(validated.Errors), Redactions: validated.Redactions}
+	for rule, n := range validated.Redactions {
+		metrics.PIIRedactions.WithLabelValues(rule).Add(float64(n))
+	}
+	for _, err := range validated.Errors {
+		if h.deadLetter(ctx, projectID, events[err.EventID], err.Code, err.Reason, opts.retry(err.EventID)) == nil {
+			result.DeadLettered++
+		}
+	}
 	if len(valid) == 0 {
-		return 0, nil
+		return result, nil
 	}
 
-	// Store events
-	if err := h.store.StoreEvents(ctx, projectID, valid); err != nil {
-		log.Printf("[ingest] storage error: %v", err)
-		return 0, err
+	for i := range valid {
+		valid[i].Route = h.normalizer.Normalize(projectID, valid[i].Route)
+		valid[i].Metadata = h.normalizeMetadataRoutes(projectID, valid[i].Metadata)
 	}
 
-	metrics.EventsIngested.Add(float64(len(valid)))
+	// Store events
+	if !opts.stored {
+		if err := h.store.StoreEvents(ctx, projectID, valid); err != nil {
+			log.Printf("[ingest] storage error: %v", err)
+			if h.dlq == nil {
+				return result, err
+			}
+			for i, e := range valid {
+				index := validated.ValidIndex[i]
+				if dlqErr := h.deadLetter(ctx, projectID, e, ReasonStorageError, err.Error(), opts.retry(index)); dlqErr != nil {
+					return result, err
+				}
+				result.Rejected = append(result.Rejected, pkgtypes.EventRejection{
+					Index: index, Reason: ReasonStorageError, Detail: "dead-lettered for replay",
+				})
+				result.DeadLettered++
+			}
+			return result, nil
+		}
+		metrics.EventsIngested.Add(float64(len(valid)))
+		for _, e := range valid {
+			metrics.EventsByRoute.WithLabelValues(metrics.RouteLabel(e.Route)).Inc()
+		}
+	}
 
 	// Group by session and forward to session manager
-	grouped := make(map[string][]types.Event)
-	for _, e := range valid {
-		grouped[e.SessionID] = append(grouped[e.SessionID], e)
+	grouped := make(map[string][]int)
+	for i, e := range valid {
+		grouped[e.SessionID] = append(grouped[e.SessionID], i)
+	}
+	for sessionID, indexes := range grouped {
+		sessionEvents := make([]types.Event, len(indexes))
+		for i, index := range indexes {
+			sessionEvents[i] = valid[index]
+		}
+		if err := h.forwarder.TryAddEvents(projectID, sessionID, sessionEvents); err != nil {
+			log.Printf("[ingest] forward to session %s: %v", sessionID, err)
+			for _, index := range indexes {
+				if h.deadLetter(ctx, projectID, valid[index], ReasonForwardError, err.Error(), opts.retry(validated.ValidIndex[index])) == nil {
+					result.DeadLettered++
+				}
+			}
+		}
+	}
+
+	result.Processed = len(valid)
+	return result, nil
+}
+
+// ReplayResult summarizes a dead letter replay.
+type ReplayResult struct {
+	Replayed     int `json:"replayed"`     // events taken off the queue
+	Processed    int `json:"processed"`    // events accepted this time
+	DeadLettered int `json:"deadLettered"` // events that failed again
+}
+
+var errNoDeadLetterQueue = errors.New("no dead letter queue")
+
+// Replay re-runs dead-lettered events through ingestion, batched per
+// project. They leave the queue first; events that fail again are
+// dead-lettered anew with a higher retry count. Events that failed session
+// forwarding were already stored and skip storage.
+func (h *Handler) Replay(ctx context.Context, deadLetters []ingestion.DeadLetterEvent) (ReplayResult, error) {
+	var result ReplayResult
+	if h.dlq == nil {
+		return result, errNoDeadLetterQueue
+	}
+	if len(deadLetters) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, len(deadLetters))
+	for i, dl := range deadLetters {
+		ids[i] = dl.ID
+	}
+	if err := h.dlq.Remove(ctx, ids); err != nil {
+		return result, fmt.Errorf("remove replayed events: %w", err)
+	}
+	result.Replayed = len(deadLetters)
+
+	type batchKey struct {
+		projectID string
+		stored    bool
+	}
+	var order []batchKey
+	batches := make(map[batchKey][]ingestion.DeadLetterEvent)
+	for _, dl := range deadLetters {
+		key := batchKey{dl.ProjectID, dl.Reason == ReasonForwardError}
+		if _, ok := batches[key]; !ok {
+			order = append(order, key)
+		}
+		batches[key] = append(batches[key], dl)
+	}
+
+	for _, key := range order {
+		batch := batches[key]
+		events := make([]types.Event, len(batch))
+		retries := make([]int, len(batch))
+		for i, dl := range batch {
+			events[i] = dl.Event
+			retries[i] = dl.RetryCount
+		}
+		ingested, err := h.ingest(ctx, key.projectID, events, batchOptions{retries: retries, stored: key.stored})
+		if err != nil {
+			return result, err
+		}
+		result.Processed += ingested.Processed
+		result.DeadLettered += ingested.DeadLettered
+	}
+	return result, nil
+}
+
+func (o batchOptions) retry(index int) int {
+	if o.retries == nil {
+		return 0
+	}
+	return o.retries[index] + 1
+}
+
+// deadLetter keeps a failed event in the dead letter queue. It fails when
+// there is no queue or the queue could not persist the event.
+func (h *Handler) deadLetter(ctx context.Context, projectID string, event types.Event, reason, detail string, retryCount int) error {
+	if h.dlq == nil {
+		return errNoDeadLetterQueue
 	}
-	for sessionID, sessionEvents := range grouped {
-		h.forwarder.AddEvents(projectID, sessionID, sessionEvents)
+	metrics.EventsDeadLettered.WithLabelValues(reason).Inc()
+	err := h.dlq.Add(ctx, ingestion.DeadLetterEvent{
+		Event:      event,
+		Reason:     reason,
+		Detail:     detail,
+		RetryCount: retryCount,
+		ProjectID:  projectID,
+	})
+	if err != nil {
+		return fmt.Errorf("dead letter event: %w", err)
 	}
+	return nil
+}
 
-	return len(valid), nil
+// rejections converts validation errors for the response and counts them.
+func rejections(errs []types.ValidationError) []pkgtypes.EventRejection {
+	if len(errs) == 0 {
+		return nil
+	}
+	out := make([]pkgtypes.EventRejection, len(errs))
+	for i, err := range errs {
+		metrics.EventsRejected.WithLabelValues(err.Code).Inc()
+		out[i] = pkgtypes.EventRejection{
+			Index:  err.EventID,
+			Field:  err.Field,
+			Reason: err.Code,
+			Detail: err.Reason,
+		}
+	}
+	return out
+}
+
+// normalizeMetadataRoutes rewrites navigation metadata ("from"/"to") so that
+// route transitions are grouped by template as well.
+func (h *Handler) normalizeMetadataRoutes(projectID string, metadata map[string]interface{}) map[string]interface{} {
+	if metadata == nil {
+		return nil
+	}
+	for _, key := range []string{"from", "to"} {
+		if v, ok := metadata[key].(string); ok && v != "" {
+			metadata[key] = h.normalizer.Normalize(projectID, v)
+		}
+	}
+	return metadata
 }
@@ -0,0 +1,209 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/enrich"
+	"github.com/your-org/frustration-engine/internal/ingestion"
+	"github.com/your-org/frustration-engine/internal/route"
+	"github.com/your-org/frustration-engine/internal/types"
+	pkgtypes "github.com/your-org/frustration-engine/pkg/types"
+)
+
+// flakyStore fails StoreEvents while down is set.
+type flakyStore struct {
+	down   bool
+	stored int
+}
+
+func (s *flakyStore) StoreEvents(ctx context.Context, projectID string, events []types.Event) error {
+	if s.down {
+		return errors.New("connection refused")
+	}
+	s.stored += len(events)
+	return nil
+}
+
+func (s *flakyStore) Close() error { return nil }
+
+// recordingForwarder records forwarded events.
+type recordingForwarder struct {
+	events    int
+	forwarded []types.Event
+}
+
+func (f *recordingForwarder) TryAddEvents(projectID, sessionID string, events []types.Event) error {
+	f.events += len(events)
+	f.forwarded = append(f.forwarded, events...)
+	return nil
+}
+
+func click(ts string) pkgtypes.Event {
+	return pkgtypes.Event{
+		EventType: "click",
+		Timestamp: ts,
+		SessionID: "s1",
+		Route:     "/checkout",
+		Target:    pkgtypes.EventTarget{Type: "button", ID: "pay"},
+	}
+}
+
+func TestIngest_DeadLettersAndReplaysStorageFailures(t *testing.T) {
+	ctx := context.Background()
+	store := &flakyStore{down: true}
+	forwarder := &recordingForwarder{}
+	h := NewHandler(store, forwarder, route.NewNormalizer())
+	dlq := ingestion.NewDeadLetterQueue(100, ingestion.NewInMemoryDeadLetterStorage())
+	h.SetDeadLetterQueue(dlq)
+
+	now := time.Now().Format(time.RFC3339)
+	result, err := h.Ingest(ctx, "web", []pkgtypes.Event{click(now), click("yesterday"), click(now)})
+	if err != nil {
+		t.Fatalf("Ingest() error = %v, want storage failures dead-lettered", err)
+	}
+	if result.Processed != 0 || result.DeadLettered != 3 || len(result.Rejected) != 3 {
+		t.Fatalf("result = %+v, want 3 events dead-lettered", result)
+	}
+
+	storageFailures, _ := dlq.List(ctx, ingestion.DeadLetterFilter{Reason: ReasonStorageError})
+	if len(storageFailures) != 2 || storageFailures[0].ProjectID != "web" {
+		t.Fatalf("storage failures = %+v, want 2 for project web", storageFailures)
+	}
+
+	// The store recovers: storage failures replay cleanly
+	store.down = false
+	replay, err := h.Replay(ctx, storageFailures)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if replay.Replayed != 2 || replay.Processed != 2 || replay.DeadLettered != 0 || store.stored != 2 || forwarder.events != 2 {
+		t.Errorf("replay = %+v (stored %d, forwarded %d), want 2 processed", replay, store.stored, forwarder.events)
+	}
+
+	// The invalid event fails again and is dead-lettered with a retry
+	invalid, _ := dlq.List(ctx, ingestion.DeadLetterFilter{})
+	if len(invalid) != 1 || invalid[0].Reason != "invalid_timestamp" {
+		t.Fatalf("remaining dead letters = %+v, want the invalid event", invalid)
+	}
+	if replay, _ := h.Replay(ctx, invalid); replay.DeadLettered != 1 {
+		t.Errorf("replay of invalid event = %+v, want it dead-lettered again", replay)
+	}
+	again, _ := dlq.List(ctx, ingestion.DeadLetterFilter{})
+	if len(again) != 1 || again[0].ID == invalid[0].ID || again[0].RetryCount != 1 {
+		t.Errorf("dead letters after retry = %+v, want one new entry with retryCount 1", again)
+	}
+}
+
+func TestIngest_StorageFailureWithoutDeadLetterQueue(t *testing.T) {
+	h := NewHandler(&flakyStore{down: true}, &recordingForwarder{}, route.NewNormalizer())
+	if _, err := h.Ingest(context.Background(), "web", []pkgtypes.Event{click(time.Now().Format(time.RFC3339))}); err == nil {
+		t.Error("Ingest() without a dead letter queue succeeded, want the storage error")
+	}
+}
+
+// saturatedForwarder reports a full backlog for the first checks.
+type saturatedForwarder struct {
+	recordingForwarder
+	fullChecks int
+}
+
+func (f *saturatedForwarder) Backlog() float64 {
+	if f.fullChecks > 0 {
+		f.fullChecks--
+		return 1
+	}
+	return 0
+}
+
+func TestStream_ChunksAndReportsLines(t *testing.T) {
+	forwarder := &saturatedForwarder{fullChecks: 2}
+	h := NewHandler(&flakyStore{}, forwarder, route.NewNormalizer())
+
+	now := time.Now().Format(time.RFC3339)
+	valid, _ := json.Marshal(click(now))
+	var body strings.Builder
+	body.WriteString(`{"eventType": "click",` + "\n")                                         // line 1: truncated JSON
+	body.WriteString("\n")                                                                    // line 2: blank
+	body.WriteString(`{"eventType":"click","timestamp":"yesterday","sessionId":"s1"}` + "\n") // line 3: invalid
+	body.WriteString(`{"pad":"` + strings.Repeat("x", MaxStreamLineSize) + `"}` + "\n")       // line 4: too long
+	for i := 0; i < 2*StreamChunkSize+50; i++ {                                               // lines 5-254
+		body.Write(valid)
+		body.WriteString("\r\n")
+	}
+	body.Write(valid) // line 255, no trailing newline
+
+	var admitted []int
+	result, err := h.Stream(context.Background(), "web", strings.NewReader(body.String()), StreamOptions{
+		Admit: func(ctx context.Context, events int) error {
+			admitted = append(admitted, events)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if result.Lines != 255 || result.Processed != 251 || result.Rejected != 3 || forwarder.events != 251 {
+		t.Errorf("result = lines %d, processed %d, rejected %d (forwarded %d); want 255, 251, 3",
+			result.Lines, result.Processed, result.Rejected, forwarder.events)
+	}
+	if len(admitted) != 3 || admitted[0] != StreamChunkSize || admitted[2] != 52 {
+		t.Errorf("admitted chunks = %v, want %d, %d, 52", admitted, StreamChunkSize, StreamChunkSize)
+	}
+	wantErrors := []struct {
+		line   int
+		reason string
+	}{{1, ReasonInvalidJSON}, {3, "invalid_timestamp"}, {4, "too_large"}}
+	for i, want := range wantErrors {
+		if i >= len(result.Errors) || result.Errors[i].Line != want.line || result.Errors[i].Reason != want.reason {
+			t.Errorf("errors = %+v, want line %d rejected as %s", result.Errors, want.line, want.reason)
+		}
+	}
+	if result.Throttled != 1 {
+		t.Errorf("throttled = %d, want the first chunk held back", result.Throttled)
+	}
+}
+
+func TestStream_StopsWhenNotAdmitted(t *testing.T) {
+	h := NewHandler(&flakyStore{}, &recordingForwarder{}, route.NewNormalizer())
+	valid, _ := json.Marshal(click(time.Now().Format(time.RFC3339)))
+	body := strings.Repeat(string(valid)+"\n", 2*StreamChunkSize)
+
+	quota := errors.New("quota exhausted")
+	chunks := 0
+	result, err := h.Stream(context.Background(), "web", strings.NewReader(body), StreamOptions{
+		Admit: func(ctx context.Context, events int) error {
+			if chunks++; chunks > 1 {
+				return quota
+			}
+			return nil
+		},
+	})
+	if !errors.Is(err, quota) || result.Lines != StreamChunkSize || result.Processed != StreamChunkSize {
+		t.Errorf("Stream() = %+v, %v; want stopped after line %d", result, err, StreamChunkSize)
+	}
+}
+
+func TestIngest_EnrichesFromRequest(t *testing.T) {
+	forwarder := &recordingForwarder{}
+	h := NewHandler(&flakyStore{}, forwarder, route.NewNormalizer())
+
+	ctx := enrich.WithSource(context.Background(), enrich.Source{
+		UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+		Release:   "web@3.2.0",
+	})
+	if _, err := h.Ingest(ctx, "p1", []pkgtypes.Event{click(time.Now().UTC().Format(time.RFC3339))}); err != nil {
+		t.Fatalf("Ingest: %v", err)
+	}
+	if len(forwarder.forwarded) != 1 {
+		t.Fatalf("forwarded %d events, want 1", len(forwarder.forwarded))
+	}
+	client, ok := enrich.FromMetadata(forwarder.forwarded[0].Metadata)
+	if !ok || client.OS != "iOS" || client.DeviceClass != enrich.DeviceMobile || client.Release != "web@3.2.0" {
+		t.Errorf("client = %+v, want iOS mobile web@3.2.0", client)
+	}
+}
@@ -0,0 +1,245 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/metrics"
+	"github.com/your-org/frustration-engine/internal/validation"
+	pkgtypes "github.com/your-org/frustration-engine/pkg/types"
+)
+
+// Streaming limits.
+const (
+	// StreamChunkSize is how many events are ingested at a time, the
+	// validation batch limit.
+	StreamChunkSize = validation.MaxBatchSize
+	// MaxStreamLineSize caps one NDJSON line, like a whole /v1/events body.
+	MaxStreamLineSize = validation.MaxPayloadSize
+	// maxStreamErrors is how many line errors a stream reports in full.
+	maxStreamErrors = 100
+)
+
+// ReasonInvalidJSON rejects stream lines that do not decode as an event.
+const ReasonInvalidJSON = "invalid_json"
+
+// ErrStreamRead wraps failures to read the stream itself (as opposed to
+// failures to ingest it).
+var ErrStreamRead = errors.New("read stream")
+
+// Backpressure: chunks wait while the session emission channel is at least
+// this full, checking every streamBackoff.
+const (
+	streamBacklogThreshold = 0.8
+	streamBackoff          = 50 * time.Millisecond
+)
+
+// backlogger is implemented by forwarders that report saturation, such as
+// session.Manager.
+type backlogger interface {
+	Backlog() float64
+}
+
+// StreamOptions tune Stream.
+type StreamOptions struct {
+	// Admit is called with each chunk's event count before it is ingested;
+	// an error stops the stream there (e.g. an exhausted quota).
+	Admit func(ctx context.Context, events int) error
+	// Release is called with the events of an admitted chunk that were not
+	// ingested (see Result.Unaccepted), e.g. to refund their quota.
+	Release func(events int)
+}
+
+// StreamResult is the outcome of an NDJSON stream.
+type StreamResult struct {
+	Lines           int // lines handled; a stopped stream resumes after these
+	Processed       int
+	Rejected        int
+	DeadLettered    int
+	Throttled       int // chunks held back by backpressure
+	Errors          []pkgtypes.LineError
+	ErrorCounts     map[string]int
+	ErrorsTruncated bool
+	Redactions      map[string]int
+}
+
+// Stream ingests newline-delimited events from r. Lines are decoded one at a
+// time and ingested in chunks of StreamChunkSize, so memory stays bounded
+// however long the stream. Blank lines are skipped; lines that are not valid
+// JSON or fail validation are reported by line number. Before each chunk,
+// Stream waits while the session manager's emission channel is saturated,
+// which in turn stops reading from r.
+//
+// An error (reading r, from Admit, or from storage) stops the stream; the
+// result covers the chunks ingested before it. Read errors wrap
+// ErrStreamRead.
+func (h *Handler) Stream(ctx context.Context, projectID string, r io.Reader, opts StreamOptions) (StreamResult, error) {
+	result, err := h.stream(ctx, projectID, r, opts)
+	// Parse errors are recorded as lines are read, validation errors when
+	// their chunk is ingested
+	sort.SliceStable(result.Errors, func(i, j int) bool { return result.Errors[i].Line < result.Errors[j].Line })
+	return result, err
+}
+
+func (h *Handler) stream(ctx context.Context, projectID string, r io.Reader, opts StreamOptions) (StreamResult, error) {
+	result := StreamResult{ErrorCounts: make(map[string]int)}
+	chunk := make([]pkgtypes.Event, 0, StreamChunkSize)
+	chunkLines := make([]int, 0, StreamChunkSize)
+
+	flush := func(lastLine int) error {
+		if len(chunk) > 0 {
+			if h.waitForCapacity(ctx) {
+				result.Throttled++
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if opts.Admit != nil {
+				if err := opts.Admit(ctx, len(chunk)); err != nil {
+					return err
+				}
+			}
+			ingested, err := h.Ingest(ctx, projectID, chunk)
+			if opts.Release != nil {
+				opts.Release(ingested.Unaccepted(len(chunk), err))
+			}
+			for _, rej := range ingested.Rejected {
+				result.addError(pkgtypes.LineError{
+					Line: chunkLines[rej.Index], Field: rej.Field, Reason: rej.Reason, Detail: rej.Detail,
+				})
+			}
+			if err != nil {
+				return err
+			}
+			result.Processed += ingested.Processed
+			result.DeadLettered += ingested.DeadLettered
+			for rule, n := range ingested.Redactions {
+				if result.Redactions == nil {
+					result.Redactions = make(map[string]int)
+				}
+				result.Redactions[rule] += n
+			}
+		}
+		result.Lines = lastLine
+		chunk, chunkLines = chunk[:0], chunkLines[:0]
+		return nil
+	}
+
+	br := bufio.NewReaderSize(r, 64*1024)
+	for line := 1; ; line++ {
+		data, err := readLine(br, MaxStreamLineSize)
+		if errors.Is(err, io.EOF) && data == nil {
+			return result, flush(line - 1)
+		}
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, errLineTooLong) {
+			if flushErr := flush(line - 1); flushErr != nil {
+				return result, flushErr
+			}
+			return result, fmt.Errorf("%w: %v", ErrStreamRead, err)
+		}
+
+		switch {
+		case errors.Is(err, errLineTooLong):
+			metrics.EventsRejected.WithLabelValues(validation.ReasonTooLarge).Inc()
+			result.addError(pkgtypes.LineError{
+				Line: line, Reason: validation.ReasonTooLarge,
+				Detail: fmt.Sprintf("line exceeds %d bytes", MaxStreamLineSize),
+			})
+		case len(bytes.TrimSpace(data)) == 0:
+		default:
+			var event pkgtypes.Event
+			if jsonErr := json.Unmarshal(data, &event); jsonErr != nil {
+				metrics.EventsRejected.WithLabelValues(ReasonInvalidJSON).Inc()
+				result.addError(pkgtypes.LineError{Line: line, Reason: ReasonInvalidJSON, Detail: jsonErr.Error()})
+			} else {
+				chunk = append(chunk, event)
+				chunkLines = append(chunkLines, line)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return result, flush(line)
+		}
+		if len(chunk) == StreamChunkSize {
+			if err := flush(line); err != nil {
+				return result, err
+			}
+		}
+	}
+}
+
+// addError records a line error, keeping the first maxStreamErrors.
+func (r *StreamResult) addError(err pkgtypes.LineError) {
+	r.Rejected++
+	r.ErrorCounts[err.Reason]++
+	if len(r.Errors) < maxStreamErrors {
+		r.Errors = append(r.Errors, err)
+	} else {
+		r.ErrorsTruncated = true
+	}
+}
+
+// waitForCapacity blocks while the forwarder reports a saturated backlog,
+// until it drains or ctx ends. It reports whether it waited.
+func (h *Handler) waitForCapacity(ctx context.Context) bool {
+	b, ok := h.forwarder.(backlogger)
+	if !ok || b.Backlog() < streamBacklogThreshold {
+		return false
+	}
+	ticker := time.NewTicker(streamBackoff)
+	defer ticker.Stop()
+	for b.Backlog() >= streamBacklogThreshold {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-ticker.C:
+		}
+	}
+	return true
+}
+
+var errLineTooLong = errors.New("line too long")
+
+// readLine returns the next line without its newline. A line over max bytes
+// is skipped to its end and reported as errLineTooLong. The last line may
+// come with io.EOF; at the end of input readLine returns nil, io.EOF.
+func readLine(br *bufio.Reader, max int) ([]byte, error) {
+	var line []byte
+	tooLong := false
+	for {
+		fragment, err := br.ReadSlice('\n')
+		if !tooLong {
+			if len(line)+len(fragment) > max+1 { // +1 for the newline
+				tooLong, line = true, nil
+			} else {
+				line = append(line, fragment...)
+			}
+		}
+		switch {
+		case errors.Is(err, bufio.ErrBufferFull):
+			continue
+		case err == nil:
+			if tooLong {
+				return nil, errLineTooLong
+			}
+			return bytes.TrimRight(line, "\r\n"), nil
+		case errors.Is(err, io.EOF):
+			if tooLong {
+				return nil, errLineTooLong
+			}
+			if len(line) == 0 {
+				return nil, io.EOF
+			}
+			return line, io.EOF
+		default:
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/**
+ * File Dead Letter Storage
+ *
+ * Responsibility: Persist dead-lettered events across restarts
+ *
+ * The file holds one JSON event per line. Store appends a line; Delete and
+ * Prune rewrite the file atomically (temp file + rename)
+ */
+
+package ingestion
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/validation"
+)
+
+// FileDeadLetterStorage is a DeadLetterStorage backed by a JSON lines file
+type FileDeadLetterStorage struct {
+	path string
+	mem  *InMemoryDeadLetterStorage
+	mu   sync.Mutex // serializes writes to path
+}
+
+// NewFileDeadLetterStorage opens (or creates) dead letter storage at path
+func NewFileDeadLetterStorage(path string) (*FileDeadLetterStorage, error) {
+	s := &FileDeadLetterStorage{path: path, mem: NewInMemoryDeadLetterStorage()}
+
+	// Fail now rather than on the first dead letter if path isn't writable
+	w, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open dead letter file: %w", err)
+	}
+	w.Close()
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read dead letter file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 2*validation.MaxEventSizeEdgeCase) // room for the envelope
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event DeadLetterEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("parse dead letter file line %d: %w", line, err)
+		}
+		s.mem.events = append(s.mem.events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read dead letter file: %w", err)
+	}
+	return s, nil
+}
+
+// Store appends an event to the file
+func (s *FileDeadLetterStorage) Store(ctx context.Context, event DeadLetterEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("write dead letter file: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("write dead letter file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write dead letter file: %w", err)
+	}
+	return s.mem.Store(ctx, event)
+}
+
+// Query queries events
+func (s *FileDeadLetterStorage) Query(ctx context.Context, projectID string, limit int) ([]DeadLetterEvent, error) {
+	return s.mem.Query(ctx, projectID, limit)
+}
+
+// List returns matching events, oldest first
+func (s *FileDeadLetterStorage) List(ctx context.Context, filter DeadLetterFilter) ([]DeadLetterEvent, error) {
+	return s.mem.List(ctx, filter)
+}
+
+// Delete removes events by ID and rewrites the file
+func (s *FileDeadLetterStorage) Delete(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mem.Delete(ctx, ids)
+	return s.flush()
+}
+
+// Prune applies retention and rewrites the file if anything was dropped
+func (s *FileDeadLetterStorage) Prune(ctx context.Context, before time.Time, maxEvents int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dropped, _ := s.mem.Prune(ctx, before, maxEvents)
+	if dropped == 0 {
+		return 0, nil
+	}
+	return dropped, s.flush()
+}
+
+// flush writes every event to a temp file and renames it over path
+func (s *FileDeadLetterStorage) flush() error {
+	events, _ := s.mem.List(context.Background(), DeadLetterFilter{})
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write dead letter file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			tmp.Close()
+			return fmt.Errorf("write dead letter file: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write dead letter file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write dead letter file: %w", err)
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,43 @@
+package ingestion
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+func TestFileDeadLetterStorage_PersistsAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dlq.jsonl")
+
+	storage, err := NewFileDeadLetterStorage(path)
+	if err != nil {
+		t.Fatalf("NewFileDeadLetterStorage() error = %v", err)
+	}
+	dlq := NewDeadLetterQueue(2, storage)
+	dlq.SetRetention(time.Hour)
+
+	old := time.Now().Add(-2 * time.Hour)
+	dlq.Add(ctx, DeadLetterEvent{ID: "old", ProjectID: "web", Reason: "storage_error", Timestamp: old})
+	for _, id := range []string{"a", "b", "c"} {
+		dlq.Add(ctx, DeadLetterEvent{ID: id, ProjectID: "web", Reason: "missing_field", Event: types.Event{SessionID: id}})
+	}
+	dlq.Remove(ctx, []string{"a"})
+
+	// Retention drops the expired event; the size limit keeps the newest 2
+	if n, err := dlq.Prune(ctx); err != nil || n != 1 {
+		t.Fatalf("Prune() = %d, %v; want 1 dropped", n, err)
+	}
+
+	reopened, err := NewFileDeadLetterStorage(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	events, _ := reopened.List(ctx, DeadLetterFilter{Reason: "missing_field"})
+	if len(events) != 2 || events[0].ID != "b" || events[1].Event.SessionID != "c" {
+		t.Errorf("events after restart = %+v, want b and c", events)
+	}
+}
@@ -1,10 +1,14 @@
 /**
  * Dead Letter Queue
- * 
+ *
  * Author: Team Alpha (Bob, Charlie, Diana)
  * Responsibility: Store failed events for later analysis and retry
- * 
+ *
  * Handles events that fail validation or cannot be processed
+ *
+ * Retention: the in-memory queue keeps at most maxSize events; storage is
+ * pruned to maxSize events no older than the retention age on every Prune
+ * (Start runs it periodically)
  */
 
 package ingestion
@@ -13,9 +17,12 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/your-org/frustration-engine/internal/types"
 )
 
@@ -24,22 +31,57 @@ type DeadLetterQueue struct {
 	mu      sync.RWMutex
 	queue   []DeadLetterEvent
 	maxSize int
+	maxAge  time.Duration
 	storage DeadLetterStorage
 }
 
 // DeadLetterEvent represents a failed event
 type DeadLetterEvent struct {
-	Event     types.Event
-	Reason    string
-	Timestamp time.Time
-	RetryCount int
+	ID         string      `json:"id"`
+	Event      types.Event `json:"event"`
+	Reason     string      `json:"reason"`
+	Detail     string      `json:"detail,omitempty"`
+	Timestamp  time.Time   `json:"timestamp"`
+	RetryCount int         `json:"retryCount"`
+	ProjectID  string      `json:"projectId"`
+}
+
+// DeadLetterFilter selects dead-lettered events (empty fields match all)
+type DeadLetterFilter struct {
 	ProjectID string
+	Reason    string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+// Matches reports whether an event passes the filter (ignoring Limit)
+func (f DeadLetterFilter) Matches(event DeadLetterEvent) bool {
+	if f.ProjectID != "" && event.ProjectID != f.ProjectID {
+		return false
+	}
+	if f.Reason != "" && event.Reason != f.Reason {
+		return false
+	}
+	if !f.Since.IsZero() && event.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && event.Timestamp.After(f.Until) {
+		return false
+	}
+	return true
 }
 
 // DeadLetterStorage interface for persistent storage
 type DeadLetterStorage interface {
 	Store(ctx context.Context, event DeadLetterEvent) error
 	Query(ctx context.Context, projectID string, limit int) ([]DeadLetterEvent, error)
+	// List returns matching events, oldest first
+	List(ctx context.Context, filter DeadLetterFilter) ([]DeadLetterEvent, error)
+	Delete(ctx context.Context, ids []string) error
+	// Prune drops events older than before and the oldest beyond maxEvents
+	// (0 = unbounded), returning how many were dropped
+	Prune(ctx context.Context, before time.Time, maxEvents int) (int, error)
 }
 
 // NewDeadLetterQueue creates a new dead letter queue
@@ -51,64 +93,128 @@ func NewDeadLetterQueue(maxSize int, storage DeadLetterStorage) *DeadLetterQueue
 	}
 }
 
-// Enqueue adds an event to the dead letter queue
-func (dlq *DeadLetterQueue) Enqueue(ctx context.Context, event types.Event, reason string) error {
+// SetRetention drops events older than maxAge on Prune (0 = keep until
+// maxSize is reached)
+func (dlq *DeadLetterQueue) SetRetention(maxAge time.Duration) {
 	dlq.mu.Lock()
 	defer dlq.mu.Unlock()
+	dlq.maxAge = maxAge
+}
 
+// Enqueue adds an event to the dead letter queue
+func (dlq *DeadLetterQueue) Enqueue(ctx context.Context, event types.Event, reason string) error {
 	// Get project ID from context or event metadata
 	projectID := getProjectIDFromContext(ctx)
 	if projectID == "" {
 		projectID = "unknown"
 	}
 
-	dlEvent := DeadLetterEvent{
-		Event:      event,
-		Reason:     reason,
-		Timestamp:  time.Now(),
-		RetryCount: 0,
-		ProjectID:  projectID,
+	return dlq.Add(ctx, DeadLetterEvent{Event: event, Reason: reason, ProjectID: projectID})
+}
+
+// Add adds a dead letter, assigning its ID and timestamp. Storage failures
+// are returned: the event is then only in the in-memory queue
+func (dlq *DeadLetterQueue) Add(ctx context.Context, dlEvent DeadLetterEvent) error {
+	dlq.mu.Lock()
+	defer dlq.mu.Unlock()
+
+	if dlEvent.ID == "" {
+		dlEvent.ID = uuid.New().String()
+	}
+	if dlEvent.Timestamp.IsZero() {
+		dlEvent.Timestamp = time.Now()
 	}
 
 	// Store persistently if storage is available
+	var storeErr error
 	if dlq.storage != nil {
-		if err := dlq.storage.Store(ctx, dlEvent); err != nil {
-			log.Printf("[DeadLetterQueue] Failed to store event: %v", err)
+		if storeErr = dlq.storage.Store(ctx, dlEvent); storeErr != nil {
+			log.Printf("[DeadLetterQueue] Failed to store event: %v", storeErr)
 		}
 	}
 
 	// Add to in-memory queue
-	if len(dlq.queue) >= dlq.maxSize {
+	if dlq.maxSize > 0 && len(dlq.queue) >= dlq.maxSize {
 		// Remove oldest event
 		dlq.queue = dlq.queue[1:]
 	}
 	dlq.queue = append(dlq.queue, dlEvent)
 
-	log.Printf("[DeadLetterQueue] Enqueued event: session=%s, reason=%s", event.SessionID, reason)
-	return nil
+	log.Printf("[DeadLetterQueue] Enqueued event: session=%s, reason=%s", dlEvent.Event.SessionID, dlEvent.Reason)
+	return storeErr
 }
 
 // GetFailedEvents returns failed events for a project
 func (dlq *DeadLetterQueue) GetFailedEvents(ctx context.Context, projectID string, limit int) ([]DeadLetterEvent, error) {
+	return dlq.List(ctx, DeadLetterFilter{ProjectID: projectID, Limit: limit})
+}
+
+// List returns failed events matching a filter, oldest first
+func (dlq *DeadLetterQueue) List(ctx context.Context, filter DeadLetterFilter) ([]DeadLetterEvent, error) {
 	dlq.mu.RLock()
 	defer dlq.mu.RUnlock()
 
 	if dlq.storage != nil {
-		return dlq.storage.Query(ctx, projectID, limit)
+		return dlq.storage.List(ctx, filter)
 	}
 
 	// Return from in-memory queue
-	var results []DeadLetterEvent
+	return filterDeadLetters(dlq.queue, filter), nil
+}
+
+// Remove deletes events by ID (e.g. after they were replayed)
+func (dlq *DeadLetterQueue) Remove(ctx context.Context, ids []string) error {
+	dlq.mu.Lock()
+	defer dlq.mu.Unlock()
+
+	dlq.queue = removeDeadLetters(dlq.queue, ids)
+	if dlq.storage != nil {
+		return dlq.storage.Delete(ctx, ids)
+	}
+	return nil
+}
+
+// Prune applies the retention limits, returning how many events were dropped
+func (dlq *DeadLetterQueue) Prune(ctx context.Context) (int, error) {
+	dlq.mu.Lock()
+	defer dlq.mu.Unlock()
+
+	var before time.Time
+	if dlq.maxAge > 0 {
+		before = time.Now().Add(-dlq.maxAge)
+	}
+	kept := dlq.queue[:0]
 	for _, event := range dlq.queue {
-		if event.ProjectID == projectID {
-			results = append(results, event)
-			if len(results) >= limit {
-				break
-			}
+		if !event.Timestamp.Before(before) {
+			kept = append(kept, event)
 		}
 	}
+	dropped := len(dlq.queue) - len(kept)
+	dlq.queue = kept
 
-	return results, nil
+	if dlq.storage != nil {
+		return dlq.storage.Prune(ctx, before, dlq.maxSize)
+	}
+	return dropped, nil
+}
+
+// Start runs Prune every interval until ctx is cancelled
+func (dlq *DeadLetterQueue) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := dlq.Prune(ctx); err != nil {
+				log.Printf("[DeadLetterQueue] Prune failed: %v", err)
+			} else if n > 0 {
+				log.Printf("[DeadLetterQueue] Pruned %d events past retention", n)
+			}
+		}
+	}
 }
 
 // getProjectIDFromContext extracts project ID from context
@@ -122,6 +228,35 @@ func getProjectIDFromContext(ctx context.Context) string {
 	return ""
 }
 
+// filterDeadLetters returns events matching a filter, oldest first
+func filterDeadLetters(events []DeadLetterEvent, filter DeadLetterFilter) []DeadLetterEvent {
+	var results []DeadLetterEvent
+	for _, event := range events {
+		if filter.Matches(event) {
+			results = append(results, event)
+			if filter.Limit > 0 && len(results) >= filter.Limit {
+				break
+			}
+		}
+	}
+	return results
+}
+
+// removeDeadLetters drops events by ID, in place
+func removeDeadLetters(events []DeadLetterEvent, ids []string) []DeadLetterEvent {
+	remove := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		remove[id] = true
+	}
+	kept := events[:0]
+	for _, event := range events {
+		if !remove[event.ID] {
+			kept = append(kept, event)
+		}
+	}
+	return kept
+}
+
 // InMemoryDeadLetterStorage is an in-memory implementation for testing
 type InMemoryDeadLetterStorage struct {
 	mu     sync.RWMutex
@@ -145,20 +280,42 @@ func (s *InMemoryDeadLetterStorage) Store(ctx context.Context, event DeadLetterE
 
 // Query queries events
 func (s *InMemoryDeadLetterStorage) Query(ctx context.Context, projectID string, limit int) ([]DeadLetterEvent, error) {
+	return s.List(ctx, DeadLetterFilter{ProjectID: projectID, Limit: limit})
+}
+
+// List returns matching events, oldest first
+func (s *InMemoryDeadLetterStorage) List(ctx context.Context, filter DeadLetterFilter) ([]DeadLetterEvent, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return filterDeadLetters(s.events, filter), nil
+}
 
-	var results []DeadLetterEvent
+// Delete removes events by ID
+func (s *InMemoryDeadLetterStorage) Delete(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = removeDeadLetters(s.events, ids)
+	return nil
+}
+
+// Prune drops events older than before and the oldest beyond maxEvents
+func (s *InMemoryDeadLetterStorage) Prune(ctx context.Context, before time.Time, maxEvents int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sort.SliceStable(s.events, func(i, j int) bool { return s.events[i].Timestamp.Before(s.events[j].Timestamp) })
+	kept := s.events[:0]
 	for _, event := range s.events {
-		if event.ProjectID == projectID {
-			results = append(results, event)
-			if len(results) >= limit {
-				break
-			}
+		if !event.Timestamp.Before(before) {
+			kept = append(kept, event)
 		}
 	}
-
-	return results, nil
+	if maxEvents > 0 && len(kept) > maxEvents {
+		kept = kept[len(kept)-maxEvents:]
+	}
+	dropped := len(s.events) - len(kept)
+	s.events = kept
+	return dropped, nil
 }
 
 // LogDeadLetterStorage logs events instead of storing (for testing)
@@ -181,3 +338,18 @@ func (s *LogDeadLetterStorage) Store(ctx context.Context, event DeadLetterEvent)
 func (s *LogDeadLetterStorage) Query(ctx context.Context, projectID string, limit int) ([]DeadLetterEvent, error) {
 	return []DeadLetterEvent{}, nil
 }
+
+// List returns empty (log-only mode)
+func (s *LogDeadLetterStorage) List(ctx context.Context, filter DeadLetterFilter) ([]DeadLetterEvent, error) {
+	return []DeadLetterEvent{}, nil
+}
+
+// Delete is a no-op (log-only mode)
+func (s *LogDeadLetterStorage) Delete(ctx context.Context, ids []string) error {
+	return nil
+}
+
+// Prune is a no-op (log-only mode)
+func (s *LogDeadLetterStorage) Prune(ctx context.Context, before time.Time, maxEvents int) (int, error) {
+	return 0, nil
+}
@@ -0,0 +1,44 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	oldtypes "github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+// GetIssueRef returns the exporter's view of an issue: its ticket, if any,
+// and the cross-session evidence to put in a new ticket.
+// Implements exporter.IssueSource.
+func (s *Service) GetIssueRef(ctx context.Context, issueID string) (oldtypes.IssueRef, bool, error) {
+	issue, ok, err := s.Get(ctx, issueID)
+	if err != nil || !ok {
+		return oldtypes.IssueRef{}, ok, err
+	}
+	return oldtypes.IssueRef{
+		IssueID:          issue.IssueID,
+		ExternalTicketID: issue.ExternalTicketID,
+		ExternalSystem:   issue.ExternalSystem,
+		Evidence: oldtypes.Evidence{
+			AffectedSessions:   issue.AffectedSessions,
+			TimeWindow:         fmt.Sprintf("between %s and %s", issue.FirstSeen.Format("Jan 2 15:04"), issue.LastSeen.Format("Jan 2 15:04")),
+			ExampleSessionRefs: issue.ExampleSessionIDs,
+		},
+		Regressed:       issue.Status == types.IssueStatusRegressed,
+		RegressionCount: issue.RegressionCount,
+	}, true, nil
+}
+
+// MarkIssueExported records the ticket filed for an issue.
+// Implements exporter.IssueSource.
+func (s *Service) MarkIssueExported(ctx context.Context, issueID, externalTicketID, externalSystem string) error {
+	return s.MarkExported(ctx, issueID, externalTicketID, externalSystem)
+}
+
+// ResolveIssue records that an issue's ticket was resolved.
+// Implements exporter.IssueResolver.
+func (s *Service) ResolveIssue(ctx context.Context, issueID string, resolvedAt time.Time) error {
+	return s.Resolve(ctx, issueID, resolvedAt)
+}
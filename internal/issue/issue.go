@@ -0,0 +1,434 @@
+// Package issue clusters per-session incidents into cross-session issues.
+//
+// Each incident belongs to exactly one session, so a broken checkout button
+// produces one incident per affected session. The issue layer groups those
+// incidents by a fingerprint — normalized route, failure point, signal types
+// and error fingerprint — and tracks how many sessions and users are
+// affected, when the issue was first and last seen, and whether it is
+// trending up or down across rolling windows. Issues, not incidents, are
+// the unit the exporter turns into tickets.
+//
+// When an issue's ticket is resolved the issue keeps its fingerprint and
+// resolution time. If the fingerprint recurs after the regression quiet
+// period, the issue is marked regressed so the exporter can reopen the
+// original ticket instead of filing a duplicate.
+//
+// The service caches the current issue of each active fingerprint with its
+// distinct session and user sets. The store is the source of truth: a
+// fingerprint missing from the cache (after a restart, or once evicted) is
+// looked up in the store, so recurrences still join their issue. Entries are
+// evicted when their issue is resolved or goes idle; distinct counts then
+// continue from the stored totals.
+package issue
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/your-org/frustration-engine/internal/metrics"
+	"github.com/your-org/frustration-engine/internal/route"
+	"github.com/your-org/frustration-engine/internal/storage"
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+// maxExampleSessions caps the example session references kept per issue.
+const maxExampleSessions = 5
+
+// Config controls issue clustering.
+type Config struct {
+	// Window is the rolling window used for trend detection. The current
+	// window is compared against the one before it.
+	Window time.Duration
+	// IdleExpiry starts a fresh issue when a fingerprint has been quiet
+	// for longer than this. Resolved issues never expire.
+	IdleExpiry time.Duration
+	// RegressionQuietPeriod is how long after resolution a recurrence is
+	// still attributed to the fix rolling out. Incidents after it mark the
+	// issue regressed.
+	RegressionQuietPeriod time.Duration
+}
+
+// DefaultConfig returns the default clustering configuration.
+func DefaultConfig() Config {
+	return Config{
+		Window:                time.Hour,
+		IdleExpiry:            7 * 24 * time.Hour,
+		RegressionQuietPeriod: 24 * time.Hour,
+	}
+}
+
+// state holds the distinct-count sets behind an issue's counters. For an
+// issue restored from the store, the sets only hold sessions and users seen
+// since, on top of the stored counts.
+type state struct {
+	sessions     map[string]struct{}
+	users        map[string]struct{}
+	baseSessions int
+	baseUsers    int
+	recent       []time.Time // incident times within the last two windows
+	lastSeen     time.Time
+}
+
+func newState(issue types.Issue) *state {
+	st := &state{
+		sessions:     make(map[string]struct{}),
+		users:        make(map[string]struct{}),
+		baseSessions: issue.AffectedSessions,
+		baseUsers:    issue.AffectedUsers,
+		lastSeen:     issue.LastSeen,
+	}
+	// Example sessions are known to be counted already
+	for _, sessionID := range issue.ExampleSessionIDs {
+		st.sessions[sessionID] = struct{}{}
+		st.baseSessions--
+	}
+	if st.baseSessions < 0 {
+		st.baseSessions = 0
+	}
+	return st
+}
+
+// Service groups incidents into issues.
+type Service struct {
+	store storage.IssueStore
+	cfg   Config
+	now   func() time.Time
+
+	mu            sync.Mutex
+	byFingerprint map[string]string // fingerprint → current issue ID (cache)
+	states        map[string]*state // issue ID → counters
+	lastSweep     time.Time
+}
+
+// NewService creates a new issue service.
+func NewService(store storage.IssueStore, cfg Config) *Service {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultConfig().Window
+	}
+	if cfg.IdleExpiry <= 0 {
+		cfg.IdleExpiry = DefaultConfig().IdleExpiry
+	}
+	if cfg.RegressionQuietPeriod < 0 {
+		cfg.RegressionQuietPeriod = 0
+	}
+	return &Service{
+		store:         store,
+		cfg:           cfg,
+		now:           time.Now,
+		byFingerprint: make(map[string]string),
+		states:        make(map[string]*state),
+	}
+}
+
+// Fingerprint returns the clustering key for an incident. Incidents from
+// different sessions with the same fingerprint describe the same problem.
+func Fingerprint(incident types.Incident) string {
+	signalTypes := uniqueSorted(incident.TriggeringSignals)
+	parts := []string{
+		incident.ProjectID,
+		route.SplitFailurePoint(incident.PrimaryFailurePoint)[0],
+		incident.PrimaryFailurePoint,
+		strings.Join(signalTypes, ","),
+		incident.ErrorFingerprint,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Record adds an incident to its issue, creating the issue if needed, and
+// returns the updated issue.
+func (s *Service) Record(ctx context.Context, incident types.Incident) (types.Issue, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fingerprint := Fingerprint(incident)
+	seenAt := incident.Timestamp
+	if seenAt.IsZero() {
+		seenAt = s.now()
+	}
+
+	s.sweep(s.now())
+	issue, st, err := s.current(ctx, fingerprint, seenAt)
+	if err != nil {
+		return types.Issue{}, err
+	}
+	if st == nil {
+		issue = newIssue(fingerprint, incident, seenAt)
+		st = newState(issue)
+		s.byFingerprint[fingerprint] = issue.IssueID
+		s.states[issue.IssueID] = st
+		log.Printf("[issue] new issue %s for %s", issue.IssueID, issue.PrimaryFailurePoint)
+	}
+	if issue.Status == types.IssueStatusResolved && issue.ResolvedAt != nil &&
+		seenAt.Sub(*issue.ResolvedAt) >= s.cfg.RegressionQuietPeriod {
+		issue.Status = types.IssueStatusRegressed
+		issue.RegressedAt = &seenAt
+		issue.RegressionCount++
+		metrics.IssueRegressions.Inc()
+		log.Printf("[issue] issue %s regressed (resolved %s, ticket %s)",
+			issue.IssueID, issue.ResolvedAt.Format(time.RFC3339), issue.ExternalTicketID)
+	}
+
+	issue.IncidentCount++
+	if _, seen := st.sessions[incident.SessionID]; !seen {
+		st.sessions[incident.SessionID] = struct{}{}
+		if len(issue.ExampleSessionIDs) < maxExampleSessions {
+			issue.ExampleSessionIDs = append(issue.ExampleSessionIDs, incident.SessionID)
+		}
+	}
+	if incident.UserID != "" {
+		st.users[incident.UserID] = struct{}{}
+	}
+	issue.AffectedSessions = st.baseSessions + len(st.sessions)
+	issue.AffectedUsers = st.baseUsers + len(st.users)
+
+	if seenAt.Before(issue.FirstSeen) {
+		issue.FirstSeen = seenAt
+	}
+	if seenAt.After(issue.LastSeen) {
+		issue.LastSeen = seenAt
+	}
+	st.lastSeen = issue.LastSeen
+	if incident.FrustrationScore > issue.MaxFrustrationScore {
+		issue.MaxFrustrationScore = incident.FrustrationScore
+	}
+	if incident.ConfidenceScore > issue.MaxConfidenceScore {
+		issue.MaxConfidenceScore = incident.ConfidenceScore
+	}
+
+	st.recent = append(st.recent, seenAt)
+	s.refreshTrend(&issue, st, s.now())
+
+	if err := s.store.Save(ctx, issue); err != nil {
+		log.Printf("[issue] failed to store issue %s: %v", issue.IssueID, err)
+		return types.Issue{}, err
+	}
+	return issue, nil
+}
+
+// Get returns an issue by ID with its trend refreshed.
+func (s *Service) Get(ctx context.Context, issueID string) (types.Issue, bool, error) {
+	issue, ok, err := s.store.Get(ctx, issueID)
+	if err != nil || !ok {
+		return issue, ok, err
+	}
+	s.mu.Lock()
+	if st := s.states[issueID]; st != nil {
+		s.refreshTrend(&issue, st, s.now())
+	}
+	s.mu.Unlock()
+	return issue, true, nil
+}
+
+// Query returns issues matching the query with trends refreshed, along with
+// the total number of matches before pagination.
+func (s *Service) Query(ctx context.Context, query types.IssueQuery) ([]types.Issue, int, error) {
+	unpaged := query
+	unpaged.Trend, unpaged.Limit, unpaged.Offset = "", 0, 0
+	issues, err := s.store.Query(ctx, unpaged)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := s.now()
+	matched := make([]types.Issue, 0, len(issues))
+	s.mu.Lock()
+	for _, issue := range issues {
+		if st := s.states[issue.IssueID]; st != nil {
+			s.refreshTrend(&issue, st, now)
+		}
+		if query.Trend != "" && issue.Trend != query.Trend {
+			continue
+		}
+		matched = append(matched, issue)
+	}
+	s.mu.Unlock()
+
+	total := len(matched)
+	if query.Offset >= len(matched) {
+		return nil, total, nil
+	}
+	matched = matched[query.Offset:]
+	if query.Limit > 0 && query.Limit < len(matched) {
+		matched = matched[:query.Limit]
+	}
+	return matched, total, nil
+}
+
+// MarkExported records the external ticket filed for an issue.
+func (s *Service) MarkExported(ctx context.Context, issueID, externalTicketID, externalSystem string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issue, ok, err := s.store.Get(ctx, issueID)
+	if err != nil || !ok {
+		return err
+	}
+	now := s.now()
+	issue.ExternalTicketID = externalTicketID
+	issue.ExternalSystem = externalSystem
+	issue.ExportedAt = &now
+	return s.store.Save(ctx, issue)
+}
+
+// Resolve marks an issue resolved at the given time, e.g. when its ticket
+// is closed as fixed. Unknown issues are ignored.
+func (s *Service) Resolve(ctx context.Context, issueID string, resolvedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issue, ok, err := s.store.Get(ctx, issueID)
+	if err != nil || !ok {
+		return err
+	}
+	if issue.Status == types.IssueStatusResolved {
+		return nil
+	}
+	issue.Status = types.IssueStatusResolved
+	issue.ResolvedAt = &resolvedAt
+	if err := s.store.Save(ctx, issue); err != nil {
+		return err
+	}
+	// A recurrence finds the resolved issue in the store
+	s.evict(issue.Fingerprint, issueID)
+	return nil
+}
+
+// current returns the issue for a fingerprint, or a nil state when a new
+// issue must be started (unknown fingerprint or idle for too long).
+// Fingerprints missing from the cache are looked up in the store.
+func (s *Service) current(ctx context.Context, fingerprint string, seenAt time.Time) (types.Issue, *state, error) {
+	var issue types.Issue
+	issueID, cached := s.byFingerprint[fingerprint]
+	if cached {
+		found, ok, err := s.store.Get(ctx, issueID)
+		if err != nil {
+			return types.Issue{}, nil, err
+		}
+		if !ok {
+			s.evict(fingerprint, issueID)
+			return types.Issue{}, nil, nil
+		}
+		issue = found
+	} else {
+		found, err := s.store.Query(ctx, types.IssueQuery{Fingerprint: fingerprint, Limit: 1})
+		if err != nil {
+			return types.Issue{}, nil, err
+		}
+		if len(found) == 0 {
+			return types.Issue{}, nil, nil
+		}
+		issue = found[0]
+	}
+
+	if issue.ResolvedAt == nil && seenAt.Sub(issue.LastSeen) > s.cfg.IdleExpiry {
+		s.evict(fingerprint, issue.IssueID)
+		return types.Issue{}, nil, nil
+	}
+	st := s.states[issue.IssueID]
+	if st == nil {
+		st = newState(issue)
+		s.byFingerprint[fingerprint] = issue.IssueID
+		s.states[issue.IssueID] = st
+	}
+	return issue, st, nil
+}
+
+// evict drops a fingerprint's cache entry.
+func (s *Service) evict(fingerprint, issueID string) {
+	if s.byFingerprint[fingerprint] == issueID {
+		delete(s.byFingerprint, fingerprint)
+	}
+	delete(s.states, issueID)
+}
+
+// sweep evicts cache entries idle for longer than IdleExpiry, at most once
+// per window.
+func (s *Service) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.cfg.Window {
+		return
+	}
+	s.lastSweep = now
+	for fingerprint, issueID := range s.byFingerprint {
+		if st := s.states[issueID]; st == nil || now.Sub(st.lastSeen) > s.cfg.IdleExpiry {
+			s.evict(fingerprint, issueID)
+		}
+	}
+}
+
+// refreshTrend recomputes the rolling-window counts and trend for an issue
+// and drops incident times older than two windows.
+func (s *Service) refreshTrend(issue *types.Issue, st *state, now time.Time) {
+	currentStart := now.Add(-s.cfg.Window)
+	previousStart := currentStart.Add(-s.cfg.Window)
+
+	kept := st.recent[:0]
+	current, previous := 0, 0
+	for _, t := range st.recent {
+		switch {
+		case t.After(currentStart):
+			current++
+		case t.After(previousStart):
+			previous++
+		default:
+			continue
+		}
+		kept = append(kept, t)
+	}
+	st.recent = kept
+
+	issue.WindowCount = current
+	issue.PreviousWindowCount = previous
+	issue.Trend = trend(issue.FirstSeen, currentStart, current, previous)
+}
+
+// trend classifies the change between the previous and current windows.
+func trend(firstSeen, currentStart time.Time, current, previous int) string {
+	switch {
+	case firstSeen.After(currentStart):
+		return types.IssueTrendNew
+	case float64(current) > float64(previous)*1.5:
+		return types.IssueTrendRising
+	case float64(current) < float64(previous)*0.5:
+		return types.IssueTrendFalling
+	default:
+		return types.IssueTrendStable
+	}
+}
+
+func newIssue(fingerprint string, incident types.Incident, seenAt time.Time) types.Issue {
+	return types.Issue{
+		IssueID:             uuid.New().String(),
+		ProjectID:           incident.ProjectID,
+		Fingerprint:         fingerprint,
+		Route:               route.SplitFailurePoint(incident.PrimaryFailurePoint)[0],
+		PrimaryFailurePoint: incident.PrimaryFailurePoint,
+		SignalTypes:         uniqueSorted(incident.TriggeringSignals),
+		ErrorFingerprint:    incident.ErrorFingerprint,
+		SeverityType:        incident.SeverityType,
+		FirstSeen:           seenAt,
+		LastSeen:            seenAt,
+		Status:              types.IssueStatusOpen,
+	}
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,225 @@
+package issue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	memstorage "github.com/your-org/frustration-engine/internal/storage/memory"
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+func checkoutIncident(id, sessionID, userID string, ts time.Time) types.Incident {
+	return types.Incident{
+		IncidentID:          id,
+		SessionID:           sessionID,
+		ProjectID:           "proj-1",
+		UserID:              userID,
+		FrustrationScore:    70,
+		ConfidenceScore:     80,
+		TriggeringSignals:   []string{"rage", "blocked"},
+		PrimaryFailurePoint: "/checkout:pay-btn:click",
+		SeverityType:        "Bug",
+		ErrorFingerprint:    "abc123",
+		Timestamp:           ts,
+	}
+}
+
+func newTestService(now time.Time) *Service {
+	svc := NewService(memstorage.NewIssueStore(), DefaultConfig())
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestFingerprint_IgnoresSessionAndSignalOrder(t *testing.T) {
+	now := time.Now()
+	a := checkoutIncident("i1", "s1", "", now)
+	b := checkoutIncident("i2", "s2", "", now)
+	b.TriggeringSignals = []string{"blocked", "rage", "rage"}
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Errorf("expected same fingerprint for same failure across sessions")
+	}
+
+	c := checkoutIncident("i3", "s3", "", now)
+	c.ErrorFingerprint = "other"
+	if Fingerprint(a) == Fingerprint(c) {
+		t.Errorf("expected different fingerprint for different error")
+	}
+}
+
+func TestRecord_ClustersAcrossSessions(t *testing.T) {
+	now := time.Now()
+	svc := newTestService(now)
+	ctx := context.Background()
+
+	var last types.Issue
+	for i, sess := range []string{"s1", "s2", "s2", "s3"} {
+		user := "u1"
+		if sess == "s3" {
+			user = "u2"
+		}
+		iss, err := svc.Record(ctx, checkoutIncident("inc-"+string(rune('a'+i)), sess, user, now.Add(-time.Duration(i)*time.Minute)))
+		if err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+		if last.IssueID != "" && iss.IssueID != last.IssueID {
+			t.Fatalf("expected all incidents in one issue, got %s and %s", last.IssueID, iss.IssueID)
+		}
+		last = iss
+	}
+
+	if last.IncidentCount != 4 {
+		t.Errorf("IncidentCount = %d, want 4", last.IncidentCount)
+	}
+	if last.AffectedSessions != 3 {
+		t.Errorf("AffectedSessions = %d, want 3", last.AffectedSessions)
+	}
+	if last.AffectedUsers != 2 {
+		t.Errorf("AffectedUsers = %d, want 2", last.AffectedUsers)
+	}
+	if last.Route != "/checkout" {
+		t.Errorf("Route = %q, want /checkout", last.Route)
+	}
+	if last.Trend != types.IssueTrendNew {
+		t.Errorf("Trend = %q, want %q", last.Trend, types.IssueTrendNew)
+	}
+
+	issues, total, err := svc.Query(ctx, types.IssueQuery{ProjectID: "proj-1"})
+	if err != nil || total != 1 || len(issues) != 1 {
+		t.Fatalf("Query = %d issues (total %d, err %v), want 1", len(issues), total, err)
+	}
+}
+
+func TestRecord_TrendRising(t *testing.T) {
+	now := time.Now()
+	svc := newTestService(now)
+	ctx := context.Background()
+
+	// One incident in the previous window, four in the current one.
+	times := []time.Duration{-90 * time.Minute, -20 * time.Minute, -15 * time.Minute, -10 * time.Minute, -5 * time.Minute}
+	var iss types.Issue
+	for i, d := range times {
+		var err error
+		iss, err = svc.Record(ctx, checkoutIncident("inc-"+string(rune('a'+i)), "s"+string(rune('a'+i)), "", now.Add(d)))
+		if err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	if iss.WindowCount != 4 || iss.PreviousWindowCount != 1 {
+		t.Errorf("window counts = %d/%d, want 4/1", iss.WindowCount, iss.PreviousWindowCount)
+	}
+	if iss.Trend != types.IssueTrendRising {
+		t.Errorf("Trend = %q, want %q", iss.Trend, types.IssueTrendRising)
+	}
+}
+
+func TestRecord_IdleExpiryStartsNewIssue(t *testing.T) {
+	now := time.Now()
+	svc := newTestService(now)
+	ctx := context.Background()
+
+	first, _ := svc.Record(ctx, checkoutIncident("inc-1", "s1", "", now.Add(-30*24*time.Hour)))
+	second, _ := svc.Record(ctx, checkoutIncident("inc-2", "s2", "", now))
+
+	if first.IssueID == second.IssueID {
+		t.Errorf("expected a new issue after the idle expiry")
+	}
+}
+
+func TestGetIssueRef_ProvidesEvidence(t *testing.T) {
+	now := time.Now()
+	svc := newTestService(now)
+	ctx := context.Background()
+
+	iss, _ := svc.Record(ctx, checkoutIncident("inc-1", "s1", "", now))
+	svc.Record(ctx, checkoutIncident("inc-2", "s2", "", now))
+
+	ref, ok, err := svc.GetIssueRef(ctx, iss.IssueID)
+	if err != nil || !ok {
+		t.Fatalf("GetIssueRef: ok=%v err=%v", ok, err)
+	}
+	if ref.Evidence.AffectedSessions != 2 {
+		t.Errorf("AffectedSessions = %d, want 2", ref.Evidence.AffectedSessions)
+	}
+
+	if err := svc.MarkIssueExported(ctx, iss.IssueID, "PROJ-1", "jira"); err != nil {
+		t.Fatalf("MarkIssueExported: %v", err)
+	}
+	ref, _, _ = svc.GetIssueRef(ctx, iss.IssueID)
+	if ref.ExternalTicketID != "PROJ-1" {
+		t.Errorf("ExternalTicketID = %q, want PROJ-1", ref.ExternalTicketID)
+	}
+}
+
+func TestRecord_RegressesAfterQuietPeriod(t *testing.T) {
+	now := time.Now()
+	svc := newTestService(now)
+	ctx := context.Background()
+
+	iss, _ := svc.Record(ctx, checkoutIncident("i1", "s1", "", now))
+	resolvedAt := now.Add(time.Hour)
+	if err := svc.Resolve(ctx, iss.IssueID, resolvedAt); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	// Within the quiet period: the fix may still be rolling out
+	iss, _ = svc.Record(ctx, checkoutIncident("i2", "s2", "", resolvedAt.Add(time.Hour)))
+	if iss.Status != types.IssueStatusResolved {
+		t.Fatalf("status = %s inside the quiet period, want resolved", iss.Status)
+	}
+
+	// Long after the fix, and past the idle expiry: same issue, regressed
+	recurredAt := resolvedAt.Add(30 * 24 * time.Hour)
+	regressed, _ := svc.Record(ctx, checkoutIncident("i3", "s3", "", recurredAt))
+	if regressed.IssueID != iss.IssueID {
+		t.Fatalf("recurrence started a new issue %s, want %s", regressed.IssueID, iss.IssueID)
+	}
+	if regressed.Status != types.IssueStatusRegressed || regressed.RegressionCount != 1 || !regressed.RegressedAt.Equal(recurredAt) {
+		t.Errorf("issue = %+v, want regressed once at %s", regressed, recurredAt)
+	}
+}
+
+func TestRecord_RestoresIssuesFromStore(t *testing.T) {
+	now := time.Now()
+	store := memstorage.NewIssueStore()
+	ctx := context.Background()
+
+	first := NewService(store, DefaultConfig())
+	first.now = func() time.Time { return now }
+	iss, _ := first.Record(ctx, checkoutIncident("i1", "s1", "u1", now))
+	first.Record(ctx, checkoutIncident("i2", "s2", "u2", now))
+
+	// A restarted service joins recurrences to the stored issue
+	restarted := NewService(store, DefaultConfig())
+	restarted.now = func() time.Time { return now }
+	got, err := restarted.Record(ctx, checkoutIncident("i3", "s3", "u1", now.Add(time.Minute)))
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if got.IssueID != iss.IssueID {
+		t.Fatalf("restart opened a duplicate issue %s, want %s", got.IssueID, iss.IssueID)
+	}
+	if got.IncidentCount != 3 || got.AffectedSessions != 3 {
+		t.Errorf("issue = %d incidents, %d sessions, want 3 and 3", got.IncidentCount, got.AffectedSessions)
+	}
+	// Example sessions are not counted twice
+	if got, _ := restarted.Record(ctx, checkoutIncident("i4", "s1", "", now.Add(time.Minute))); got.AffectedSessions != 3 {
+		t.Errorf("AffectedSessions = %d after a known session, want 3", got.AffectedSessions)
+	}
+}
+
+func TestResolve_EvictsCache(t *testing.T) {
+	now := time.Now()
+	svc := newTestService(now)
+	ctx := context.Background()
+
+	iss, _ := svc.Record(ctx, checkoutIncident("i1", "s1", "", now))
+	if err := svc.Resolve(ctx, iss.IssueID, now); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if len(svc.byFingerprint) != 0 || len(svc.states) != 0 {
+		t.Errorf("cache holds %d fingerprints and %d states after resolve, want none", len(svc.byFingerprint), len(svc.states))
+	}
+}
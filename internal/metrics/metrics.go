@@ -16,6 +16,55 @@ var (
 		Help: "Total number of events ingested from SDK",
 	})
 
+	// EventsRejected counts events dropped by ingest validation, by reason
+	// code (see internal/validation).
+	EventsRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "hawkeye_events_rejected_total",
+		Help: "Total events rejected by ingest validation by reason",
+	}, []string{"reason"})
+
+	// EventsDeadLettered counts events put in the dead letter queue, by
+	// reason (a validation reason code, storage_error or forward_error).
+	EventsDeadLettered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "hawkeye_events_dead_lettered_total",
+		Help: "Total events put in the dead letter queue by reason",
+	}, []string{"reason"})
+
+	// RateLimited counts ingestion requests rejected with 429, by the limit
+	// they hit (requests, events, daily_quota or monthly_quota).
+	RateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "hawkeye_rate_limited_total",
+		Help: "Total ingestion requests rejected by rate limits and quotas by limit",
+	}, []string{"limit"})
+
+	// PIIRedactions counts values redacted from event metadata and
+	// selectors, by redaction rule.
+	PIIRedactions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "hawkeye_pii_redactions_total",
+		Help: "Total PII values redacted from ingested events by rule",
+	}, []string{"rule"})
+
+	// OTLPRecords counts spans and log records received over OTLP, by
+	// signal (traces or logs) and outcome (mapped, ignored or rejected).
+	OTLPRecords = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "hawkeye_otlp_records_total",
+		Help: "Total OTLP spans and log records received by signal and outcome",
+	}, []string{"signal", "outcome"})
+
+	// EventsUpcast counts events converted from an older schema version at
+	// ingest, by the version they arrived in.
+	EventsUpcast = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "hawkeye_events_upcast_total",
+		Help: "Total events upcast to the current schema version by original version",
+	}, []string{"from"})
+
+	// EventsByRoute counts ingested events by normalized route template
+	// (label values capped by RouteLabel).
+	EventsByRoute = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "hawkeye_events_by_route_total",
+		Help: "Total events ingested by normalized route template",
+	}, []string{"route"})
+
 	// SessionsCreated counts sessions created by the session manager.
 	SessionsCreated = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "hawkeye_sessions_created_total",
@@ -59,6 +108,26 @@ var (
 		Help: "Total signals discarded by reason",
 	}, []string{"reason"})
 
+	// NotificationsTotal counts chat notifications by channel and outcome
+	// (sent, digested, throttled, failed).
+	NotificationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "hawkeye_notifications_total",
+		Help: "Total chat notifications by channel and outcome",
+	}, []string{"channel", "outcome"})
+
+	// NotificationsDropped counts incidents not notified because the
+	// notification queue was full.
+	NotificationsDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "hawkeye_notifications_dropped_total",
+		Help: "Total incidents dropped because the notification queue was full",
+	})
+
+	// IssueRegressions counts resolved issues that recurred.
+	IssueRegressions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "hawkeye_issue_regressions_total",
+		Help: "Total resolved issues that recurred after the regression quiet period",
+	})
+
 	// HTTPRequestsTotal counts HTTP requests by method and status.
 	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "hawkeye_http_requests_total",
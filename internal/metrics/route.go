@@ -0,0 +1,31 @@
+package metrics
+
+import "sync"
+
+// MaxRouteLabels caps the distinct route label values of EventsByRoute.
+// Route templates are bounded for well-behaved apps, but heuristics miss
+// some IDs, and every distinct label value is a new time series.
+const MaxRouteLabels = 500
+
+// OtherRoute is the EventsByRoute label of routes past MaxRouteLabels.
+const OtherRoute = "other"
+
+var routeLabels = struct {
+	sync.Mutex
+	seen map[string]bool
+}{seen: make(map[string]bool)}
+
+// RouteLabel returns the EventsByRoute label for a route: the route itself
+// for the first MaxRouteLabels distinct routes, OtherRoute afterwards.
+func RouteLabel(route string) string {
+	routeLabels.Lock()
+	defer routeLabels.Unlock()
+	if routeLabels.seen[route] {
+		return route
+	}
+	if len(routeLabels.seen) >= MaxRouteLabels {
+		return OtherRoute
+	}
+	routeLabels.seen[route] = true
+	return route
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestRouteLabel(t *testing.T) {
+	if got := RouteLabel("/checkout"); got != "/checkout" {
+		t.Fatalf("RouteLabel(/checkout) = %q", got)
+	}
+	for i := 0; i < MaxRouteLabels; i++ {
+		RouteLabel("/items/" + strconv.Itoa(i))
+	}
+	if got := RouteLabel("/one-too-many"); got != OtherRoute {
+		t.Errorf("RouteLabel past the cap = %q, want %q", got, OtherRoute)
+	}
+	// Routes seen before the cap keep their label
+	if got := RouteLabel("/checkout"); got != "/checkout" {
+		t.Errorf("RouteLabel(/checkout) after the cap = %q", got)
+	}
+}
@@ -0,0 +1,191 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxDigestItems caps the incidents listed in one digest message; the rest
+// are summarized as a count.
+const maxDigestItems = 20
+
+// title is the one-line summary shared by both formats.
+func title(item Item) string {
+	inc := item.Incident
+	where := inc.PrimaryFailurePoint
+	if where == "" {
+		where = "unknown location"
+	}
+	return truncate(fmt.Sprintf("[%s] %s frustration at %s", item.Priority, inc.SeverityType, where), 150)
+}
+
+// SlackMessage formats an incident as a Slack Block Kit message.
+func SlackMessage(item Item, link string) map[string]interface{} {
+	inc := item.Incident
+	blocks := []interface{}{
+		map[string]interface{}{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": title(item)},
+		},
+		map[string]interface{}{
+			"type": "section",
+			"fields": []interface{}{
+				slackField("Priority", string(item.Priority)),
+				slackField("Severity", inc.SeverityType),
+				slackField("Project", inc.ProjectID),
+				slackField("Confidence", fmt.Sprintf("%.1f (%s)", inc.ConfidenceScore, inc.ConfidenceLevel)),
+				slackField("Frustration score", fmt.Sprintf("%d", inc.FrustrationScore)),
+				slackField("Signals", strings.Join(inc.TriggeringSignals, ", ")),
+			},
+		},
+	}
+	if inc.Explanation != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": truncate(inc.Explanation, 3000)},
+		})
+	}
+	blocks = append(blocks, map[string]interface{}{
+		"type": "context",
+		"elements": []interface{}{
+			map[string]interface{}{"type": "mrkdwn", "text": fmt.Sprintf("Incident `%s` · session `%s` · %s",
+				inc.IncidentID, inc.SessionID, inc.Timestamp.UTC().Format(time.RFC3339))},
+		},
+	})
+	if link != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "actions",
+			"elements": []interface{}{
+				map[string]interface{}{
+					"type": "button",
+					"text": map[string]interface{}{"type": "plain_text", "text": "View incident"},
+					"url":  link,
+				},
+			},
+		})
+	}
+	return map[string]interface{}{"text": title(item), "blocks": blocks}
+}
+
+// SlackDigest formats batched incidents as one Slack Block Kit message.
+func SlackDigest(items []Item, interval time.Duration) map[string]interface{} {
+	heading := fmt.Sprintf("HawkEye digest: %d incident(s) in the last %s", len(items), interval)
+	lines := make([]string, 0, len(items))
+	for i, item := range items {
+		if i == maxDigestItems {
+			lines = append(lines, fmt.Sprintf("…and %d more", len(items)-maxDigestItems))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("• *%s* %s — %s (confidence %.1f)",
+			item.Priority, item.Incident.SeverityType, item.Incident.PrimaryFailurePoint, item.Incident.ConfidenceScore))
+	}
+	return map[string]interface{}{
+		"text": heading,
+		"blocks": []interface{}{
+			map[string]interface{}{
+				"type": "header",
+				"text": map[string]interface{}{"type": "plain_text", "text": truncate(heading, 150)},
+			},
+			map[string]interface{}{
+				"type": "section",
+				"text": map[string]interface{}{"type": "mrkdwn", "text": truncate(strings.Join(lines, "\n"), 3000)},
+			},
+		},
+	}
+}
+
+// TeamsMessage formats an incident as a Teams Adaptive Card message.
+func TeamsMessage(item Item, link string) map[string]interface{} {
+	inc := item.Incident
+	body := []interface{}{
+		map[string]interface{}{
+			"type": "TextBlock", "text": title(item), "size": "Large", "weight": "Bolder", "wrap": true,
+		},
+		map[string]interface{}{
+			"type": "FactSet",
+			"facts": []interface{}{
+				teamsFact("Priority", string(item.Priority)),
+				teamsFact("Severity", inc.SeverityType),
+				teamsFact("Project", inc.ProjectID),
+				teamsFact("Confidence", fmt.Sprintf("%.1f (%s)", inc.ConfidenceScore, inc.ConfidenceLevel)),
+				teamsFact("Frustration score", fmt.Sprintf("%d", inc.FrustrationScore)),
+				teamsFact("Signals", strings.Join(inc.TriggeringSignals, ", ")),
+				teamsFact("Incident", inc.IncidentID),
+			},
+		},
+	}
+	if inc.Explanation != "" {
+		body = append(body, map[string]interface{}{"type": "TextBlock", "text": inc.Explanation, "wrap": true})
+	}
+	var actions []interface{}
+	if link != "" {
+		actions = append(actions, map[string]interface{}{"type": "Action.OpenUrl", "title": "View incident", "url": link})
+	}
+	return adaptiveCard(body, actions)
+}
+
+// TeamsDigest formats batched incidents as one Teams Adaptive Card message.
+func TeamsDigest(items []Item, interval time.Duration) map[string]interface{} {
+	body := []interface{}{
+		map[string]interface{}{
+			"type": "TextBlock", "size": "Large", "weight": "Bolder", "wrap": true,
+			"text": fmt.Sprintf("HawkEye digest: %d incident(s) in the last %s", len(items), interval),
+		},
+	}
+	facts := make([]interface{}, 0, len(items))
+	for i, item := range items {
+		if i == maxDigestItems {
+			facts = append(facts, teamsFact("…", fmt.Sprintf("and %d more", len(items)-maxDigestItems)))
+			break
+		}
+		facts = append(facts, teamsFact(string(item.Priority), fmt.Sprintf("%s — %s (confidence %.1f)",
+			item.Incident.SeverityType, item.Incident.PrimaryFailurePoint, item.Incident.ConfidenceScore)))
+	}
+	body = append(body, map[string]interface{}{"type": "FactSet", "facts": facts})
+	return adaptiveCard(body, nil)
+}
+
+// adaptiveCard wraps a card body in the message envelope Teams incoming
+// webhooks expect.
+func adaptiveCard(body, actions []interface{}) map[string]interface{} {
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if len(actions) > 0 {
+		card["actions"] = actions
+	}
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"contentUrl":  nil,
+				"content":     card,
+			},
+		},
+	}
+}
+
+func slackField(label, value string) map[string]interface{} {
+	if value == "" {
+		value = "—"
+	}
+	return map[string]interface{}{"type": "mrkdwn", "text": fmt.Sprintf("*%s*\n%s", label, value)}
+}
+
+func teamsFact(label, value string) map[string]interface{} {
+	return map[string]interface{}{"title": label, "value": value}
+}
+
+// truncate shortens s to at most max runes.
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-1]) + "…"
+}
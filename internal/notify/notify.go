@@ -0,0 +1,381 @@
+// Package notify alerts chat channels about incidents in real time.
+//
+// Incidents are posted to Slack (Block Kit) and Microsoft Teams (Adaptive
+// Card) incoming-webhook URLs. Routing rules pick the channels for an
+// incident by project, severity type and priority (as computed by
+// exporter.PriorityMapper). Each rule has two thresholds: incidents at or
+// above MinPriority are posted immediately; incidents down to
+// DigestPriority are batched into a periodic digest (hourly by default).
+// Each channel is throttled to MaxPerHour immediate messages; incidents
+// over the limit are demoted to the digest rather than dropped.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/exporter"
+	"github.com/your-org/frustration-engine/internal/metrics"
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+// Channel kinds.
+const (
+	KindSlack = "slack"
+	KindTeams = "teams"
+)
+
+// DefaultDigestInterval is how often batched incidents are flushed.
+const DefaultDigestInterval = time.Hour
+
+// Channel is a chat destination reached through an incoming webhook.
+type Channel struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"` // "slack" or "teams"
+	WebhookURL string `json:"webhookUrl"`
+	// MaxPerHour caps immediate messages per rolling hour. 0 = unlimited.
+	MaxPerHour int `json:"maxPerHour,omitempty"`
+}
+
+// Rule routes matching incidents to a channel.
+type Rule struct {
+	Channel string `json:"channel"`
+	// ProjectID restricts the rule to one project. Empty or "*" matches all.
+	ProjectID string `json:"projectId,omitempty"`
+	// SeverityTypes restricts the rule to these severity types
+	// (e.g. "Bug", "UX", "Performance"). Empty matches all.
+	SeverityTypes []string `json:"severityTypes,omitempty"`
+	// MinPriority is the lowest priority posted immediately (default "P1").
+	MinPriority string `json:"minPriority,omitempty"`
+	// DigestPriority is the lowest priority included in the digest. Empty
+	// disables the digest for this rule.
+	DigestPriority string `json:"digestPriority,omitempty"`
+}
+
+// Config is the notification configuration, usually loaded from JSON.
+type Config struct {
+	Channels []Channel `json:"channels"`
+	Rules    []Rule    `json:"rules"`
+	// DigestInterval is a Go duration string (default "1h").
+	DigestInterval string `json:"digestInterval,omitempty"`
+	// DashboardURL, when set, adds a "View incident" link to messages.
+	DashboardURL string `json:"dashboardUrl,omitempty"`
+}
+
+// LoadConfigFile reads a JSON notification config.
+func LoadConfigFile(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read notify config: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse notify config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Item is an incident with the priority it was routed at.
+type Item struct {
+	Incident types.Incident
+	Priority exporter.PriorityLevel
+}
+
+// channelState is a channel's throttle window and pending digest.
+type channelState struct {
+	Channel
+	sent   []time.Time
+	digest []Item
+}
+
+// Notifier routes incidents to chat channels.
+type Notifier struct {
+	mu             sync.Mutex
+	channels       map[string]*channelState
+	rules          []Rule
+	digestInterval time.Duration
+	dashboardURL   string
+	priority       *exporter.PriorityMapper
+	client         *http.Client
+	now            func() time.Time
+}
+
+// New validates cfg and creates a notifier.
+func New(cfg Config) (*Notifier, error) {
+	n := &Notifier{
+		channels:       make(map[string]*channelState),
+		digestInterval: DefaultDigestInterval,
+		dashboardURL:   strings.TrimRight(cfg.DashboardURL, "/"),
+		priority:       exporter.NewPriorityMapper(),
+		client:         &http.Client{Timeout: 10 * time.Second},
+		now:            time.Now,
+	}
+	if cfg.DigestInterval != "" {
+		d, err := time.ParseDuration(cfg.DigestInterval)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid digestInterval %q", cfg.DigestInterval)
+		}
+		n.digestInterval = d
+	}
+
+	for _, ch := range cfg.Channels {
+		if ch.Name == "" || ch.WebhookURL == "" {
+			return nil, fmt.Errorf("channel %q: name and webhookUrl are required", ch.Name)
+		}
+		if ch.Kind != KindSlack && ch.Kind != KindTeams {
+			return nil, fmt.Errorf("channel %q: unknown kind %q", ch.Name, ch.Kind)
+		}
+		if _, dup := n.channels[ch.Name]; dup {
+			return nil, fmt.Errorf("channel %q defined twice", ch.Name)
+		}
+		n.channels[ch.Name] = &channelState{Channel: ch}
+	}
+
+	for i, rule := range cfg.Rules {
+		if _, ok := n.channels[rule.Channel]; !ok {
+			return nil, fmt.Errorf("rule %d: unknown channel %q", i, rule.Channel)
+		}
+		if rule.MinPriority == "" {
+			rule.MinPriority = string(exporter.PriorityP1)
+		}
+		if priorityRank(exporter.PriorityLevel(rule.MinPriority)) < 0 {
+			return nil, fmt.Errorf("rule %d: invalid minPriority %q", i, rule.MinPriority)
+		}
+		if rule.DigestPriority != "" && priorityRank(exporter.PriorityLevel(rule.DigestPriority)) < 0 {
+			return nil, fmt.Errorf("rule %d: invalid digestPriority %q", i, rule.DigestPriority)
+		}
+		n.rules = append(n.rules, rule)
+	}
+	return n, nil
+}
+
+// Notify routes an incident. Immediate messages are posted before Notify
+// returns; digest items are queued. Suppressed incidents are ignored.
+func (n *Notifier) Notify(ctx context.Context, incident types.Incident) error {
+	if incident.Suppressed {
+		return nil
+	}
+	priority := n.priority.MapPriority(incident.ConfidenceScore, incident.SeverityType)
+	item := Item{Incident: incident, Priority: priority}
+
+	n.mu.Lock()
+	var immediate []Channel
+	for _, name := range n.route(incident, priority, true) {
+		ch := n.channels[name]
+		if n.allow(ch) {
+			immediate = append(immediate, ch.Channel)
+			continue
+		}
+		ch.digest = append(ch.digest, item)
+		metrics.NotificationsTotal.WithLabelValues(name, "throttled").Inc()
+	}
+	for _, name := range n.route(incident, priority, false) {
+		n.channels[name].digest = append(n.channels[name].digest, item)
+		metrics.NotificationsTotal.WithLabelValues(name, "digested").Inc()
+	}
+	n.mu.Unlock()
+
+	var errs []error
+	for _, ch := range immediate {
+		var payload interface{}
+		if ch.Kind == KindTeams {
+			payload = TeamsMessage(item, n.incidentURL(incident))
+		} else {
+			payload = SlackMessage(item, n.incidentURL(incident))
+		}
+		errs = append(errs, n.send(ctx, ch, payload))
+	}
+	return errors.Join(errs...)
+}
+
+// FlushDigests posts one digest message per channel with pending items.
+// Items whose post fails are dropped; the error is returned.
+func (n *Notifier) FlushDigests(ctx context.Context) error {
+	n.mu.Lock()
+	pending := make(map[string][]Item)
+	for name, ch := range n.channels {
+		if len(ch.digest) > 0 {
+			pending[name] = ch.digest
+			ch.digest = nil
+		}
+	}
+	n.mu.Unlock()
+
+	names := make([]string, 0, len(pending))
+	for name := range pending {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		items := pending[name]
+		sort.SliceStable(items, func(i, j int) bool {
+			return priorityRank(items[i].Priority) < priorityRank(items[j].Priority)
+		})
+		ch := n.channels[name].Channel
+		var payload interface{}
+		if ch.Kind == KindTeams {
+			payload = TeamsDigest(items, n.digestInterval)
+		} else {
+			payload = SlackDigest(items, n.digestInterval)
+		}
+		errs = append(errs, n.send(ctx, ch, payload))
+	}
+	return errors.Join(errs...)
+}
+
+// Start flushes digests every digest interval until ctx is cancelled.
+func (n *Notifier) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(n.digestInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := n.FlushDigests(ctx); err != nil {
+					log.Printf("[notify] digest delivery failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// route returns the channels an incident is routed to, either for
+// immediate delivery or (when immediate is false) for the digest only.
+// A channel appears at most once, and never in both lists.
+func (n *Notifier) route(incident types.Incident, priority exporter.PriorityLevel, immediate bool) []string {
+	rank := priorityRank(priority)
+	now := make(map[string]bool)
+	later := make(map[string]bool)
+	for _, rule := range n.rules {
+		if !rule.matches(incident) {
+			continue
+		}
+		if rank <= priorityRank(exporter.PriorityLevel(rule.MinPriority)) {
+			now[rule.Channel] = true
+		} else if rule.DigestPriority != "" && rank <= priorityRank(exporter.PriorityLevel(rule.DigestPriority)) {
+			later[rule.Channel] = true
+		}
+	}
+
+	selected := now
+	if !immediate {
+		selected = make(map[string]bool)
+		for name := range later {
+			if !now[name] {
+				selected[name] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(selected))
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// matches reports whether a rule's project and severity filters match.
+func (r Rule) matches(incident types.Incident) bool {
+	if r.ProjectID != "" && r.ProjectID != "*" && r.ProjectID != incident.ProjectID {
+		return false
+	}
+	if len(r.SeverityTypes) == 0 {
+		return true
+	}
+	for _, s := range r.SeverityTypes {
+		if strings.EqualFold(s, incident.SeverityType) {
+			return true
+		}
+	}
+	return false
+}
+
+// allow reports whether a channel may post another immediate message and
+// records it if so. Callers must hold n.mu.
+func (n *Notifier) allow(ch *channelState) bool {
+	if ch.MaxPerHour <= 0 {
+		return true
+	}
+	now := n.now()
+	cutoff := now.Add(-time.Hour)
+	kept := ch.sent[:0]
+	for _, t := range ch.sent {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	ch.sent = kept
+	if len(ch.sent) >= ch.MaxPerHour {
+		return false
+	}
+	ch.sent = append(ch.sent, now)
+	return true
+}
+
+// incidentURL links to the incident in the dashboard, if configured.
+func (n *Notifier) incidentURL(incident types.Incident) string {
+	if n.dashboardURL == "" {
+		return ""
+	}
+	return n.dashboardURL + "/incidents/" + incident.IncidentID
+}
+
+// send POSTs a message to a channel's incoming webhook.
+func (n *Notifier) send(ctx context.Context, ch Channel, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("notify %s: %w", ch.Name, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ch.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify %s: %w", ch.Name, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		metrics.NotificationsTotal.WithLabelValues(ch.Name, "failed").Inc()
+		return fmt.Errorf("notify %s: %w", ch.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		metrics.NotificationsTotal.WithLabelValues(ch.Name, "failed").Inc()
+		return fmt.Errorf("notify %s: HTTP %d: %s", ch.Name, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	metrics.NotificationsTotal.WithLabelValues(ch.Name, "sent").Inc()
+	return nil
+}
+
+// priorityRank orders priorities, P0 = 0 (most urgent). Unknown = -1.
+func priorityRank(p exporter.PriorityLevel) int {
+	switch p {
+	case exporter.PriorityP0:
+		return 0
+	case exporter.PriorityP1:
+		return 1
+	case exporter.PriorityP2:
+		return 2
+	case exporter.PriorityP3:
+		return 3
+	case exporter.PriorityP4:
+		return 4
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,121 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+// hookRecorder is a fake incoming-webhook endpoint.
+type hookRecorder struct {
+	mu       sync.Mutex
+	messages []map[string]interface{}
+}
+
+func (h *hookRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var msg map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&msg)
+	h.mu.Lock()
+	h.messages = append(h.messages, msg)
+	h.mu.Unlock()
+	w.Write([]byte("ok"))
+}
+
+func (h *hookRecorder) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.messages)
+}
+
+// incident returns an incident whose priority is P0 for "Critical", P2 for
+// "Medium" and P3 for "Low" (confidence ≥ 90).
+func incident(id, project, severity string) types.Incident {
+	return types.Incident{
+		IncidentID:          id,
+		ProjectID:           project,
+		SeverityType:        severity,
+		ConfidenceScore:     95,
+		ConfidenceLevel:     "high",
+		PrimaryFailurePoint: "/checkout",
+		TriggeringSignals:   []string{"rage_click", "error"},
+	}
+}
+
+func TestNotify_RoutesThrottlesAndDigests(t *testing.T) {
+	slack := &hookRecorder{}
+	slackServer := httptest.NewServer(slack)
+	defer slackServer.Close()
+	teams := &hookRecorder{}
+	teamsServer := httptest.NewServer(teams)
+	defer teamsServer.Close()
+
+	n, err := New(Config{
+		Channels: []Channel{
+			{Name: "oncall", Kind: KindSlack, WebhookURL: slackServer.URL, MaxPerHour: 1},
+			{Name: "web-team", Kind: KindTeams, WebhookURL: teamsServer.URL},
+		},
+		Rules: []Rule{
+			{Channel: "oncall", MinPriority: "P0", DigestPriority: "P2"},
+			{Channel: "web-team", ProjectID: "web", SeverityTypes: []string{"critical"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	n.Notify(ctx, incident("a", "web", "Critical"))    // immediate to both
+	n.Notify(ctx, incident("b", "mobile", "Critical")) // oncall throttled → digest
+	n.Notify(ctx, incident("c", "web", "Medium"))      // P2 → oncall digest only
+	n.Notify(ctx, incident("d", "web", "Low"))         // P3 → dropped
+
+	if slack.count() != 1 || teams.count() != 1 {
+		t.Fatalf("immediate messages: slack=%d teams=%d, want 1 each", slack.count(), teams.count())
+	}
+	attachment := teams.messages[0]["attachments"].([]interface{})[0].(map[string]interface{})
+	if attachment["contentType"] != "application/vnd.microsoft.card.adaptive" {
+		t.Errorf("teams message is not an Adaptive Card: %v", attachment)
+	}
+	if text, _ := slack.messages[0]["text"].(string); !strings.HasPrefix(text, "[P0]") {
+		t.Errorf("slack fallback text = %q", text)
+	}
+
+	if err := n.FlushDigests(ctx); err != nil {
+		t.Fatalf("FlushDigests: %v", err)
+	}
+	if slack.count() != 2 || teams.count() != 1 {
+		t.Fatalf("after flush: slack=%d teams=%d, want 2 and 1", slack.count(), teams.count())
+	}
+	digest, _ := json.Marshal(slack.messages[1])
+	if !strings.Contains(string(digest), "2 incident(s)") {
+		t.Errorf("digest should batch both deferred incidents: %s", digest)
+	}
+
+	// Throttle window rolls over after an hour
+	n.now = func() time.Time { return time.Now().Add(61 * time.Minute) }
+	n.Notify(ctx, incident("e", "mobile", "Critical"))
+	if slack.count() != 3 {
+		t.Errorf("expected an immediate message once the throttle window passed, got %d messages", slack.count())
+	}
+}
+
+func TestNew_RejectsInvalidConfig(t *testing.T) {
+	cases := []Config{
+		{Channels: []Channel{{Name: "x", Kind: "email", WebhookURL: "http://x"}}},
+		{Rules: []Rule{{Channel: "missing"}}},
+		{Channels: []Channel{{Name: "x", Kind: KindSlack, WebhookURL: "http://x"}}, Rules: []Rule{{Channel: "x", MinPriority: "urgent"}}},
+		{DigestInterval: "hourly"},
+	}
+	for i, cfg := range cases {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
@@ -40,6 +40,15 @@ var (
 		Help: "Total number of export failures",
 	})
 
+	// TicketSyncOutcomes tracks incidents updated from ticket status by outcome
+	TicketSyncOutcomes = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ticket_exporter_sync_outcomes_total",
+			Help: "Total number of incidents updated from ticket status by outcome",
+		},
+		[]string{"outcome"},
+	)
+
 	// SessionsProcessed tracks sessions processed by UFSE
 	SessionsProcessed = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "ufse_sessions_processed_total",
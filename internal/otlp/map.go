@@ -0,0 +1,282 @@
+package otlp
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/metrics"
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+// Mapping defaults.
+const (
+	// DefaultSessionAttribute is the attribute holding the session ID.
+	DefaultSessionAttribute = "session.id"
+	// DefaultSlowThreshold is how long a successful request may take before
+	// it maps to slow_response.
+	DefaultSlowThreshold = 3 * time.Second
+)
+
+// Record outcomes, as counted in metrics.OTLPRecords.
+const (
+	outcomeMapped   = "mapped"
+	outcomeIgnored  = "ignored"
+	outcomeRejected = "rejected"
+)
+
+// Options configure a Mapper.
+type Options struct {
+	// SessionAttribute names the attribute holding the session ID (default
+	// DefaultSessionAttribute).
+	SessionAttribute string
+	// RouteAttribute names an attribute holding the page URL or path. When
+	// unset or absent, user interaction spans use their page URL (http.url)
+	// and other records the route "/".
+	RouteAttribute string
+	// SlowThreshold is the duration of a slow request (default
+	// DefaultSlowThreshold).
+	SlowThreshold time.Duration
+}
+
+// Mapper converts OTLP spans and log records to events.
+type Mapper struct {
+	opts Options
+}
+
+// NewMapper creates a mapper, applying defaults to unset options.
+func NewMapper(opts Options) *Mapper {
+	if opts.SessionAttribute == "" {
+		opts.SessionAttribute = DefaultSessionAttribute
+	}
+	if opts.SlowThreshold <= 0 {
+		opts.SlowThreshold = DefaultSlowThreshold
+	}
+	return &Mapper{opts: opts}
+}
+
+// Result is the outcome of mapping a request.
+type Result struct {
+	Events   []types.Event
+	Rejected int // records without a session ID
+	Ignored  int // records that map to no event
+}
+
+func (r *Result) record(signal string, event types.Event, outcome string) {
+	switch outcome {
+	case outcomeMapped:
+		r.Events = append(r.Events, event)
+	case outcomeRejected:
+		r.Rejected++
+	default:
+		r.Ignored++
+	}
+	metrics.OTLPRecords.WithLabelValues(signal, outcome).Inc()
+}
+
+// Traces maps the spans of a traces request.
+func (m *Mapper) Traces(req TracesRequest) Result {
+	var result Result
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				event, outcome := m.span(span, ss.Scope, rs.Resource)
+				result.record("traces", event, outcome)
+			}
+		}
+	}
+	return result
+}
+
+// Logs maps the log records of a logs request.
+func (m *Mapper) Logs(req LogsRequest) Result {
+	var result Result
+	for _, rl := range req.ResourceLogs {
+		for _, sl := range rl.ScopeLogs {
+			for _, rec := range sl.LogRecords {
+				event, outcome := m.logRecord(rec, rl.Resource)
+				result.record("logs", event, outcome)
+			}
+		}
+	}
+	return result
+}
+
+// span maps a span from the long-task, user-interaction or fetch/XHR
+// instrumentations.
+func (m *Mapper) span(span Span, scope Scope, res Resource) (types.Event, string) {
+	attrs := span.Attributes
+	event := types.Event{
+		SessionID:     lookup(m.opts.SessionAttribute, attrs, res.Attributes),
+		Timestamp:     timestamp(span.StartTimeUnixNano),
+		Environment:   res.Attributes.String("deployment.environment"),
+		Metadata:      map[string]interface{}{},
+		SchemaVersion: types.CurrentSchemaVersion,
+	}
+	if span.TraceID != "" && span.SpanID != "" {
+		event.IdempotencyKey = "otlp:" + span.TraceID + ":" + span.SpanID
+	}
+	duration := spanDuration(span)
+	pageURL := lookup(m.opts.RouteAttribute, attrs, res.Attributes)
+
+	switch interaction := attrs.String("event_type"); {
+	case span.Name == "longtask" || strings.Contains(scope.Name, "long-task"):
+		event.EventType = "long_task"
+		event.Target = types.EventTarget{Type: "window"}
+		event.Metadata["duration"] = millis(duration)
+
+	case interaction != "":
+		event.EventType = interactionEventType(interaction)
+		if event.EventType == "" {
+			return event, outcomeIgnored
+		}
+		tag := strings.ToLower(attrs.String("target_element"))
+		event.Target = types.EventTarget{Type: "element", Selector: attrs.String("target_xpath"), TagName: tag}
+		if pageURL == "" {
+			pageURL = attrs.String("http.url")
+		}
+
+	case firstString(attrs, "http.request.method", "http.method") != "":
+		status, _ := firstNumber(attrs, "http.response.status_code", "http.status_code")
+		failed := status >= 400 || (status == 0 && span.Status.Code == StatusCodeError)
+		switch {
+		case failed:
+			event.EventType = "network_error"
+			if span.Status.Message != "" {
+				event.Metadata["message"] = span.Status.Message
+			}
+		case duration >= m.opts.SlowThreshold:
+			event.EventType = "slow_response"
+		default:
+			event.EventType = "network_success"
+		}
+		event.Timestamp = timestamp(span.EndTimeUnixNano)
+		event.Target = types.EventTarget{Type: "network"}
+		event.Metadata["url"] = firstString(attrs, "url.full", "http.url")
+		event.Metadata["method"] = firstString(attrs, "http.request.method", "http.method")
+		event.Metadata["duration"] = millis(duration)
+		if status > 0 {
+			event.Metadata["status"] = status
+		}
+
+	default:
+		return event, outcomeIgnored
+	}
+
+	if event.SessionID == "" {
+		return event, outcomeRejected
+	}
+	event.Route = route(pageURL)
+	return event, outcomeMapped
+}
+
+// logRecord maps an error log record or a record carrying an exception.
+func (m *Mapper) logRecord(rec LogRecord, res Resource) (types.Event, string) {
+	attrs := rec.Attributes
+	excType, excMessage := attrs.String("exception.type"), attrs.String("exception.message")
+	if rec.SeverityNumber < SeverityError && excType == "" && excMessage == "" {
+		return types.Event{}, outcomeIgnored
+	}
+
+	ts := rec.TimeUnixNano
+	if ts == 0 {
+		ts = rec.ObservedTimeUnixNano
+	}
+	event := types.Event{
+		EventType:     "error",
+		Timestamp:     timestamp(ts),
+		SessionID:     lookup(m.opts.SessionAttribute, attrs, res.Attributes),
+		Route:         route(lookup(m.opts.RouteAttribute, attrs, res.Attributes)),
+		Target:        types.EventTarget{Type: "error"},
+		Environment:   res.Attributes.String("deployment.environment"),
+		Metadata:      map[string]interface{}{},
+		SchemaVersion: types.CurrentSchemaVersion,
+	}
+	if event.SessionID == "" {
+		return event, outcomeRejected
+	}
+
+	message := excMessage
+	if message == "" {
+		message = rec.Body.String()
+	}
+	if message == "" {
+		message = "Unknown error"
+	}
+	event.Metadata["message"] = message
+	if excType != "" {
+		event.Metadata["type"] = excType
+	}
+	if rec.SeverityText != "" {
+		event.Metadata["severity"] = rec.SeverityText
+	}
+	return event, outcomeMapped
+}
+
+// interactionEventType maps a DOM event type from the user-interaction
+// instrumentation ("" for unsupported ones).
+func interactionEventType(domEvent string) string {
+	switch domEvent {
+	case "click", "dblclick":
+		return "click"
+	case "submit":
+		return "form_submit"
+	case "input", "change":
+		return "input"
+	}
+	return ""
+}
+
+// lookup returns the string attribute key from the record's attributes, or
+// else its resource's.
+func lookup(key string, attrs, resource Attributes) string {
+	if key == "" {
+		return ""
+	}
+	if v := attrs.String(key); v != "" {
+		return v
+	}
+	return resource.String(key)
+}
+
+func firstString(attrs Attributes, keys ...string) string {
+	for _, key := range keys {
+		if v := attrs.String(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func firstNumber(attrs Attributes, keys ...string) (float64, bool) {
+	for _, key := range keys {
+		if v, ok := attrs.Number(key); ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// route returns the path of a page URL, or "/".
+func route(pageURL string) string {
+	if u, err := url.Parse(pageURL); err == nil && strings.HasPrefix(u.Path, "/") {
+		return u.Path
+	}
+	return "/"
+}
+
+func timestamp(unixNano Uint64) string {
+	return time.Unix(0, int64(unixNano)).UTC().Format(time.RFC3339Nano)
+}
+
+func spanDuration(span Span) time.Duration {
+	if span.EndTimeUnixNano <= span.StartTimeUnixNano {
+		return 0
+	}
+	return time.Duration(span.EndTimeUnixNano - span.StartTimeUnixNano)
+}
+
+// millis is a duration in milliseconds, as the SDK reports them.
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,125 @@
+package otlp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// tracesJSON is shaped like the OpenTelemetry browser SDK's OTLP/HTTP JSON
+// export: int64 values as strings, session ID on the resource.
+const tracesJSON = `{"resourceSpans":[{
+  "resource":{"attributes":[
+    {"key":"service.name","value":{"stringValue":"shop-web"}},
+    {"key":"deployment.environment","value":{"stringValue":"production"}},
+    {"key":"session.id","value":{"stringValue":"sess-1"}}]},
+  "scopeSpans":[
+    {"scope":{"name":"@opentelemetry/instrumentation-user-interaction"},"spans":[
+      {"traceId":"t1","spanId":"s1","name":"click","startTimeUnixNano":"1700000000000000000","endTimeUnixNano":"1700000000005000000",
+       "attributes":[{"key":"event_type","value":{"stringValue":"click"}},
+                     {"key":"target_element","value":{"stringValue":"BUTTON"}},
+                     {"key":"target_xpath","value":{"stringValue":"//*[@id=\"pay\"]"}},
+                     {"key":"http.url","value":{"stringValue":"https://shop.example.com/checkout?step=2"}}]},
+      {"traceId":"t1","spanId":"s2","name":"mouseover","startTimeUnixNano":"1700000000000000000",
+       "attributes":[{"key":"event_type","value":{"stringValue":"mouseover"}}]}]},
+    {"scope":{"name":"@opentelemetry/instrumentation-fetch"},"spans":[
+      {"traceId":"t1","spanId":"s3","name":"HTTP POST","startTimeUnixNano":1700000000010000000,"endTimeUnixNano":1700000000250000000,
+       "status":{"code":2,"message":"Internal Server Error"},
+       "attributes":[{"key":"http.method","value":{"stringValue":"POST"}},
+                     {"key":"http.url","value":{"stringValue":"https://api.example.com/orders"}},
+                     {"key":"http.status_code","value":{"intValue":"500"}}]},
+      {"traceId":"t1","spanId":"s4","name":"HTTP GET","startTimeUnixNano":"1700000000000000000","endTimeUnixNano":"1700000004000000000",
+       "attributes":[{"key":"http.request.method","value":{"stringValue":"GET"}},
+                     {"key":"http.response.status_code","value":{"intValue":200}},
+                     {"key":"session.id","value":{"stringValue":"sess-2"}}]}]},
+    {"scope":{"name":"@opentelemetry/instrumentation-long-task"},"spans":[
+      {"traceId":"t2","spanId":"s5","name":"longtask","startTimeUnixNano":"1700000000000000000","endTimeUnixNano":"1700000000120000000"}]},
+    {"scope":{"name":"@opentelemetry/instrumentation-document-load"},"spans":[
+      {"traceId":"t3","spanId":"s6","name":"documentLoad","startTimeUnixNano":"1700000000000000000"}]}]},
+  {"resource":{"attributes":[]},"scopeSpans":[{"scope":{"name":"@opentelemetry/instrumentation-fetch"},"spans":[
+      {"traceId":"t4","spanId":"s7","name":"HTTP GET","attributes":[{"key":"http.method","value":{"stringValue":"GET"}}]}]}]}]}`
+
+func TestMapperTraces(t *testing.T) {
+	var req TracesRequest
+	if err := json.Unmarshal([]byte(tracesJSON), &req); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	result := NewMapper(Options{}).Traces(req)
+	if result.Rejected != 1 || result.Ignored != 2 || len(result.Events) != 4 {
+		t.Fatalf("result = %d events, %d rejected, %d ignored; want 4, 1, 2", len(result.Events), result.Rejected, result.Ignored)
+	}
+
+	click := result.Events[0]
+	if click.EventType != "click" || click.SessionID != "sess-1" || click.Route != "/checkout" ||
+		click.Target.TagName != "button" || click.Target.Selector != `//*[@id="pay"]` ||
+		click.Environment != "production" || click.IdempotencyKey != "otlp:t1:s1" ||
+		click.Timestamp != "2023-11-14T22:13:20Z" {
+		t.Errorf("click = %+v", click)
+	}
+
+	netErr := result.Events[1]
+	if netErr.EventType != "network_error" || netErr.Route != "/" || netErr.Metadata["status"] != 500.0 ||
+		netErr.Metadata["method"] != "POST" || netErr.Metadata["url"] != "https://api.example.com/orders" ||
+		netErr.Metadata["duration"] != 240.0 || netErr.Metadata["message"] != "Internal Server Error" {
+		t.Errorf("network error = %+v", netErr)
+	}
+
+	// Span attributes take precedence over the resource's
+	slow := result.Events[2]
+	if slow.EventType != "slow_response" || slow.SessionID != "sess-2" {
+		t.Errorf("slow request = %+v, want slow_response for sess-2", slow)
+	}
+
+	if long := result.Events[3]; long.EventType != "long_task" || long.Metadata["duration"] != 120.0 {
+		t.Errorf("long task = %+v", long)
+	}
+}
+
+func TestMapperAttributes(t *testing.T) {
+	str := func(s string) AnyValue { return AnyValue{StringValue: &s} }
+	span := Span{
+		Name:              "click",
+		StartTimeUnixNano: 1,
+		Attributes: Attributes{
+			{Key: "event_type", Value: str("submit")},
+			{Key: "app.session", Value: str("custom")},
+			{Key: "page.url", Value: str("/cart")},
+		},
+	}
+	req := TracesRequest{ResourceSpans: []ResourceSpans{{ScopeSpans: []ScopeSpans{{Spans: []Span{span}}}}}}
+
+	result := NewMapper(Options{SessionAttribute: "app.session", RouteAttribute: "page.url"}).Traces(req)
+	if len(result.Events) != 1 {
+		t.Fatalf("events = %d, want 1", len(result.Events))
+	}
+	if e := result.Events[0]; e.EventType != "form_submit" || e.SessionID != "custom" || e.Route != "/cart" {
+		t.Errorf("event = %+v, want form_submit for custom on /cart", e)
+	}
+}
+
+func TestMapperLogs(t *testing.T) {
+	const logsJSON = `{"resourceLogs":[{
+	  "resource":{"attributes":[{"key":"session.id","value":{"stringValue":"sess-1"}}]},
+	  "scopeLogs":[{"logRecords":[
+	    {"timeUnixNano":"1700000000000000000","severityNumber":17,"severityText":"ERROR",
+	     "body":{"stringValue":"Checkout failed"}},
+	    {"observedTimeUnixNano":"1700000000000000000","severityNumber":9,
+	     "attributes":[{"key":"exception.type","value":{"stringValue":"TypeError"}},
+	                   {"key":"exception.message","value":{"stringValue":"x is undefined"}}]},
+	    {"timeUnixNano":"1700000000000000000","severityNumber":9,"body":{"stringValue":"page viewed"}}]}]}]}`
+
+	var req LogsRequest
+	if err := json.Unmarshal([]byte(logsJSON), &req); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	result := NewMapper(Options{}).Logs(req)
+	if len(result.Events) != 2 || result.Ignored != 1 {
+		t.Fatalf("result = %d events, %d ignored; want 2, 1", len(result.Events), result.Ignored)
+	}
+	if e := result.Events[0]; e.EventType != "error" || e.Metadata["message"] != "Checkout failed" || e.Metadata["severity"] != "ERROR" {
+		t.Errorf("error log = %+v", e)
+	}
+	if e := result.Events[1]; e.Metadata["message"] != "x is undefined" || e.Metadata["type"] != "TypeError" || e.Timestamp == "" {
+		t.Errorf("exception log = %+v", e)
+	}
+}
@@ -0,0 +1,238 @@
+// Package otlp maps OpenTelemetry data to HawkEye events, so frontends
+// instrumented with the OpenTelemetry browser SDK can be analyzed without the
+// HawkEye SDK.
+//
+// It reads OTLP/HTTP requests in the JSON encoding (the browser exporters'
+// default): ExportTraceServiceRequest and ExportLogsServiceRequest. Only the
+// fields the mapping needs are decoded. Spans from the user-interaction,
+// fetch/XMLHttpRequest and long-task instrumentations become click (and
+// form/input), network and long_task events; error log records become error
+// events. Everything else (document load spans, informational logs) is
+// accepted and ignored.
+//
+// Each record's session ID is read from a configurable attribute, on the
+// record first and then on its resource. Records without one cannot be
+// attributed to a session and are rejected.
+package otlp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// TracesRequest is an OTLP ExportTraceServiceRequest.
+type TracesRequest struct {
+	ResourceSpans []ResourceSpans `json:"resourceSpans"`
+}
+
+// ResourceSpans are the spans of one resource (e.g. one page load).
+type ResourceSpans struct {
+	Resource   Resource     `json:"resource"`
+	ScopeSpans []ScopeSpans `json:"scopeSpans"`
+}
+
+// ScopeSpans are the spans of one instrumentation scope.
+type ScopeSpans struct {
+	Scope Scope  `json:"scope"`
+	Spans []Span `json:"spans"`
+}
+
+// Span is an OTLP span.
+type Span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	Name              string     `json:"name"`
+	StartTimeUnixNano Uint64     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   Uint64     `json:"endTimeUnixNano"`
+	Attributes        Attributes `json:"attributes"`
+	Status            Status     `json:"status"`
+}
+
+// Status is a span status.
+type Status struct {
+	Code    int    `json:"code"` // StatusCodeError for failed spans
+	Message string `json:"message"`
+}
+
+// StatusCodeError is the status code of a span that failed.
+const StatusCodeError = 2
+
+// LogsRequest is an OTLP ExportLogsServiceRequest.
+type LogsRequest struct {
+	ResourceLogs []ResourceLogs `json:"resourceLogs"`
+}
+
+// ResourceLogs are the log records of one resource.
+type ResourceLogs struct {
+	Resource  Resource    `json:"resource"`
+	ScopeLogs []ScopeLogs `json:"scopeLogs"`
+}
+
+// ScopeLogs are the log records of one instrumentation scope.
+type ScopeLogs struct {
+	Scope      Scope       `json:"scope"`
+	LogRecords []LogRecord `json:"logRecords"`
+}
+
+// LogRecord is an OTLP log record.
+type LogRecord struct {
+	TimeUnixNano         Uint64     `json:"timeUnixNano"`
+	ObservedTimeUnixNano Uint64     `json:"observedTimeUnixNano"`
+	SeverityNumber       int        `json:"severityNumber"`
+	SeverityText         string     `json:"severityText"`
+	Body                 AnyValue   `json:"body"`
+	Attributes           Attributes `json:"attributes"`
+}
+
+// SeverityError is the lowest severity number of an error log record.
+const SeverityError = 17
+
+// Resource describes the entity that produced the data.
+type Resource struct {
+	Attributes Attributes `json:"attributes"`
+}
+
+// Scope identifies an instrumentation library.
+type Scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// KeyValue is an attribute.
+type KeyValue struct {
+	Key   string   `json:"key"`
+	Value AnyValue `json:"value"`
+}
+
+// Attributes is a list of attributes.
+type Attributes []KeyValue
+
+// Get returns the value of the attribute named key.
+func (a Attributes) Get(key string) (AnyValue, bool) {
+	for _, kv := range a {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return AnyValue{}, false
+}
+
+// String returns the attribute named key as a string ("" when absent).
+func (a Attributes) String(key string) string {
+	v, _ := a.Get(key)
+	return v.String()
+}
+
+// Number returns the attribute named key as a number.
+func (a Attributes) Number(key string) (float64, bool) {
+	v, ok := a.Get(key)
+	if !ok {
+		return 0, false
+	}
+	return v.Number()
+}
+
+// AnyValue is an attribute or log body value. Exactly one field is set.
+type AnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *Int64   `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	ArrayValue  *struct {
+		Values []AnyValue `json:"values"`
+	} `json:"arrayValue,omitempty"`
+	KvlistValue *struct {
+		Values Attributes `json:"values"`
+	} `json:"kvlistValue,omitempty"`
+}
+
+// String formats the value as a string ("" when unset; arrays and maps as
+// JSON).
+func (v AnyValue) String() string {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return strconv.FormatBool(*v.BoolValue)
+	case v.IntValue != nil:
+		return strconv.FormatInt(int64(*v.IntValue), 10)
+	case v.DoubleValue != nil:
+		return strconv.FormatFloat(*v.DoubleValue, 'g', -1, 64)
+	case v.ArrayValue != nil, v.KvlistValue != nil:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	return ""
+}
+
+// Number returns an int, double or numeric string value as a float64.
+func (v AnyValue) Number() (float64, bool) {
+	switch {
+	case v.IntValue != nil:
+		return float64(*v.IntValue), true
+	case v.DoubleValue != nil:
+		return *v.DoubleValue, true
+	case v.StringValue != nil:
+		f, err := strconv.ParseFloat(*v.StringValue, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// Int64 is a 64-bit integer, which the OTLP JSON encoding writes as a
+// string but also accepts as a number.
+type Int64 int64
+
+// UnmarshalJSON accepts a number or a decimal string.
+func (n *Int64) UnmarshalJSON(data []byte) error {
+	v, err := strconv.ParseInt(unquote(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid int64 %s", data)
+	}
+	*n = Int64(v)
+	return nil
+}
+
+// Uint64 is an unsigned 64-bit integer (e.g. a timestamp in nanoseconds),
+// written as a string or a number like Int64.
+type Uint64 uint64
+
+// UnmarshalJSON accepts a number or a decimal string.
+func (n *Uint64) UnmarshalJSON(data []byte) error {
+	v, err := strconv.ParseUint(unquote(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid uint64 %s", data)
+	}
+	*n = Uint64(v)
+	return nil
+}
+
+func unquote(data []byte) string {
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		return string(data[1 : len(data)-1])
+	}
+	return string(data)
+}
+
+// ExportTraceServiceResponse is the OTLP response to a traces request.
+type ExportTraceServiceResponse struct {
+	PartialSuccess *ExportTracePartialSuccess `json:"partialSuccess,omitempty"`
+}
+
+// ExportTracePartialSuccess reports spans that were rejected.
+type ExportTracePartialSuccess struct {
+	RejectedSpans int64  `json:"rejectedSpans,string,omitempty"`
+	ErrorMessage  string `json:"errorMessage,omitempty"`
+}
+
+// ExportLogsServiceResponse is the OTLP response to a logs request.
+type ExportLogsServiceResponse struct {
+	PartialSuccess *ExportLogsPartialSuccess `json:"partialSuccess,omitempty"`
+}
+
+// ExportLogsPartialSuccess reports log records that were rejected.
+type ExportLogsPartialSuccess struct {
+	RejectedLogRecords int64  `json:"rejectedLogRecords,string,omitempty"`
+	ErrorMessage       string `json:"errorMessage,omitempty"`
+}
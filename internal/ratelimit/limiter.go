@@ -3,12 +3,18 @@
  *
  * Author: Frank Miller (Team Beta)
  * Responsibility: Per-API-key rate limiting with burst tolerance
+ *
+ * Keys are API keys or project IDs. A request may take several tokens
+ * (AllowN, e.g. one per event); a full bucket admits a request larger than
+ * its burst and goes into debt, so big batches are slowed down, not refused
+ * forever
  */
 
 package ratelimit
 
 import (
 	"context"
+	"math"
 	"sync"
 	"time"
 )
@@ -17,11 +23,27 @@ import (
 type Limiter struct {
 	mu              sync.RWMutex
 	limiters        map[string]*tokenBucket
-	rate            int // requests per second
-	burst           int // burst capacity
+	overrides       map[string]bucketLimit // per-key rate and burst
+	rate            int                    // requests per second
+	burst           int                    // burst capacity
 	cleanupInterval time.Duration
 }
 
+// bucketLimit is the rate and burst of a key's bucket
+type bucketLimit struct {
+	rate  int
+	burst int
+}
+
+// Decision is the outcome of AllowN
+type Decision struct {
+	Allowed    bool
+	Limit      int           // bucket capacity (0 = unlimited)
+	Remaining  int           // whole tokens left after the request
+	RetryAfter time.Duration // wait before the request would be allowed
+	Reset      time.Duration // time until the bucket is full again
+}
+
 // tokenBucket implements token bucket algorithm
 type tokenBucket struct {
 	tokens     float64
@@ -35,6 +57,7 @@ type tokenBucket struct {
 func NewLimiter(rate, burst int) *Limiter {
 	limiter := &Limiter{
 		limiters:        make(map[string]*tokenBucket),
+		overrides:       make(map[string]bucketLimit),
 		rate:            rate,
 		burst:           burst,
 		cleanupInterval: 5 * time.Minute,
@@ -57,6 +80,24 @@ func (l *Limiter) Allow(ctx context.Context, apiKey string) bool {
 	return bucket.allow()
 }
 
+// SetLimit overrides the rate and burst for one key (rate 0 = unlimited)
+func (l *Limiter) SetLimit(key string, rate, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.overrides[key] = bucketLimit{rate: rate, burst: burst}
+	delete(l.limiters, key)
+}
+
+// AllowN takes n tokens from the key's bucket if it has them. A request
+// larger than the burst is allowed once the bucket is full
+func (l *Limiter) AllowN(ctx context.Context, key string, n int) Decision {
+	bucket := l.getBucket(key)
+	if bucket.rate <= 0 {
+		return Decision{Allowed: true}
+	}
+	return bucket.take(float64(n), time.Now())
+}
+
 // getBucket gets or creates token bucket for API key
 func (l *Limiter) getBucket(apiKey string) *tokenBucket {
 	l.mu.RLock()
@@ -76,10 +117,14 @@ func (l *Limiter) getBucket(apiKey string) *tokenBucket {
 		return bucket
 	}
 
+	limit, ok := l.overrides[apiKey]
+	if !ok {
+		limit = bucketLimit{rate: l.rate, burst: l.burst}
+	}
 	bucket = &tokenBucket{
-		tokens:     float64(l.burst),
-		capacity:   float64(l.burst),
-		rate:       float64(l.rate),
+		tokens:     float64(limit.burst),
+		capacity:   float64(limit.burst),
+		rate:       float64(limit.rate),
 		lastRefill: time.Now(),
 	}
 
@@ -108,6 +153,31 @@ func (tb *tokenBucket) allow() bool {
 	return false
 }
 
+// take removes n tokens if the bucket holds them (or is full)
+func (tb *tokenBucket) take(n float64, now time.Time) Decision {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.tokens = min(tb.capacity, tb.tokens+now.Sub(tb.lastRefill).Seconds()*tb.rate)
+	tb.lastRefill = now
+
+	d := Decision{Limit: int(tb.capacity)}
+	if need := min(n, tb.capacity); tb.tokens >= need {
+		tb.tokens -= n
+		d.Allowed = true
+	} else {
+		d.RetryAfter = secondsDuration((need - tb.tokens) / tb.rate)
+	}
+	d.Remaining = int(math.Max(0, math.Floor(tb.tokens)))
+	d.Reset = secondsDuration((tb.capacity - tb.tokens) / tb.rate)
+	return d
+}
+
+// secondsDuration converts fractional seconds to a duration
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
 // cleanup removes old buckets periodically
 func (l *Limiter) cleanup() {
 	ticker := time.NewTicker(l.cleanupInterval)
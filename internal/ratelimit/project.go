@@ -0,0 +1,205 @@
+/**
+ * Project Ingestion Limits
+ *
+ * Responsibility: Per-project request rate, event rate and event quotas
+ * for the ingestion endpoint
+ *
+ * Checks run in order and the first one that fails rejects the request:
+ * 1. Requests per second (one token per request)
+ * 2. Events per second (one token per event in the batch)
+ * 3. Daily and monthly event quotas (QuotaTracker)
+ *
+ * Projects without an entry in Config.Projects use Config.Default; an entry
+ * replaces the default limits as a whole. Zero means unlimited
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Limits rejected by ProjectLimiter.Check, besides the quota periods
+const (
+	LimitRequests = "requests"
+	LimitEvents   = "events"
+)
+
+// Limits are the ingestion limits of a project
+type Limits struct {
+	RequestsPerSecond int   `json:"requestsPerSecond"`
+	RequestBurst      int   `json:"requestBurst"` // default: RequestsPerSecond
+	EventsPerSecond   int   `json:"eventsPerSecond"`
+	EventBurst        int   `json:"eventBurst"` // default: EventsPerSecond
+	DailyEvents       int64 `json:"dailyEvents"`
+	MonthlyEvents     int64 `json:"monthlyEvents"`
+}
+
+// Config holds the default limits and per-project overrides
+type Config struct {
+	Default  Limits            `json:"default"`
+	Projects map[string]Limits `json:"projects,omitempty"`
+}
+
+// LoadConfigFile reads a Config from a JSON file
+func LoadConfigFile(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read rate limit config: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse rate limit config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Result is the outcome of a ProjectLimiter check. Limit, Remaining and
+// Reset describe the rejecting limit, or the request rate when allowed
+type Result struct {
+	Allowed    bool
+	Exceeded   string // LimitRequests, LimitEvents, QuotaDaily or QuotaMonthly
+	Limit      int64  // 0 = unlimited
+	Remaining  int64
+	Reset      time.Time
+	RetryAfter time.Duration
+}
+
+// Usage is a project's consumption against its limits
+type Usage struct {
+	ProjectID string      `json:"projectId"`
+	Limits    Limits      `json:"limits"`
+	Daily     PeriodUsage `json:"daily"`
+	Monthly   PeriodUsage `json:"monthly"`
+}
+
+// ProjectLimiter enforces ingestion limits per project
+type ProjectLimiter struct {
+	cfg      Config
+	requests *Limiter
+	events   *Limiter
+	quotas   *QuotaTracker
+	now      func() time.Time
+}
+
+// NewProjectLimiter creates a limiter for cfg, counting quotas in quotas
+func NewProjectLimiter(cfg Config, quotas *QuotaTracker) *ProjectLimiter {
+	l := &ProjectLimiter{
+		cfg:      cfg,
+		requests: NewLimiter(cfg.Default.RequestsPerSecond, burstOr(cfg.Default.RequestBurst, cfg.Default.RequestsPerSecond)),
+		events:   NewLimiter(cfg.Default.EventsPerSecond, burstOr(cfg.Default.EventBurst, cfg.Default.EventsPerSecond)),
+		quotas:   quotas,
+		now:      time.Now,
+	}
+	for projectID, limits := range cfg.Projects {
+		l.requests.SetLimit(projectID, limits.RequestsPerSecond, burstOr(limits.RequestBurst, limits.RequestsPerSecond))
+		l.events.SetLimit(projectID, limits.EventsPerSecond, burstOr(limits.EventBurst, limits.EventsPerSecond))
+	}
+	return l
+}
+
+// Quotas returns the quota tracker
+func (l *ProjectLimiter) Quotas() *QuotaTracker {
+	return l.quotas
+}
+
+// LimitsFor returns the limits that apply to a project
+func (l *ProjectLimiter) LimitsFor(projectID string) Limits {
+	if limits, ok := l.cfg.Projects[projectID]; ok {
+		return limits
+	}
+	return l.cfg.Default
+}
+
+// Check admits a request carrying events for a project, counting it
+// against every limit it passes
+func (l *ProjectLimiter) Check(ctx context.Context, projectID string, events int) Result {
+	now := l.now()
+
+	requests := l.requests.AllowN(ctx, projectID, 1)
+	if !requests.Allowed {
+		return bucketResult(LimitRequests, requests, now)
+	}
+	if result := l.reserveEvents(ctx, projectID, events, now); !result.Allowed {
+		return result
+	}
+
+	result := bucketResult("", requests, now)
+	result.Allowed = true
+	return result
+}
+
+// ReserveEvents counts more events of an admitted request (e.g. the next
+// chunk of a stream) against the event rate and quotas
+func (l *ProjectLimiter) ReserveEvents(ctx context.Context, projectID string, events int) Result {
+	return l.reserveEvents(ctx, projectID, events, l.now())
+}
+
+func (l *ProjectLimiter) reserveEvents(ctx context.Context, projectID string, events int, now time.Time) Result {
+	d := l.events.AllowN(ctx, projectID, events)
+	if !d.Allowed {
+		return bucketResult(LimitEvents, d, now)
+	}
+
+	limits := l.LimitsFor(projectID)
+	quota := l.quotas.Reserve(projectID, events, QuotaLimits{Daily: limits.DailyEvents, Monthly: limits.MonthlyEvents}, now)
+	if !quota.Allowed {
+		period := quota.Day
+		if quota.Exceeded == QuotaMonthly {
+			period = quota.Month
+		}
+		return Result{
+			Exceeded:   quota.Exceeded,
+			Limit:      period.Limit,
+			Remaining:  max64(0, period.Limit-period.Used),
+			Reset:      period.ResetsAt,
+			RetryAfter: period.ResetsAt.Sub(now),
+		}
+	}
+	return bucketResult("", d, now)
+}
+
+// ReleaseEvents returns reserved events that were not ingested to the
+// project's quotas, so only accepted events count. The event rate is not
+// refunded: rejected events still cost the work of validating them
+func (l *ProjectLimiter) ReleaseEvents(projectID string, events int) {
+	l.quotas.Release(projectID, events, l.now())
+}
+
+// Usage returns a project's quota consumption
+func (l *ProjectLimiter) Usage(projectID string) Usage {
+	limits := l.LimitsFor(projectID)
+	day, month := l.quotas.Usage(projectID, QuotaLimits{Daily: limits.DailyEvents, Monthly: limits.MonthlyEvents}, l.now())
+	return Usage{ProjectID: projectID, Limits: limits, Daily: day, Monthly: month}
+}
+
+// bucketResult describes a token bucket decision
+func bucketResult(exceeded string, d Decision, now time.Time) Result {
+	return Result{
+		Allowed:    d.Allowed,
+		Exceeded:   exceeded,
+		Limit:      int64(d.Limit),
+		Remaining:  int64(d.Remaining),
+		Reset:      now.Add(d.Reset),
+		RetryAfter: d.RetryAfter,
+	}
+}
+
+// burstOr defaults a burst to the rate
+func burstOr(burst, rate int) int {
+	if burst > 0 {
+		return burst
+	}
+	return rate
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProjectLimiter_EventRateAndOverrides(t *testing.T) {
+	quotas, _ := NewQuotaTracker("")
+	l := NewProjectLimiter(Config{
+		Default:  Limits{EventsPerSecond: 10},
+		Projects: map[string]Limits{"big": {EventsPerSecond: 1000}},
+	}, quotas)
+	ctx := context.Background()
+
+	// A full bucket admits a batch larger than the burst, then goes into debt
+	if r := l.Check(ctx, "web", 25); !r.Allowed {
+		t.Fatalf("first oversized batch = %+v, want allowed", r)
+	}
+	r := l.Check(ctx, "web", 1)
+	if r.Allowed || r.Exceeded != LimitEvents || r.RetryAfter < time.Second {
+		t.Errorf("batch while in debt = %+v, want events limit with retry after >1s", r)
+	}
+
+	if r := l.Check(ctx, "big", 500); !r.Allowed {
+		t.Errorf("override project = %+v, want allowed", r)
+	}
+}
+
+func TestProjectLimiter_QuotasPersistAndReset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quota.json")
+	now := time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)
+	cfg := Config{Default: Limits{DailyEvents: 100, MonthlyEvents: 150}}
+
+	quotas, err := NewQuotaTracker(path)
+	if err != nil {
+		t.Fatalf("NewQuotaTracker() error = %v", err)
+	}
+	l := NewProjectLimiter(cfg, quotas)
+	l.now = func() time.Time { return now }
+	if r := l.Check(context.Background(), "web", 90); !r.Allowed {
+		t.Fatalf("Check() = %+v, want allowed", r)
+	}
+	if err := quotas.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	// Usage survives a restart
+	quotas, _ = NewQuotaTracker(path)
+	l = NewProjectLimiter(cfg, quotas)
+	l.now = func() time.Time { return now }
+	r := l.Check(context.Background(), "web", 20)
+	if r.Allowed || r.Exceeded != QuotaDaily || r.Remaining != 10 || r.RetryAfter != time.Hour {
+		t.Errorf("Check() over daily quota = %+v, want daily_quota with 10 left, retry in 1h", r)
+	}
+
+	// The next day the daily quota resets but the month keeps counting
+	now = now.Add(2 * time.Hour)
+	if r := l.Check(context.Background(), "web", 20); !r.Allowed {
+		t.Errorf("Check() next day = %+v, want allowed", r)
+	}
+	r = l.Check(context.Background(), "web", 50)
+	if r.Allowed || r.Exceeded != QuotaMonthly {
+		t.Errorf("Check() over monthly quota = %+v, want monthly_quota", r)
+	}
+	if u := l.Usage("web"); u.Daily.Used != 20 || u.Monthly.Used != 110 || u.Monthly.Period != "2026-10" {
+		t.Errorf("Usage() = %+v, want 20 today and 110 this month", u)
+	}
+}
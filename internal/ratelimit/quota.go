@@ -0,0 +1,239 @@
+/**
+ * Event Quotas
+ *
+ * Responsibility: Daily and monthly event quotas per project
+ *
+ * Periods are UTC calendar days and months. Usage is kept in memory and,
+ * with a file, flushed there periodically (Start) and on Flush, so quotas
+ * survive restarts. A crash loses at most one flush interval of usage
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Quota periods, also reported as the rejected limit
+const (
+	QuotaDaily   = "daily_quota"
+	QuotaMonthly = "monthly_quota"
+)
+
+// QuotaLimits caps the events a project may send per period (0 = unlimited)
+type QuotaLimits struct {
+	Daily   int64
+	Monthly int64
+}
+
+// PeriodUsage is a project's consumption in the current period
+type PeriodUsage struct {
+	Period   string    `json:"period"` // "2006-01-02" or "2006-01"
+	Used     int64     `json:"used"`
+	Limit    int64     `json:"limit"` // 0 = unlimited
+	ResetsAt time.Time `json:"resetsAt"`
+}
+
+// QuotaDecision is the outcome of Reserve
+type QuotaDecision struct {
+	Allowed  bool
+	Exceeded string // QuotaDaily or QuotaMonthly when not allowed
+	Day      PeriodUsage
+	Month    PeriodUsage
+}
+
+// quotaUsage is the persisted usage of a project
+type quotaUsage struct {
+	Day         string `json:"day"`
+	DayEvents   int64  `json:"dayEvents"`
+	Month       string `json:"month"`
+	MonthEvents int64  `json:"monthEvents"`
+}
+
+// QuotaTracker counts events per project against quotas
+type QuotaTracker struct {
+	mu     sync.Mutex
+	usage  map[string]*quotaUsage
+	path   string
+	dirty  bool
+	fileMu sync.Mutex // serializes writes to path
+}
+
+// NewQuotaTracker creates a tracker, loading usage from path when set
+// ("" = memory only)
+func NewQuotaTracker(path string) (*QuotaTracker, error) {
+	q := &QuotaTracker{usage: make(map[string]*quotaUsage), path: path}
+	if path == "" {
+		return q, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read quota file: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &q.usage); err != nil {
+			return nil, fmt.Errorf("parse quota file: %w", err)
+		}
+	}
+	return q, nil
+}
+
+// Reserve counts n events for a project if that keeps it within limits
+func (q *QuotaTracker) Reserve(projectID string, n int, limits QuotaLimits, now time.Time) QuotaDecision {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	u := q.current(projectID, now)
+	d := QuotaDecision{}
+	switch {
+	case limits.Daily > 0 && u.DayEvents+int64(n) > limits.Daily:
+		d.Exceeded = QuotaDaily
+	case limits.Monthly > 0 && u.MonthEvents+int64(n) > limits.Monthly:
+		d.Exceeded = QuotaMonthly
+	default:
+		u.DayEvents += int64(n)
+		u.MonthEvents += int64(n)
+		q.dirty = true
+		d.Allowed = true
+	}
+	d.Day, d.Month = dayUsage(u, limits, now), monthUsage(u, limits, now)
+	return d
+}
+
+// Release returns n reserved events to a project's quotas (e.g. events
+// that failed validation after their batch was admitted)
+func (q *QuotaTracker) Release(projectID string, n int, now time.Time) {
+	if n <= 0 {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	u := q.current(projectID, now)
+	u.DayEvents = max64(0, u.DayEvents-int64(n))
+	u.MonthEvents = max64(0, u.MonthEvents-int64(n))
+	q.dirty = true
+}
+
+// Usage returns a project's daily and monthly consumption
+func (q *QuotaTracker) Usage(projectID string, limits QuotaLimits, now time.Time) (day, month PeriodUsage) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	u := q.current(projectID, now)
+	return dayUsage(u, limits, now), monthUsage(u, limits, now)
+}
+
+// current returns a project's usage, reset for periods that have ended
+func (q *QuotaTracker) current(projectID string, now time.Time) *quotaUsage {
+	now = now.UTC()
+	u, ok := q.usage[projectID]
+	if !ok {
+		u = &quotaUsage{}
+		q.usage[projectID] = u
+	}
+	if day := now.Format("2006-01-02"); u.Day != day {
+		u.Day, u.DayEvents = day, 0
+	}
+	if month := now.Format("2006-01"); u.Month != month {
+		u.Month, u.MonthEvents = month, 0
+	}
+	return u
+}
+
+func dayUsage(u *quotaUsage, limits QuotaLimits, now time.Time) PeriodUsage {
+	y, m, d := now.UTC().Date()
+	return PeriodUsage{
+		Period:   u.Day,
+		Used:     u.DayEvents,
+		Limit:    limits.Daily,
+		ResetsAt: time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func monthUsage(u *quotaUsage, limits QuotaLimits, now time.Time) PeriodUsage {
+	y, m, _ := now.UTC().Date()
+	return PeriodUsage{
+		Period:   u.Month,
+		Used:     u.MonthEvents,
+		Limit:    limits.Monthly,
+		ResetsAt: time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// Flush writes usage to the file if it changed since the last flush
+func (q *QuotaTracker) Flush() error {
+	if q.path == "" {
+		return nil
+	}
+	q.fileMu.Lock()
+	defer q.fileMu.Unlock()
+
+	q.mu.Lock()
+	if !q.dirty {
+		q.mu.Unlock()
+		return nil
+	}
+	data, err := json.MarshalIndent(q.usage, "", "  ")
+	q.dirty = false
+	q.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(q.path, data); err != nil {
+		q.mu.Lock()
+		q.dirty = true
+		q.mu.Unlock()
+		return fmt.Errorf("write quota file: %w", err)
+	}
+	return nil
+}
+
+// Start flushes usage every interval until ctx is cancelled, then once more
+func (q *QuotaTracker) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := q.Flush(); err != nil {
+				log.Printf("[ratelimit] quota flush failed: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := q.Flush(); err != nil {
+				log.Printf("[ratelimit] quota flush failed: %v", err)
+			}
+		}
+	}
+}
+
+// writeFileAtomic writes data to a temp file and renames it over path
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
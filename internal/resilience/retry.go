@@ -109,6 +109,13 @@ func isRetryableError(err error, config RetryConfig) bool {
 	return false
 }
 
+// Backoff returns the delay before retry attempt (0-based) under config,
+// exactly as Retry computes it. Callers that schedule retries themselves
+// (rather than blocking in Retry) use it to space attempts the same way
+func Backoff(config RetryConfig, attempt int) time.Duration {
+	return calculateBackoff(config.InitialBackoff, config.MaxBackoff, attempt)
+}
+
 // calculateBackoff calculates backoff duration with jitter
 func calculateBackoff(baseBackoff, maxBackoff time.Duration, attempt int) time.Duration {
 	// Exponential backoff: base * 2^attempt
@@ -0,0 +1,231 @@
+// Package route normalizes raw page routes into stable templates.
+//
+// Routes arrive from the SDK exactly as the browser saw them, so
+// /users/8812/settings and /users/9921/settings look like two different
+// pages. Every route-based grouping downstream (correlation, failure points,
+// route configs, incident dedup, metric labels) needs the template instead:
+// /users/:id/settings.
+//
+// Normalization is applied once at ingestion. Per-project templates are
+// checked first; segments that no template covers fall back to heuristics
+// for numeric IDs, UUIDs, hashes and opaque tokens.
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Placeholders substituted for dynamic segments by the heuristics.
+const (
+	PlaceholderID    = ":id"
+	PlaceholderUUID  = ":uuid"
+	PlaceholderHash  = ":hash"
+	PlaceholderToken = ":token"
+)
+
+// AllProjects is the project key for templates that apply to every project.
+const AllProjects = "*"
+
+var (
+	numericSegment = regexp.MustCompile(`^\d+$`)
+	uuidSegment    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hashSegment    = regexp.MustCompile(`^[0-9a-fA-F]{12,}$`)
+	tokenSegment   = regexp.MustCompile(`^[A-Za-z0-9_\-]{20,}$`)
+	hasDigit       = regexp.MustCompile(`\d`)
+)
+
+// Template is a parsed route template such as /users/:id/settings.
+// Segments starting with ':' match any single segment; "*" matches any
+// single segment; a trailing "**" matches the rest of the path.
+type Template struct {
+	Raw      string
+	segments []string
+}
+
+// ParseTemplate parses and validates a route template.
+func ParseTemplate(raw string) (Template, error) {
+	if !strings.HasPrefix(raw, "/") {
+		return Template{}, fmt.Errorf("route template %q must start with /", raw)
+	}
+	segments := splitPath(raw)
+	for i, seg := range segments {
+		if seg == "**" && i != len(segments)-1 {
+			return Template{}, fmt.Errorf("route template %q: ** is only allowed as the last segment", raw)
+		}
+		if seg == ":" {
+			return Template{}, fmt.Errorf("route template %q: empty parameter name", raw)
+		}
+	}
+	return Template{Raw: raw, segments: segments}, nil
+}
+
+// Match reports whether the path segments match the template.
+func (t Template) Match(segments []string) bool {
+	for i, seg := range t.segments {
+		if seg == "**" {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if seg == "*" || strings.HasPrefix(seg, ":") {
+			continue
+		}
+		if seg != segments[i] {
+			return false
+		}
+	}
+	return len(segments) == len(t.segments)
+}
+
+// Normalizer rewrites raw routes into templates. It is safe for concurrent use.
+type Normalizer struct {
+	mu        sync.RWMutex
+	templates map[string][]Template // projectID (or AllProjects) → templates
+}
+
+// NewNormalizer creates a normalizer with heuristics only.
+func NewNormalizer() *Normalizer {
+	return &Normalizer{templates: make(map[string][]Template)}
+}
+
+// AddTemplate registers a template for a project. Use AllProjects to apply
+// it to every project. Templates are matched in registration order, with
+// project-specific templates taking precedence over global ones.
+func (n *Normalizer) AddTemplate(projectID, raw string) error {
+	tmpl, err := ParseTemplate(raw)
+	if err != nil {
+		return err
+	}
+	if projectID == "" {
+		projectID = AllProjects
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.templates[projectID] = append(n.templates[projectID], tmpl)
+	return nil
+}
+
+// LoadTemplatesFile loads templates from a JSON file mapping project IDs
+// (or "*") to template lists:
+//
+//	{"*": ["/users/:id/settings"], "shop": ["/p/:slug"]}
+func (n *Normalizer) LoadTemplatesFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read route templates: %w", err)
+	}
+	var byProject map[string][]string
+	if err := json.Unmarshal(data, &byProject); err != nil {
+		return fmt.Errorf("parse route templates: %w", err)
+	}
+	for projectID, templates := range byProject {
+		for _, raw := range templates {
+			if err := n.AddTemplate(projectID, raw); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Normalize returns the template for a raw route. Query strings, fragments,
+// scheme/host and trailing slashes are stripped before matching.
+func (n *Normalizer) Normalize(projectID, raw string) string {
+	path := cleanPath(raw)
+	if path == "" {
+		return raw
+	}
+	segments := splitPath(path)
+
+	n.mu.RLock()
+	projectTemplates := n.templates[projectID]
+	globalTemplates := n.templates[AllProjects]
+	n.mu.RUnlock()
+
+	for _, tmpl := range projectTemplates {
+		if tmpl.Match(segments) {
+			return tmpl.Raw
+		}
+	}
+	for _, tmpl := range globalTemplates {
+		if tmpl.Match(segments) {
+			return tmpl.Raw
+		}
+	}
+
+	for i, seg := range segments {
+		segments[i] = normalizeSegment(seg)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// normalizeSegment replaces a dynamic-looking path segment with a placeholder.
+func normalizeSegment(seg string) string {
+	switch {
+	case numericSegment.MatchString(seg):
+		return PlaceholderID
+	case uuidSegment.MatchString(seg):
+		return PlaceholderUUID
+	case hashSegment.MatchString(seg) && hasDigit.MatchString(seg):
+		return PlaceholderHash
+	case tokenSegment.MatchString(seg) && hasDigit.MatchString(seg):
+		return PlaceholderToken
+	default:
+		return seg
+	}
+}
+
+// cleanPath strips scheme, host, query and fragment and collapses slashes.
+func cleanPath(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	if strings.HasPrefix(raw, "http://") || strings.HasPrefix(raw, "https://") {
+		if u, err := url.Parse(raw); err == nil {
+			raw = u.Path
+		}
+	}
+	if i := strings.IndexAny(raw, "?#"); i >= 0 {
+		raw = raw[:i]
+	}
+	if !strings.HasPrefix(raw, "/") {
+		raw = "/" + raw
+	}
+	return raw
+}
+
+// splitPath splits a path into non-empty segments.
+func splitPath(path string) []string {
+	parts := strings.Split(path, "/")
+	segments := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p != "" {
+			segments = append(segments, p)
+		}
+	}
+	return segments
+}
+
+// SplitFailurePoint splits a "route:component:action" failure point into its
+// parts. Route templates contain ":param" segments (and the root route may be
+// followed directly by a colon), so the component and action are taken from
+// the last two colons.
+func SplitFailurePoint(failurePoint string) []string {
+	actionAt := strings.LastIndexByte(failurePoint, ':')
+	if actionAt < 0 {
+		return []string{failurePoint}
+	}
+	componentAt := strings.LastIndexByte(failurePoint[:actionAt], ':')
+	if componentAt < 0 {
+		return []string{failurePoint[:actionAt], failurePoint[actionAt+1:]}
+	}
+	return []string{failurePoint[:componentAt], failurePoint[componentAt+1 : actionAt], failurePoint[actionAt+1:]}
+}
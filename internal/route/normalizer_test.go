@@ -0,0 +1,106 @@
+package route
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalize_Heuristics(t *testing.T) {
+	n := NewNormalizer()
+
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"root", "/", "/"},
+		{"static", "/checkout/payment", "/checkout/payment"},
+		{"numeric_id", "/users/8812/settings", "/users/:id/settings"},
+		{"uuid", "/orders/3f2b8c1e-9a4d-4e6f-8b2a-1c3d5e7f9a0b", "/orders/:uuid"},
+		{"hash", "/commits/9fceb02d0ae598e95dc970b74767f19372d61af8", "/commits/:hash"},
+		{"token", "/invite/aB3dE5fG7hJ9kL1mN3pQ5r", "/invite/:token"},
+		{"hex_word_kept", "/deadbeefcafe", "/deadbeefcafe"},
+		{"query_and_fragment", "/users/42?tab=profile#top", "/users/:id"},
+		{"trailing_slash", "/users/42/", "/users/:id"},
+		{"full_url", "https://shop.example.com/p/123", "/p/:id"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := n.Normalize("proj-1", tt.raw); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize_ProjectTemplatesTakePrecedence(t *testing.T) {
+	n := NewNormalizer()
+	if err := n.AddTemplate(AllProjects, "/p/:productId"); err != nil {
+		t.Fatalf("AddTemplate: %v", err)
+	}
+	if err := n.AddTemplate("shop", "/p/:slug"); err != nil {
+		t.Fatalf("AddTemplate: %v", err)
+	}
+
+	if got := n.Normalize("shop", "/p/red-shoes"); got != "/p/:slug" {
+		t.Errorf("project template: got %q, want /p/:slug", got)
+	}
+	if got := n.Normalize("other", "/p/red-shoes"); got != "/p/:productId" {
+		t.Errorf("global template: got %q, want /p/:productId", got)
+	}
+}
+
+func TestParseTemplate_Invalid(t *testing.T) {
+	for _, raw := range []string{"users/:id", "/a/**/b", "/a/:"} {
+		if _, err := ParseTemplate(raw); err == nil {
+			t.Errorf("ParseTemplate(%q) expected error", raw)
+		}
+	}
+}
+
+func TestLoadTemplatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.json")
+	if err := os.WriteFile(path, []byte(`{"*": ["/docs/**"], "shop": ["/cart/:cartId"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	n := NewNormalizer()
+	if err := n.LoadTemplatesFile(path); err != nil {
+		t.Fatalf("LoadTemplatesFile: %v", err)
+	}
+	if got := n.Normalize("any", "/docs/guides/42/intro"); got != "/docs/**" {
+		t.Errorf("got %q, want /docs/**", got)
+	}
+	if got := n.Normalize("shop", "/cart/abc"); got != "/cart/:cartId" {
+		t.Errorf("got %q, want /cart/:cartId", got)
+	}
+}
+
+func TestSplitFailurePoint(t *testing.T) {
+	tests := []struct {
+		failurePoint string
+		want         []string
+	}{
+		{"/checkout:submit:form_submit", []string{"/checkout", "submit", "form_submit"}},
+		{"/users/:id/settings:save:rage", []string{"/users/:id/settings", "save", "rage"}},
+		{"/:unknown:rage_click", []string{"/", "unknown", "rage_click"}},
+		{"/cart/:cartId:unknown:blocked", []string{"/cart/:cartId", "unknown", "blocked"}},
+		{"/checkout", []string{"/checkout"}},
+	}
+	for _, tt := range tests {
+		got := SplitFailurePoint(tt.failurePoint)
+		if len(got) != len(tt.want) {
+			t.Errorf("SplitFailurePoint(%q) = %q, want %q", tt.failurePoint, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("SplitFailurePoint(%q) = %q, want %q", tt.failurePoint, got, tt.want)
+				break
+			}
+		}
+	}
+}
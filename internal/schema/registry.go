@@ -0,0 +1,222 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/your-org/frustration-engine/internal/validation"
+)
+
+// JSON Schema dialect of the published schemas.
+const dialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Metadata field kinds.
+const (
+	kindString = "string"
+	kindNumber = "number"
+	kindObject = "object"
+)
+
+// field is a documented metadata field.
+type field struct {
+	name        string
+	kind        string
+	description string
+}
+
+// Event type families sharing metadata fields.
+var (
+	networkTypes     = set("network", "network_error", "network_success", "slow_response")
+	errorTypes       = set("error", "unhandled_rejection", "validation_error", "network_error")
+	performanceTypes = set("performance", "long_task", "loading")
+	inputTypes       = set("input", "input_focus", "input_blur", "input_paste", "input_invalid")
+)
+
+// commonFields may appear in the metadata of any event type.
+var commonFields = []field{
+	{"userAgent", kindString, "client user agent, when not sent as a header"},
+	{"userId", kindString, "application user ID"},
+	{"client", kindObject, "client context set by the server at ingest; client values are replaced"},
+}
+
+// typeFields are the version 2 metadata fields by event type.
+var typeFields = map[string][]field{
+	"click":      {{"clientX", kindNumber, "pointer x in CSS pixels"}, {"clientY", kindNumber, "pointer y in CSS pixels"}},
+	"scroll":     {{"scrollY", kindNumber, "vertical scroll offset"}, {"scrollDelta", kindNumber, "scroll distance since the last event"}},
+	"form":       {{"fieldCount", kindNumber, "number of form controls"}},
+	"navigation": {{"from", kindString, "previous route"}, {"to", kindString, "new route"}},
+	"error": {
+		{"filename", kindString, "script URL"},
+		{"lineno", kindNumber, "line number"},
+		{"colno", kindNumber, "column number"},
+		{"type", kindString, "error class, e.g. TypeError"},
+	},
+	"input":         {{"editType", kindString, "InputEvent.inputType"}, {"valueLength", kindNumber, "length of the value (never the value)"}},
+	"input_paste":   {{"pastedLength", kindNumber, "length of the pasted text"}},
+	"input_invalid": {{"valueLength", kindNumber, "length of the value"}},
+}
+
+func init() {
+	typeFields["form_submit"] = typeFields["form"]
+	typeFields["route_change"] = typeFields["navigation"]
+	for eventType := range inputTypes {
+		typeFields[eventType] = append(typeFields[eventType], field{"inputType", kindString, "input element type"})
+	}
+	for eventType := range networkTypes {
+		typeFields[eventType] = append(typeFields[eventType],
+			field{"url", kindString, "request URL"},
+			field{"method", kindString, "HTTP method"},
+			field{"status", kindNumber, "HTTP status (0 or absent for network failures)"})
+	}
+	for eventType := range errorTypes {
+		typeFields[eventType] = append(typeFields[eventType], field{"message", kindString, "error message"})
+	}
+	for eventType := range union(networkTypes, performanceTypes) {
+		typeFields[eventType] = append(typeFields[eventType], field{"duration", kindNumber, "duration in milliseconds"})
+	}
+}
+
+// alias is a version 1 metadata name that version 2 renames.
+type alias struct {
+	alias, canonical string
+	eventTypes       map[string]bool
+}
+
+var v1Aliases = []alias{
+	{"statusCode", "status", networkTypes},
+	{"httpStatus", "status", networkTypes},
+	{"status_code", "status", networkTypes},
+	{"error", "message", errorTypes},
+	{"errorMessage", "message", errorTypes},
+	{"error_message", "message", errorTypes},
+	{"durationMs", "duration", union(networkTypes, performanceTypes)},
+}
+
+// metadataFields returns the documented metadata fields of an event type in
+// a version, common fields last. Version 1 also lists the aliases, with the
+// kind of their canonical field.
+func metadataFields(version int, eventType string) []field {
+	fields := append([]field{}, typeFields[eventType]...)
+	if version == 1 {
+		for _, a := range v1Aliases {
+			if !a.eventTypes[eventType] {
+				continue
+			}
+			for _, f := range typeFields[eventType] {
+				if f.name == a.canonical {
+					fields = append(fields, field{a.alias, f.kind, "deprecated alias of " + a.canonical})
+				}
+			}
+		}
+	}
+	return append(fields, commonFields...)
+}
+
+// Lookup returns the JSON Schema of an event type in a schema version.
+func Lookup(version int, eventType string) (map[string]interface{}, bool) {
+	if version < 1 || version > Current || !isEventType(eventType) {
+		return nil, false
+	}
+
+	metadata := make(map[string]interface{})
+	for _, f := range metadataFields(version, eventType) {
+		prop := map[string]interface{}{"type": f.kind, "description": f.description}
+		if version == 1 && f.kind == kindNumber {
+			// Version 1 SDKs sent some numbers as strings
+			prop["type"] = []string{kindNumber, kindString}
+		}
+		if version == 1 && isAlias(f.name, eventType) {
+			prop["deprecated"] = true
+		}
+		metadata[f.name] = prop
+	}
+
+	str := map[string]interface{}{"type": "string", "minLength": 1}
+	properties := map[string]interface{}{
+		"eventType": map[string]interface{}{"const": eventType},
+		"timestamp": map[string]interface{}{"type": "string", "description": "RFC 3339 time or unix milliseconds"},
+		"sessionId": str,
+		"route":     str,
+		"target": map[string]interface{}{
+			"type":     "object",
+			"required": []string{"type"},
+			"properties": map[string]interface{}{
+				"type":     str,
+				"id":       map[string]interface{}{"type": "string"},
+				"selector": map[string]interface{}{"type": "string"},
+				"tagName":  map[string]interface{}{"type": "string"},
+			},
+		},
+		"metadata": map[string]interface{}{
+			"type":                 "object",
+			"properties":           metadata,
+			"additionalProperties": true,
+		},
+		"environment":    map[string]interface{}{"type": "string"},
+		"idempotencyKey": map[string]interface{}{"type": "string"},
+	}
+	required := []string{"eventType", "timestamp", "sessionId", "route", "target"}
+	if version == 1 {
+		properties["schemaVersion"] = map[string]interface{}{"enum": []int{0, 1}, "description": "absent in version 1 payloads"}
+	} else {
+		properties["schemaVersion"] = map[string]interface{}{"const": version}
+		required = append(required, "schemaVersion")
+	}
+
+	return map[string]interface{}{
+		"$schema":    dialect,
+		"$id":        fmt.Sprintf("urn:hawkeye:event:v%d:%s", version, eventType),
+		"title":      fmt.Sprintf("%s event, schema version %d", eventType, version),
+		"type":       "object",
+		"required":   required,
+		"properties": properties,
+	}, true
+}
+
+// Bundle returns the schemas of every event type in a version, by type.
+func Bundle(version int) (map[string]interface{}, bool) {
+	if version < 1 || version > Current {
+		return nil, false
+	}
+	schemas := make(map[string]interface{})
+	for _, eventType := range validation.EventTypes() {
+		schemas[eventType], _ = Lookup(version, eventType)
+	}
+	return schemas, true
+}
+
+func isEventType(eventType string) bool {
+	for _, t := range validation.EventTypes() {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func isAlias(name, eventType string) bool {
+	for _, a := range v1Aliases {
+		if a.alias == name && a.eventTypes[eventType] {
+			return true
+		}
+	}
+	return false
+}
+
+func set(items ...string) map[string]bool {
+	s := make(map[string]bool, len(items))
+	for _, item := range items {
+		s[item] = true
+	}
+	return s
+}
+
+func union(a, b map[string]bool) map[string]bool {
+	u := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		u[k] = true
+	}
+	for k := range b {
+		u[k] = true
+	}
+	return u
+}
@@ -0,0 +1,97 @@
+// Package schema versions the event format shared by the SDK and the server.
+//
+// Every event carries a schema version (types.Event.SchemaVersion; 0 means an
+// unversioned payload from an SDK that predates versioning, i.e. version 1).
+// The registry describes each event type in each version as a JSON Schema,
+// published under GET /v1/schema. Upcasters convert events from older SDKs
+// to the current version at ingest, so detectors only see the current shape.
+//
+// Version history:
+//   - 1: metadata names were ad hoc: an HTTP status could come as status,
+//     statusCode, httpStatus or status_code (possibly a string), an error
+//     message as message, error, errorMessage or error_message, and a
+//     duration as duration or durationMs.
+//   - 2: metadata uses the canonical names (status, message, duration) with
+//     numbers as JSON numbers, and schemaVersion is set.
+//
+// To add a version, bump types.CurrentSchemaVersion, add its metadata fields
+// to the registry and register an upcaster from the previous version.
+package schema
+
+import (
+	"strconv"
+
+	"github.com/your-org/frustration-engine/internal/metrics"
+	"github.com/your-org/frustration-engine/internal/types"
+	pkgtypes "github.com/your-org/frustration-engine/pkg/types"
+)
+
+// Current is the latest schema version.
+const Current = pkgtypes.CurrentSchemaVersion
+
+// upcasters convert an event from the version they are registered under to
+// the next one.
+var upcasters = map[int]func(*types.Event){
+	1: upcastV1,
+}
+
+// Versions returns the supported schema versions, oldest first.
+func Versions() []int {
+	versions := make([]int, Current)
+	for i := range versions {
+		versions[i] = i + 1
+	}
+	return versions
+}
+
+// Upcast converts an event to the current schema version and reports whether
+// it changed. Events of an unsupported version are left for validation to
+// reject.
+func Upcast(event *types.Event) bool {
+	version := event.SchemaVersion
+	if version == 0 {
+		version = 1
+	}
+	if version < 1 || version >= Current {
+		return false
+	}
+
+	from := version
+	for ; version < Current; version++ {
+		upcasters[version](event)
+	}
+	event.SchemaVersion = Current
+	metrics.EventsUpcast.WithLabelValues(strconv.Itoa(from)).Inc()
+	return true
+}
+
+// upcastV1 renames version 1 metadata aliases to their canonical names and
+// converts numeric strings to numbers.
+func upcastV1(event *types.Event) {
+	if event.Metadata == nil {
+		return
+	}
+	for _, a := range v1Aliases {
+		if !a.eventTypes[event.EventType] {
+			continue
+		}
+		v, ok := event.Metadata[a.alias]
+		if !ok {
+			continue
+		}
+		delete(event.Metadata, a.alias)
+		if _, exists := event.Metadata[a.canonical]; !exists {
+			event.Metadata[a.canonical] = v
+		}
+	}
+	for _, field := range metadataFields(2, event.EventType) {
+		if field.kind != kindNumber {
+			continue
+		}
+		if s, ok := event.Metadata[field.name].(string); ok {
+			if n, err := strconv.ParseFloat(s, 64); err == nil {
+				event.Metadata[field.name] = n
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+func TestUpcastV1(t *testing.T) {
+	event := types.Event{
+		EventType: "network_error",
+		Metadata: map[string]interface{}{
+			"statusCode":   "503",
+			"errorMessage": "upstream unavailable",
+			"durationMs":   1200.0,
+			"url":          "/api/cart",
+		},
+	}
+	if !Upcast(&event) {
+		t.Fatal("Upcast() = false for an unversioned event")
+	}
+	if event.SchemaVersion != Current {
+		t.Errorf("SchemaVersion = %d, want %d", event.SchemaVersion, Current)
+	}
+	want := map[string]interface{}{
+		"status":   503.0,
+		"message":  "upstream unavailable",
+		"duration": 1200.0,
+		"url":      "/api/cart",
+	}
+	if len(event.Metadata) != len(want) {
+		t.Errorf("Metadata = %v, want %v", event.Metadata, want)
+	}
+	for k, v := range want {
+		if event.Metadata[k] != v {
+			t.Errorf("Metadata[%s] = %v, want %v", k, event.Metadata[k], v)
+		}
+	}
+}
+
+func TestUpcastKeepsCanonicalValues(t *testing.T) {
+	event := types.Event{
+		EventType:     "error",
+		SchemaVersion: 1,
+		Metadata:      map[string]interface{}{"message": "boom", "error": "stale", "statusCode": "500"},
+	}
+	Upcast(&event)
+	if event.Metadata["message"] != "boom" || event.Metadata["error"] != nil {
+		t.Errorf("Metadata = %v, want the canonical message kept and the alias dropped", event.Metadata)
+	}
+	// statusCode is not an alias for error events
+	if event.Metadata["statusCode"] != "500" {
+		t.Errorf("Metadata[statusCode] = %v, want it untouched", event.Metadata["statusCode"])
+	}
+}
+
+func TestUpcastLeavesOtherVersions(t *testing.T) {
+	for _, version := range []int{Current, Current + 1, -1} {
+		event := types.Event{EventType: "network", SchemaVersion: version, Metadata: map[string]interface{}{"statusCode": "500"}}
+		if Upcast(&event) || event.SchemaVersion != version || event.Metadata["statusCode"] != "500" {
+			t.Errorf("Upcast changed a version %d event: %+v", version, event)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	doc, ok := Lookup(Current, "network_error")
+	if !ok {
+		t.Fatal("Lookup() found no network_error schema")
+	}
+	if doc["$id"] != "urn:hawkeye:event:v2:network_error" {
+		t.Errorf("$id = %v", doc["$id"])
+	}
+	metadata := doc["properties"].(map[string]interface{})["metadata"].(map[string]interface{})["properties"].(map[string]interface{})
+	for _, name := range []string{"status", "message", "duration", "url", "client"} {
+		if _, ok := metadata[name]; !ok {
+			t.Errorf("v2 network_error metadata has no %s", name)
+		}
+	}
+	if _, ok := metadata["statusCode"]; ok {
+		t.Error("v2 network_error metadata lists the v1 alias statusCode")
+	}
+
+	v1, _ := Lookup(1, "network_error")
+	alias := v1["properties"].(map[string]interface{})["metadata"].(map[string]interface{})["properties"].(map[string]interface{})["statusCode"]
+	if alias == nil || alias.(map[string]interface{})["deprecated"] != true {
+		t.Errorf("v1 statusCode = %v, want a deprecated property", alias)
+	}
+
+	for _, tt := range []struct {
+		version   int
+		eventType string
+	}{{0, "click"}, {Current + 1, "click"}, {Current, "hover"}} {
+		if _, ok := Lookup(tt.version, tt.eventType); ok {
+			t.Errorf("Lookup(%d, %q) found a schema", tt.version, tt.eventType)
+		}
+	}
+}
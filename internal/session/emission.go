@@ -38,6 +38,18 @@ func (s *SessionState) ToSession() *types.Session {
 		}
 	}
 
+	metadata := map[string]interface{}{
+		"event_count": len(events),
+	}
+	// Client context enriched at ingest; the latest event's wins (e.g. after
+	// an app upgrade mid-session)
+	for i := len(events) - 1; i >= 0; i-- {
+		if client, ok := events[i].Metadata["client"]; ok {
+			metadata["client"] = client
+			break
+		}
+	}
+
 	return &types.Session{
 		SessionID:        s.SessionID,
 		ProjectID:        s.ProjectID,
@@ -47,9 +59,7 @@ func (s *SessionState) ToSession() *types.Session {
 		EndTime:          endTime,
 		LastActivity:     s.LastActivity,
 		RouteTransitions: s.RouteTransitions,
-		Metadata: map[string]interface{}{
-			"event_count": len(events),
-		},
+		Metadata:         metadata,
 	}
 }
 
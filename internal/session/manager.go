@@ -9,6 +9,8 @@ package session
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"sync"
 	"time"
@@ -57,6 +59,27 @@ func (m *Manager) Stop() {
 	m.wg.Wait()
 }
 
+// ErrManagerStopped is returned for events that arrive after Stop
+var ErrManagerStopped = errors.New("session manager stopped")
+
+// TryAddEvents is AddEvents for callers that must know when events were not
+// taken: after Stop, or when adding them panicked
+func (m *Manager) TryAddEvents(projectID, sessionID string, events []types.Event) (err error) {
+	select {
+	case <-m.stopChan:
+		return ErrManagerStopped
+	default:
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("add events to session %s: %v", sessionID, r)
+		}
+	}()
+	m.AddEvents(projectID, sessionID, events)
+	return nil
+}
+
 // AddEvents adds events to session with comprehensive edge case handling
 func (m *Manager) AddEvents(projectID, sessionID string, events []types.Event) {
 	if sessionID == "" {
@@ -143,6 +166,12 @@ func (m *Manager) GetEmissionChannel() <-chan *types.Session {
 	return m.emissionChan
 }
 
+// Backlog returns how full the emission channel is, from 0 to 1. Producers
+// can slow down when the engine falls behind
+func (m *Manager) Backlog() float64 {
+	return float64(len(m.emissionChan)) / float64(cap(m.emissionChan))
+}
+
 // Get gets session by ID (for testing)
 func (m *Manager) Get(sessionID string) (*SessionState, bool) {
 	return m.storage.Get(sessionID)
@@ -27,6 +27,15 @@ type EventStore interface {
 // IncidentStore persists and queries detected incidents.
 type IncidentStore interface {
 	Save(ctx context.Context, incident pkgtypes.Incident) error
+	Get(ctx context.Context, incidentID string) (pkgtypes.Incident, bool, error)
 	Query(ctx context.Context, filter pkgtypes.Filter) ([]pkgtypes.Incident, error)
 	Close() error
 }
+
+// IssueStore persists and queries cross-session issues.
+type IssueStore interface {
+	Save(ctx context.Context, issue pkgtypes.Issue) error
+	Get(ctx context.Context, issueID string) (pkgtypes.Issue, bool, error)
+	Query(ctx context.Context, query pkgtypes.IssueQuery) ([]pkgtypes.Issue, error)
+	Close() error
+}
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -49,6 +50,18 @@ func (s *IncidentStore) Save(ctx context.Context, incident types.Incident) error
 	return nil
 }
 
+// Get returns an incident by ID.
+func (s *IncidentStore) Get(ctx context.Context, incidentID string) (types.Incident, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, inc := range s.incidents {
+		if inc.IncidentID == incidentID {
+			return inc, true, nil
+		}
+	}
+	return types.Incident{}, false, nil
+}
+
 // Query returns incidents matching the given filter.
 func (s *IncidentStore) Query(ctx context.Context, filter types.Filter) ([]types.Incident, error) {
 	s.mu.RLock()
@@ -68,6 +81,15 @@ func (s *IncidentStore) Query(ctx context.Context, filter types.Filter) ([]types
 		if filter.Suppressed != nil && inc.Suppressed != *filter.Suppressed {
 			continue
 		}
+		if filter.Exported != nil && (inc.ExternalTicketID != "") != *filter.Exported {
+			continue
+		}
+		if filter.ExportFailed != nil && inc.ExportFailed != *filter.ExportFailed {
+			continue
+		}
+		if !matchesClient(inc.Client, filter) {
+			continue
+		}
 		result = append(result, inc)
 	}
 
@@ -84,5 +106,27 @@ func (s *IncidentStore) Query(ctx context.Context, filter types.Filter) ([]types
 	return result, nil
 }
 
+// matchesClient applies the client context filters. Incidents without a
+// client context only match when no client filter is set.
+func matchesClient(c *types.ClientContext, filter types.Filter) bool {
+	var client types.ClientContext
+	if c != nil {
+		client = *c
+	}
+	for _, f := range []struct{ want, got string }{
+		{filter.Browser, client.Browser},
+		{filter.OS, client.OS},
+		{filter.DeviceClass, client.DeviceClass},
+		{filter.BotLikelihood, client.BotLikelihood},
+		{filter.Release, client.Release},
+		{filter.Country, client.Country},
+	} {
+		if f.want != "" && !strings.EqualFold(f.want, f.got) {
+			return false
+		}
+	}
+	return true
+}
+
 // Close is a no-op.
 func (s *IncidentStore) Close() error { return nil }
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/your-org/frustration-engine/pkg/types"
+)
+
+// IssueStore stores issues in memory for development and testing.
+type IssueStore struct {
+	mu     sync.RWMutex
+	issues map[string]types.Issue
+}
+
+// NewIssueStore creates a new in-memory issue store.
+func NewIssueStore() *IssueStore {
+	log.Println("[storage/memory] initialised in-memory issue store")
+	return &IssueStore{issues: make(map[string]types.Issue)}
+}
+
+// Save inserts or replaces an issue.
+func (s *IssueStore) Save(ctx context.Context, issue types.Issue) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issues[issue.IssueID] = issue
+	return nil
+}
+
+// Get returns an issue by ID.
+func (s *IssueStore) Get(ctx context.Context, issueID string) (types.Issue, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	issue, ok := s.issues[issueID]
+	return issue, ok, nil
+}
+
+// Query returns issues matching the query, most recently seen first.
+func (s *IssueStore) Query(ctx context.Context, query types.IssueQuery) ([]types.Issue, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []types.Issue
+	for _, issue := range s.issues {
+		if query.ProjectID != "" && issue.ProjectID != query.ProjectID {
+			continue
+		}
+		if query.Status != "" && issue.Status != query.Status {
+			continue
+		}
+		if query.Trend != "" && issue.Trend != query.Trend {
+			continue
+		}
+		if query.Fingerprint != "" && issue.Fingerprint != query.Fingerprint {
+			continue
+		}
+		result = append(result, issue)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LastSeen.After(result[j].LastSeen)
+	})
+
+	// Apply limit/offset
+	if query.Offset > 0 && query.Offset < len(result) {
+		result = result[query.Offset:]
+	} else if query.Offset >= len(result) {
+		result = nil
+	}
+	if query.Limit > 0 && query.Limit < len(result) {
+		result = result[:query.Limit]
+	}
+
+	return result, nil
+}
+
+// Close is a no-op.
+func (s *IssueStore) Close() error { return nil }
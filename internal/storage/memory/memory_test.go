@@ -123,3 +123,25 @@ func TestIncidentStore_QueryFilter(t *testing.T) {
 		t.Errorf("expected 1 result with limit=1, got %d", len(results))
 	}
 }
+
+func TestIncidentStore_QueryClientFilter(t *testing.T) {
+	store := NewIncidentStore()
+	ctx := context.Background()
+
+	store.Save(ctx, pkgtypes.Incident{IncidentID: "a", Client: &pkgtypes.ClientContext{Browser: "Chrome", Country: "GB", BotLikelihood: "low"}})
+	store.Save(ctx, pkgtypes.Incident{IncidentID: "b", Client: &pkgtypes.ClientContext{Browser: "Safari", Country: "GB", BotLikelihood: "low"}})
+	store.Save(ctx, pkgtypes.Incident{IncidentID: "c"})
+
+	results, _ := store.Query(ctx, pkgtypes.Filter{Country: "gb", BotLikelihood: "low"})
+	if len(results) != 2 {
+		t.Errorf("expected 2 results for GB, got %d", len(results))
+	}
+	results, _ = store.Query(ctx, pkgtypes.Filter{Browser: "Chrome"})
+	if len(results) != 1 || results[0].IncidentID != "a" {
+		t.Errorf("expected incident a for Chrome, got %v", results)
+	}
+	results, _ = store.Query(ctx, pkgtypes.Filter{})
+	if len(results) != 3 {
+		t.Errorf("expected 3 results without filters, got %d", len(results))
+	}
+}
@@ -11,12 +11,13 @@ const (
 	StatusDraft     = "draft"
 	StatusConfirmed = "confirmed"
 	StatusSuppressed = "suppressed"
+	StatusResolved  = "resolved"
 )
 
 // ValidateStatus validates incident status
 func ValidateStatus(status string) bool {
 	switch status {
-	case StatusDraft, StatusConfirmed, StatusSuppressed:
+	case StatusDraft, StatusConfirmed, StatusSuppressed, StatusResolved:
 		return true
 	default:
 		return false
@@ -0,0 +1,82 @@
+/**
+ * Confidence Score Tests
+ *
+ * Responsibility: Numeric confidence persisted on emitted incidents
+ */
+
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/ufse/correlation"
+	"github.com/your-org/frustration-engine/internal/ufse/emission"
+	"github.com/your-org/frustration-engine/internal/ufse/scoring"
+	"github.com/your-org/frustration-engine/internal/ufse/signals"
+)
+
+func confidenceGroup(spacing time.Duration, signalTypes ...string) correlation.CorrelatedGroup {
+	base := time.Now()
+	group := correlation.CorrelatedGroup{Route: "/checkout", HasSystemFeedback: true, TimeWindow: 30 * time.Second}
+	for i, t := range signalTypes {
+		group.Signals = append(group.Signals, signals.QualifiedSignal{
+			Type:      t,
+			Timestamp: base.Add(time.Duration(i) * spacing),
+			Route:     "/checkout",
+			Details:   map[string]interface{}{"targetID": "pay-btn", "strength": "high"},
+		})
+	}
+	return group
+}
+
+func TestConfidenceScore_StrongGroupIsExportable(t *testing.T) {
+	group := confidenceGroup(2*time.Second, "rage", "blocked", "rage_bait", "rage")
+
+	b := scoring.CalculateConfidenceScore(group, types.Session{SessionID: "s1"})
+	if b.Score < 70 {
+		t.Errorf("Score = %.1f, want >= 70 (export threshold) for a strong group; breakdown %+v", b.Score, b)
+	}
+	if b.FalseAlarm != 0 {
+		t.Errorf("FalseAlarm = %.2f, want 0", b.FalseAlarm)
+	}
+}
+
+func TestConfidenceScore_DecayLowersDrawnOutGroups(t *testing.T) {
+	tight := scoring.CalculateConfidenceScore(confidenceGroup(2*time.Second, "rage", "blocked"), types.Session{})
+	drawnOut := scoring.CalculateConfidenceScore(confidenceGroup(3*time.Minute, "rage", "blocked"), types.Session{})
+
+	if drawnOut.Score >= tight.Score {
+		t.Errorf("drawn-out score %.1f should be below tight score %.1f", drawnOut.Score, tight.Score)
+	}
+}
+
+func TestConfidenceScore_FalseAlarmsReduceScore(t *testing.T) {
+	group := confidenceGroup(2*time.Second, "rage", "blocked")
+	session := types.Session{
+		SessionID: "s1",
+		Events: []types.Event{{
+			EventType: "click",
+			Route:     "/checkout",
+			Metadata:  map[string]interface{}{"userAgent": "Googlebot/2.1"},
+		}},
+	}
+
+	b := scoring.CalculateConfidenceScore(group, session)
+	if b.FalseAlarm != 1 || b.Score != 0 {
+		t.Errorf("bot session: FalseAlarm = %.2f, Score = %.1f, want 1 and 0", b.FalseAlarm, b.Score)
+	}
+}
+
+func TestEmitIncident_PersistsConfidenceScore(t *testing.T) {
+	group := confidenceGroup(2*time.Second, "rage", "blocked")
+
+	incident, ok := emission.EmitIncident("s1", "p1", group, 60, 82.5, "Bug", "/checkout:pay-btn:click")
+	if !ok {
+		t.Fatal("expected incident to be emitted")
+	}
+	if incident.ConfidenceScore != 82.5 {
+		t.Errorf("ConfidenceScore = %.1f, want 82.5", incident.ConfidenceScore)
+	}
+}
@@ -0,0 +1,222 @@
+/**
+ * Input Struggle Detection Tests
+ *
+ * Responsibility: Field-level struggle detection on form inputs
+ */
+
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/ufse/signals"
+)
+
+func inputEvent(eventType string, ts time.Time, selector string, metadata map[string]interface{}) signals.ClassifiedEvent {
+	category := signals.CategoryInteraction
+	if eventType == "input_invalid" {
+		category = signals.CategorySystemFeedback
+	}
+	return signals.ClassifiedEvent{
+		Event: types.Event{
+			EventType: eventType,
+			SessionID: "input-session",
+			Timestamp: ts.Format(time.RFC3339),
+			Route:     "/signup",
+			Target:    types.EventTarget{Type: "input", Selector: selector},
+			Metadata:  metadata,
+		},
+		Category:  category,
+		Timestamp: ts,
+		Route:     "/signup",
+	}
+}
+
+func detectInputStruggle(classified []signals.ClassifiedEvent) []signals.CandidateSignal {
+	session := types.Session{SessionID: "input-session", ProjectID: "test-project"}
+	return signals.NewInputStruggleDetector().DetectInputStruggle(classified, session)
+}
+
+func TestInputStruggle_ClearAndRetype(t *testing.T) {
+	now := time.Now()
+	lengths := []float64{8, 0, 6, 0, 9}
+	classified := make([]signals.ClassifiedEvent, 0, len(lengths))
+	for i, l := range lengths {
+		classified = append(classified, inputEvent("input", now.Add(time.Duration(i)*time.Second), "#email", map[string]interface{}{"valueLength": l}))
+	}
+
+	candidates := detectInputStruggle(classified)
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 input struggle signal, got %d", len(candidates))
+	}
+	c := candidates[0]
+	if c.Type != "input_struggle" {
+		t.Errorf("Type = %q, want input_struggle", c.Type)
+	}
+	if c.Details["struggle_type"] != "clear_and_retype" {
+		t.Errorf("struggle_type = %v, want clear_and_retype", c.Details["struggle_type"])
+	}
+	if c.Details["field_selector"] != "#email" || c.Details["target_id"] != "#email" {
+		t.Errorf("expected field selector #email in details, got %v", c.Details)
+	}
+}
+
+func TestInputStruggle_RepeatedValidationErrors(t *testing.T) {
+	now := time.Now()
+	var classified []signals.ClassifiedEvent
+	for i := 0; i < 3; i++ {
+		ts := now.Add(time.Duration(i*3) * time.Second)
+		classified = append(classified,
+			inputEvent("input_blur", ts, "#card-number", map[string]interface{}{"valueLength": float64(15)}),
+			inputEvent("input_invalid", ts.Add(time.Second), "#card-number", nil),
+		)
+	}
+
+	candidates := detectInputStruggle(classified)
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 input struggle signal, got %d", len(candidates))
+	}
+	if candidates[0].Details["struggle_type"] != "repeated_validation_errors" {
+		t.Errorf("struggle_type = %v, want repeated_validation_errors", candidates[0].Details["struggle_type"])
+	}
+	if candidates[0].Details["validation_error_count"] != 3 {
+		t.Errorf("validation_error_count = %v, want 3", candidates[0].Details["validation_error_count"])
+	}
+}
+
+func TestInputStruggle_PasteThenDelete(t *testing.T) {
+	now := time.Now()
+	var classified []signals.ClassifiedEvent
+	for i := 0; i < 2; i++ {
+		ts := now.Add(time.Duration(i*5) * time.Second)
+		classified = append(classified,
+			inputEvent("input_paste", ts, "#iban", map[string]interface{}{"pastedLength": float64(22)}),
+			inputEvent("input", ts.Add(100*time.Millisecond), "#iban", map[string]interface{}{"valueLength": float64(22)}),
+			inputEvent("input", ts.Add(2*time.Second), "#iban", map[string]interface{}{"valueLength": float64(0)}),
+		)
+	}
+
+	candidates := detectInputStruggle(classified)
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 input struggle signal, got %d", len(candidates))
+	}
+	if candidates[0].Details["struggle_type"] != "paste_then_delete" {
+		t.Errorf("struggle_type = %v, want paste_then_delete", candidates[0].Details["struggle_type"])
+	}
+}
+
+func TestInputStruggle_NormalTypingNotDetected(t *testing.T) {
+	now := time.Now()
+	classified := []signals.ClassifiedEvent{
+		inputEvent("input_focus", now, "#name", nil),
+		inputEvent("input", now.Add(time.Second), "#name", map[string]interface{}{"valueLength": float64(3)}),
+		inputEvent("input", now.Add(2*time.Second), "#name", map[string]interface{}{"valueLength": float64(7)}),
+		inputEvent("input_blur", now.Add(3*time.Second), "#name", map[string]interface{}{"valueLength": float64(7)}),
+	}
+
+	if candidates := detectInputStruggle(classified); len(candidates) != 0 {
+		t.Errorf("expected no signals for normal typing, got %d", len(candidates))
+	}
+}
+
+func TestInputStruggle_DetailsHoldNoValues(t *testing.T) {
+	now := time.Now()
+	lengths := []float64{8, 0, 6, 0, 9}
+	var classified []signals.ClassifiedEvent
+	for i, l := range lengths {
+		classified = append(classified, inputEvent("input", now.Add(time.Duration(i)*time.Second), "#email", map[string]interface{}{"valueLength": l}))
+	}
+
+	for _, c := range detectInputStruggle(classified) {
+		for key, v := range c.Details {
+			switch v.(type) {
+			case int, float64:
+			case string:
+				if key != "struggle_type" && key != "target_id" && key != "field_selector" && key != "action_type" {
+					t.Errorf("unexpected string detail %q=%v", key, v)
+				}
+			default:
+				t.Errorf("unexpected detail type for %q: %T", key, v)
+			}
+		}
+	}
+}
+
+func TestInputStruggle_BackspacingNotDetected(t *testing.T) {
+	now := time.Now()
+	var lengths []float64
+	for l := 1; l <= 16; l++ {
+		lengths = append(lengths, float64(l)) // type the value
+	}
+	for l := 15; l >= 0; l-- {
+		lengths = append(lengths, float64(l)) // backspace it one character at a time
+	}
+	for l := 1; l <= 12; l++ {
+		lengths = append(lengths, float64(l)) // type it again
+	}
+	for l := 11; l >= 8; l-- {
+		lengths = append(lengths, float64(l)) // correct the last few characters
+	}
+
+	var classified []signals.ClassifiedEvent
+	for i, l := range lengths {
+		classified = append(classified, inputEvent("input", now.Add(time.Duration(i)*200*time.Millisecond), "#address", map[string]interface{}{"valueLength": l}))
+	}
+
+	if candidates := detectInputStruggle(classified); len(candidates) != 0 {
+		t.Errorf("expected no signals for backspacing, got %v", candidates[0].Details)
+	}
+}
+
+func TestInputStruggle_PasteDeleteOutsideWindowNotDetected(t *testing.T) {
+	now := time.Now()
+	var classified []signals.ClassifiedEvent
+	for i := 0; i < 2; i++ {
+		ts := now.Add(time.Duration(i*30) * time.Second)
+		classified = append(classified,
+			inputEvent("input_paste", ts, "#iban", map[string]interface{}{"pastedLength": float64(22)}),
+			inputEvent("input", ts.Add(100*time.Millisecond), "#iban", map[string]interface{}{"valueLength": float64(22)}),
+			inputEvent("input", ts.Add(15*time.Second), "#iban", map[string]interface{}{"valueLength": float64(2)}),
+		)
+	}
+
+	if candidates := detectInputStruggle(classified); len(candidates) != 0 {
+		t.Errorf("expected no signals when the delete is outside the window, got %v", candidates[0].Details)
+	}
+}
+
+func TestInputStruggle_FieldKeyedByID(t *testing.T) {
+	now := time.Now()
+	lengths := []float64{8, 0, 6, 0, 9}
+	var classified []signals.ClassifiedEvent
+	for i, l := range lengths {
+		event := inputEvent("input", now.Add(time.Duration(i)*time.Second), "", map[string]interface{}{"valueLength": l})
+		event.Event.Target.ID = "email"
+		classified = append(classified, event)
+	}
+
+	candidates := detectInputStruggle(classified)
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 input struggle signal, got %d", len(candidates))
+	}
+	if candidates[0].Details["field_selector"] != "#email" {
+		t.Errorf("field_selector = %v, want #email", candidates[0].Details["field_selector"])
+	}
+}
+
+func TestInputStruggle_UnnamedFieldsSkipped(t *testing.T) {
+	now := time.Now()
+	// Two unnamed fields, each cleared and retyped once: merged into one
+	// field they would look like two clear-and-retype cycles
+	lengths := []float64{8, 0, 6, 5, 0, 9}
+	var classified []signals.ClassifiedEvent
+	for i, l := range lengths {
+		classified = append(classified, inputEvent("input", now.Add(time.Duration(i)*time.Second), "", map[string]interface{}{"valueLength": l}))
+	}
+
+	if candidates := detectInputStruggle(classified); len(candidates) != 0 {
+		t.Errorf("expected no signals for fields without a selector or id, got %v", candidates[0].Details)
+	}
+}
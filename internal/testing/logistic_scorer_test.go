@@ -0,0 +1,108 @@
+/**
+ * Logistic Scorer Tests
+ *
+ * Responsibility: Calibrated scoring, model loading and coefficient fitting
+ */
+
+package testing
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/ufse/correlation"
+	"github.com/your-org/frustration-engine/internal/ufse/scoring"
+	"github.com/your-org/frustration-engine/internal/ufse/signals"
+)
+
+func scorerGroup(feedback bool, signalTypes ...string) correlation.CorrelatedGroup {
+	base := time.Now()
+	group := correlation.CorrelatedGroup{Route: "/checkout", HasSystemFeedback: feedback, TimeWindow: 30 * time.Second}
+	for i, t := range signalTypes {
+		group.Signals = append(group.Signals, signals.QualifiedSignal{
+			Type:      t,
+			Timestamp: base.Add(time.Duration(i) * 20 * time.Second),
+			Route:     "/checkout",
+			Strength:  0.7,
+		})
+	}
+	return group
+}
+
+func TestDeterministicScorer_MatchesCalculateScore(t *testing.T) {
+	group := scorerGroup(true, "rage", "blocked", "rage")
+	now := time.Now()
+
+	result := scoring.DeterministicScorer{}.Score(group, now, now)
+	if want := scoring.CalculateScore(group, now, now); result.Score != want {
+		t.Errorf("Score = %d, want %d", result.Score, want)
+	}
+	if len(result.Contributions) == 0 {
+		t.Error("expected a per-factor breakdown")
+	}
+	if result.Features[scoring.FeatureSignalCount] != 3 {
+		t.Errorf("signal_count = %v, want 3", result.Features[scoring.FeatureSignalCount])
+	}
+}
+
+func TestLogisticModel_ScoreAndContributions(t *testing.T) {
+	model := &scoring.LogisticModel{
+		Intercept: -2,
+		Coefficients: map[string]float64{
+			scoring.FeatureSignalCount:    0.5,
+			scoring.FeatureSystemFeedback: 2,
+		},
+	}
+
+	result := model.Score(scorerGroup(true, "rage", "blocked"), time.Now(), time.Now())
+	// logit = -2 + 0.5*2 + 2*1 = 1 → p ≈ 0.731
+	if result.Score != 73 {
+		t.Errorf("Score = %d, want 73", result.Score)
+	}
+	if result.Contributions[0].Feature != scoring.FeatureSystemFeedback {
+		t.Errorf("largest contribution = %s, want %s", result.Contributions[0].Feature, scoring.FeatureSystemFeedback)
+	}
+}
+
+func TestFitLogistic_SeparatesLabelsAndRoundTrips(t *testing.T) {
+	var samples []scoring.Sample
+	for i := 0; i < 20; i++ {
+		samples = append(samples,
+			scoring.Sample{Features: scoring.ExtractFeatures(scorerGroup(true, "rage", "blocked", "rage")), Label: true},
+			scoring.Sample{Features: scoring.ExtractFeatures(scorerGroup(false, "confusion", "confusion")), Label: false},
+		)
+	}
+
+	model, err := scoring.FitLogistic(samples, scoring.DefaultFitOptions())
+	if err != nil {
+		t.Fatalf("FitLogistic: %v", err)
+	}
+	if model.Report.Accuracy < 1 {
+		t.Errorf("Accuracy = %.2f, want 1 on separable data", model.Report.Accuracy)
+	}
+
+	path := filepath.Join(t.TempDir(), "model.json")
+	if err := model.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	scorer, err := scoring.NewScorer(scoring.ScorerLogistic, path)
+	if err != nil {
+		t.Fatalf("NewScorer: %v", err)
+	}
+
+	high := scorer.Score(scorerGroup(true, "rage", "blocked", "rage"), time.Now(), time.Now())
+	low := scorer.Score(scorerGroup(false, "confusion", "confusion"), time.Now(), time.Now())
+	if high.Score <= 50 || low.Score >= 50 {
+		t.Errorf("scores = %d (confirmed-like) / %d (suppressed-like), want >50 / <50", high.Score, low.Score)
+	}
+}
+
+func TestNewScorer_RejectsMissingModel(t *testing.T) {
+	if _, err := scoring.NewScorer(scoring.ScorerLogistic, ""); err == nil {
+		t.Error("expected an error without a model file")
+	}
+	if _, err := scoring.NewScorer("bogus", ""); err == nil {
+		t.Error("expected an error for an unknown scorer")
+	}
+}
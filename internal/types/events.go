@@ -27,6 +27,7 @@ type Event struct {
 	Metadata       map[string]interface{} `json:"metadata"`
 	Environment    string                 `json:"environment,omitempty"`    // "production", "staging", "development"
 	IdempotencyKey string                 `json:"idempotencyKey,omitempty"` // Unique key to prevent duplicate signal processing
+	SchemaVersion  int                    `json:"schemaVersion,omitempty"`  // 0 = unversioned (version 1) payload
 }
 
 // EventTarget represents the target of an event
@@ -48,7 +49,8 @@ type IngestResponse struct {
 type ValidationError struct {
 	Field   string
 	Reason  string
-	EventID int // Index in batch
+	Code    string // Stable reason code, e.g. "missing_field"
+	EventID int    // Index in batch
 }
 
 // StoredEvent represents an event stored in ClickHouse
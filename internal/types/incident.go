@@ -14,26 +14,30 @@ import (
 
 // Incident represents an incident from Incident Store
 type Incident struct {
-	IncidentID          string         `json:"incidentId"`
-	SessionID           string         `json:"sessionId"`
-	ProjectID           string         `json:"projectId"`
-	FrustrationScore    int            `json:"frustrationScore"`
-	ConfidenceLevel     string         `json:"confidenceLevel"`
-	TriggeringSignals   []string       `json:"triggeringSignals"`
-	PrimaryFailurePoint string         `json:"primaryFailurePoint"`
-	SeverityType        string         `json:"severityType"`
-	Timestamp           time.Time      `json:"timestamp"`
-	Explanation         string         `json:"explanation"`
-	SignalDetails       []SignalDetail `json:"signalDetails"`
-	Status              string         `json:"status"`          // "confirmed", "draft", etc.
-	ConfidenceScore     float64        `json:"confidenceScore"` // 0-100
-	Suppressed          bool           `json:"suppressed"`
-	ExternalTicketID    string         `json:"externalTicketId,omitempty"`
-	ExternalSystem      string         `json:"externalSystem,omitempty"` // "jira" or "linear"
-	ExportedAt          *time.Time     `json:"exportedAt,omitempty"`
-	ExportFailed        bool           `json:"exportFailed"`
-	CreatedAt           time.Time      `json:"createdAt"`
-	UpdatedAt           time.Time      `json:"updatedAt"`
+	IncidentID          string             `json:"incidentId"`
+	SessionID           string             `json:"sessionId"`
+	ProjectID           string             `json:"projectId"`
+	FrustrationScore    int                `json:"frustrationScore"`
+	ConfidenceLevel     string             `json:"confidenceLevel"`
+	TriggeringSignals   []string           `json:"triggeringSignals"`
+	PrimaryFailurePoint string             `json:"primaryFailurePoint"`
+	SeverityType        string             `json:"severityType"`
+	Timestamp           time.Time          `json:"timestamp"`
+	Explanation         string             `json:"explanation"`
+	SignalDetails       []SignalDetail     `json:"signalDetails"`
+	Status              string             `json:"status"`          // "confirmed", "draft", etc.
+	ConfidenceScore     float64            `json:"confidenceScore"` // 0-100
+	Suppressed          bool               `json:"suppressed"`
+	ExternalTicketID    string             `json:"externalTicketId,omitempty"`
+	ExternalSystem      string             `json:"externalSystem,omitempty"` // "jira", "linear" or "github"
+	ExportedAt          *time.Time         `json:"exportedAt,omitempty"`
+	ExportFailed        bool               `json:"exportFailed"`
+	IssueID             string             `json:"issueId,omitempty"`       // Cross-session issue this incident belongs to
+	Regression          bool               `json:"regression,omitempty"`    // Issue recurred after its ticket was resolved
+	ScoreModel          string             `json:"scoreModel,omitempty"`    // Scorer that produced FrustrationScore
+	ScoreFeatures       map[string]float64 `json:"scoreFeatures,omitempty"` // Feature vector used for scoring
+	CreatedAt           time.Time          `json:"createdAt"`
+	UpdatedAt           time.Time          `json:"updatedAt"`
 }
 
 // SignalDetail provides details about a signal
@@ -79,6 +83,13 @@ type IncidentRequest struct {
 	Incident Incident `json:"incident"`
 }
 
+// ExportUpdateRequest records the outcome of a ticket export on an incident
+type ExportUpdateRequest struct {
+	ExternalTicketID string `json:"externalTicketId,omitempty"`
+	ExternalSystem   string `json:"externalSystem,omitempty"`
+	ExportFailed     bool   `json:"exportFailed,omitempty"`
+}
+
 // QueryResponse represents a query response
 type QueryResponse struct {
 	Incidents []Incident `json:"incidents"`
@@ -37,3 +37,16 @@ type ConfidenceInfo struct {
 	Score  float64
 	Reason string
 }
+
+// IssueRef summarizes the cross-session issue an incident belongs to, so the
+// exporter can file one ticket per issue instead of one per incident
+type IssueRef struct {
+	IssueID          string
+	ExternalTicketID string
+	ExternalSystem   string
+	Evidence         Evidence
+	// Regressed is set when the issue recurred after its ticket was
+	// resolved; RegressionCount numbers the recurrences
+	Regressed       bool
+	RegressionCount int
+}
@@ -9,6 +9,7 @@
  * - Each incident includes all required fields
  * - Never emit partial incidents
  * - If explanation unclear → discard
+ * - Persist the numeric confidence score (0-100) alongside the level
  */
 
 package emission
@@ -27,6 +28,7 @@ func EmitIncident(
 	projectID string,
 	group correlation.CorrelatedGroup,
 	frustrationScore int,
+	confidenceScore float64,
 	severityType string,
 	failurePoint string,
 ) (*types.Incident, bool) {
@@ -52,6 +54,7 @@ func EmitIncident(
 		ProjectID:        projectID,
 		FrustrationScore:  frustrationScore,
 		ConfidenceLevel:   "High",
+		ConfidenceScore:   confidenceScore,
 		TriggeringSignals: triggeringSignals,
 		PrimaryFailurePoint: failurePoint,
 		SeverityType:     severityType,
@@ -144,6 +144,7 @@ func ProcessSessionEnhanced(session types.Session) []*types.Incident {
 			session.ProjectID,
 			group,
 			frustrationScore,
+			scoring.CalculateConfidenceScore(group, session).Score,
 			severityType,
 			failurePoint,
 		)
@@ -108,6 +108,7 @@ func ProcessSession(session types.Session) []*types.Incident {
 			session.ProjectID,
 			group,
 			frustrationScore,
+			scoring.CalculateConfidenceScore(group, session).Score,
 			severityType,
 			failurePoint,
 		)
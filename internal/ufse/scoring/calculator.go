@@ -26,6 +26,7 @@ var signalWeights = map[string]float64{
 	"blocked":    0.4,
 	"abandonment": 0.3,
 	"confusion":   0.1, // Low severity by default
+	"input_struggle": 0.2,
 }
 
 // CalculateScore calculates frustration score (0-100)
@@ -0,0 +1,118 @@
+/**
+ * Numeric Confidence Score
+ *
+ * Responsibility: Compute the 0-100 ConfidenceScore persisted on incidents
+ *
+ * EvaluateConfidence gates emission with a Low/Medium/High level; the
+ * exporter's eligibility and priority rules need a number. The score blends:
+ * - Correlation: signal count, signal type diversity, system feedback and
+ *   a clear failure point (0-1)
+ * - Strength: mean signal strength (0-1)
+ * - Freshness: mean decay factor of each signal relative to the group's
+ *   latest signal, so drawn-out groups count for less (0-1)
+ * - False alarms: the share of signals that match a false-alarm pattern
+ *   scales the score down
+ *
+ * Score = 100 × (0.5 × Correlation + 0.3 × Strength + 0.2 × Freshness) × (1 − FalseAlarm)
+ */
+
+package scoring
+
+import (
+	"math"
+
+	"github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/ufse/correlation"
+	"github.com/your-org/frustration-engine/internal/ufse/signals"
+)
+
+// Confidence score weights
+const (
+	confidenceCorrelationWeight = 0.5
+	confidenceStrengthWeight    = 0.3
+	confidenceFreshnessWeight   = 0.2
+)
+
+// ConfidenceBreakdown is a numeric confidence score with its inputs
+type ConfidenceBreakdown struct {
+	Correlation float64 // 0-1
+	Strength    float64 // 0-1
+	Freshness   float64 // 0-1
+	FalseAlarm  float64 // 0-1, share of signals flagged as false alarms
+	Score       float64 // 0-100
+}
+
+// CalculateConfidenceScore computes the numeric confidence for a correlated
+// group. The session's events are used for false-alarm checks.
+func CalculateConfidenceScore(group correlation.CorrelatedGroup, session types.Session) ConfidenceBreakdown {
+	if len(group.Signals) == 0 {
+		return ConfidenceBreakdown{}
+	}
+
+	b := ConfidenceBreakdown{
+		Correlation: correlationStrength(group),
+		Strength:    calculateAverageStrength(group),
+		Freshness:   signalFreshness(group, NewSignalDecayer()),
+		FalseAlarm:  falseAlarmRatio(group, session, signals.NewFalseAlarmPreventer()),
+	}
+
+	blended := confidenceCorrelationWeight*b.Correlation +
+		confidenceStrengthWeight*b.Strength +
+		confidenceFreshnessWeight*b.Freshness
+	b.Score = math.Round(100*blended*(1-b.FalseAlarm)*10) / 10
+	return b
+}
+
+// correlationStrength scores how strongly the group's signals corroborate
+// each other
+func correlationStrength(group correlation.CorrelatedGroup) float64 {
+	signalTypes := make(map[string]bool)
+	for _, signal := range group.Signals {
+		signalTypes[signal.Type] = true
+	}
+
+	strength := 0.3*math.Min(float64(len(group.Signals))/5.0, 1.0) +
+		0.3*math.Min(float64(len(signalTypes))/3.0, 1.0)
+	if group.HasSystemFeedback {
+		strength += 0.2
+	}
+	if hasClearFailurePoint(group) {
+		strength += 0.2
+	}
+	return strength
+}
+
+// signalFreshness returns the mean decay factor of the group's signals
+// measured from the latest signal in the group
+func signalFreshness(group correlation.CorrelatedGroup, decayer *SignalDecayer) float64 {
+	latest := group.Signals[0].Timestamp
+	for _, signal := range group.Signals {
+		if signal.Timestamp.After(latest) {
+			latest = signal.Timestamp
+		}
+	}
+
+	total := 0.0
+	for _, signal := range group.Signals {
+		total += decayer.ApplyDecay(1.0, signal.Timestamp, latest).DecayFactor
+	}
+	return total / float64(len(group.Signals))
+}
+
+// falseAlarmRatio returns the share of the group's signals that match a
+// false-alarm pattern in the session
+func falseAlarmRatio(group correlation.CorrelatedGroup, session types.Session, preventer *signals.FalseAlarmPreventer) float64 {
+	flagged := 0
+	for _, signal := range group.Signals {
+		candidate := signals.CandidateSignal{
+			Type:      signal.Type,
+			Timestamp: signal.Timestamp.Unix(),
+			Route:     signal.Route,
+			Details:   signal.Details,
+		}
+		if isFalse, _ := preventer.IsFalseAlarm(candidate, session, session.Events); isFalse {
+			flagged++
+		}
+	}
+	return float64(flagged) / float64(len(group.Signals))
+}
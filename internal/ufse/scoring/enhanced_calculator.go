@@ -27,6 +27,7 @@ var enhancedSignalWeights = map[string]float64{
 	"blocked":    0.4,
 	"abandonment": 0.3,
 	"confusion":   0.1,
+	"input_struggle": 0.2,
 }
 
 // CalculateEnhancedScore calculates frustration score with enhanced logic
@@ -0,0 +1,143 @@
+/**
+ * Scoring Features
+ *
+ * Responsibility: Extract a stable, named feature vector from a correlated group
+ *
+ * The same feature names are used at detection time (from correlated groups)
+ * and at calibration time (from stored incidents), so coefficients fitted
+ * offline apply unchanged online. Incidents record the vector they were
+ * scored with; FeaturesFromIncident is only a fallback for older incidents.
+ */
+
+package scoring
+
+import (
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/types"
+	"github.com/your-org/frustration-engine/internal/ufse/correlation"
+)
+
+// Feature names
+const (
+	FeatureSignalCount     = "signal_count"
+	FeatureDistinctTypes   = "distinct_signal_types"
+	FeatureSystemFeedback  = "has_system_feedback"
+	FeatureStruggleMinutes = "struggle_minutes"
+	FeatureMeanStrength    = "mean_strength"
+	featureCountPrefix     = "count_"
+)
+
+// countedSignalTypes are the signal types that get their own count feature
+var countedSignalTypes = []string{"rage", "rage_bait", "blocked", "abandonment", "confusion", "form_loop", "input_struggle"}
+
+// defaultTypeStrength is used when a signal carries no strength of its own
+var defaultTypeStrength = map[string]float64{
+	"rage_bait":   0.8,
+	"rage":        0.6,
+	"blocked":     0.7,
+	"abandonment": 0.5,
+	"confusion":   0.3,
+}
+
+// Features is a named feature vector
+type Features map[string]float64
+
+// signalObservation is the subset of a signal that features are built from
+type signalObservation struct {
+	Type      string
+	Timestamp time.Time
+	Strength  float64
+}
+
+// FeatureNames returns all feature names in a stable order
+func FeatureNames() []string {
+	names := []string{
+		FeatureSignalCount,
+		FeatureDistinctTypes,
+		FeatureSystemFeedback,
+		FeatureStruggleMinutes,
+		FeatureMeanStrength,
+	}
+	for _, t := range countedSignalTypes {
+		names = append(names, featureCountPrefix+t)
+	}
+	return names
+}
+
+// ExtractFeatures builds the feature vector for a correlated group
+func ExtractFeatures(group correlation.CorrelatedGroup) Features {
+	observations := make([]signalObservation, 0, len(group.Signals))
+	for _, signal := range group.Signals {
+		strength := signal.Strength
+		if strength == 0 && signal.Details != nil {
+			if s, ok := signal.Details["signal_strength"].(float64); ok {
+				strength = s
+			}
+		}
+		observations = append(observations, signalObservation{
+			Type:      signal.Type,
+			Timestamp: signal.Timestamp,
+			Strength:  strength,
+		})
+	}
+	return buildFeatures(observations, group.HasSystemFeedback)
+}
+
+// FeaturesFromIncident rebuilds an approximate feature vector from a stored
+// incident that predates recorded features
+func FeaturesFromIncident(incident types.Incident) Features {
+	observations := make([]signalObservation, 0, len(incident.SignalDetails))
+	for _, detail := range incident.SignalDetails {
+		observations = append(observations, signalObservation{Type: detail.Type, Timestamp: detail.Timestamp})
+	}
+	if len(observations) == 0 {
+		for _, signalType := range incident.TriggeringSignals {
+			observations = append(observations, signalObservation{Type: signalType, Timestamp: incident.Timestamp})
+		}
+	}
+	return buildFeatures(observations, incident.SeverityType != "UX")
+}
+
+func buildFeatures(observations []signalObservation, hasSystemFeedback bool) Features {
+	features := make(Features, len(FeatureNames()))
+	for _, name := range FeatureNames() {
+		features[name] = 0
+	}
+	if len(observations) == 0 {
+		return features
+	}
+
+	seenTypes := make(map[string]bool)
+	earliest, latest := observations[0].Timestamp, observations[0].Timestamp
+	totalStrength := 0.0
+	for _, o := range observations {
+		seenTypes[o.Type] = true
+		if _, counted := features[featureCountPrefix+o.Type]; counted {
+			features[featureCountPrefix+o.Type]++
+		}
+		if o.Timestamp.Before(earliest) {
+			earliest = o.Timestamp
+		}
+		if o.Timestamp.After(latest) {
+			latest = o.Timestamp
+		}
+		strength := o.Strength
+		if strength == 0 {
+			strength = defaultTypeStrength[o.Type]
+			if strength == 0 {
+				strength = 0.5
+			}
+		}
+		totalStrength += strength
+	}
+
+	features[FeatureSignalCount] = float64(len(observations))
+	features[FeatureDistinctTypes] = float64(len(seenTypes))
+	features[FeatureStruggleMinutes] = latest.Sub(earliest).Minutes()
+	features[FeatureMeanStrength] = totalStrength / float64(len(observations))
+	if hasSystemFeedback {
+		features[FeatureSystemFeedback] = 1
+	}
+	return features
+}
@@ -0,0 +1,224 @@
+/**
+ * Logistic Regression Scorer
+ *
+ * Responsibility: Calibrated, explainable frustration scoring
+ *
+ * Model:
+ * - p = sigmoid(intercept + Σ coefficient_i × feature_i)
+ * - Score = round(100 × p)
+ * - Contribution of feature i = coefficient_i × feature_i (log-odds)
+ *
+ * Coefficients are fitted offline (cmd/calibrate) from labelled incidents:
+ * confirmed incidents are positives, suppressed incidents are negatives.
+ */
+
+package scoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/ufse/correlation"
+)
+
+// LogisticModel is a logistic regression over named features
+type LogisticModel struct {
+	Version      string             `json:"version"`
+	Intercept    float64            `json:"intercept"`
+	Coefficients map[string]float64 `json:"coefficients"`
+	TrainedAt    time.Time          `json:"trainedAt"`
+	Samples      int                `json:"samples"`
+	Report       *FitReport         `json:"report,omitempty"`
+}
+
+// LoadLogisticModel loads coefficients from a JSON file
+func LoadLogisticModel(path string) (*LogisticModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read model: %w", err)
+	}
+	var model LogisticModel
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("parse model: %w", err)
+	}
+	known := make(map[string]bool)
+	for _, name := range FeatureNames() {
+		known[name] = true
+	}
+	for name := range model.Coefficients {
+		if !known[name] {
+			return nil, fmt.Errorf("model references unknown feature %q", name)
+		}
+	}
+	return &model, nil
+}
+
+// Save writes the model as indented JSON
+func (m *LogisticModel) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Name returns the scorer model name
+func (m *LogisticModel) Name() string { return ScorerLogistic }
+
+// Score scores a correlated group with the calibrated model
+func (m *LogisticModel) Score(group correlation.CorrelatedGroup, sessionStart, sessionEnd time.Time) ScoreResult {
+	return m.ScoreFeatures(ExtractFeatures(group))
+}
+
+// ScoreFeatures scores a feature vector
+func (m *LogisticModel) ScoreFeatures(features Features) ScoreResult {
+	logit := m.Intercept
+	contributions := make([]Contribution, 0, len(m.Coefficients))
+	for _, name := range FeatureNames() {
+		weight, ok := m.Coefficients[name]
+		if !ok {
+			continue
+		}
+		value := features[name]
+		logit += weight * value
+		contributions = append(contributions, Contribution{
+			Feature:      name,
+			Value:        value,
+			Weight:       weight,
+			Contribution: weight * value,
+		})
+	}
+
+	p := sigmoid(logit)
+	return ScoreResult{
+		Model:         ScorerLogistic,
+		Score:         int(math.Round(p * 100)),
+		Probability:   p,
+		Features:      features,
+		Contributions: sortContributions(contributions),
+	}
+}
+
+// Sample is one labelled feature vector for calibration
+type Sample struct {
+	Features Features
+	Label    bool // true = confirmed, false = suppressed
+}
+
+// FitOptions controls logistic regression fitting
+type FitOptions struct {
+	LearningRate float64
+	Iterations   int
+	L2           float64 // L2 regularization strength
+}
+
+// DefaultFitOptions returns sensible fitting defaults
+func DefaultFitOptions() FitOptions {
+	return FitOptions{LearningRate: 0.05, Iterations: 5000, L2: 0.01}
+}
+
+// FitReport summarizes how well a fitted model matches its training data
+type FitReport struct {
+	Positives int     `json:"positives"`
+	Negatives int     `json:"negatives"`
+	LogLoss   float64 `json:"logLoss"`
+	Brier     float64 `json:"brier"`
+	Accuracy  float64 `json:"accuracy"`
+}
+
+// FitLogistic fits a logistic model with batch gradient descent
+func FitLogistic(samples []Sample, opts FitOptions) (*LogisticModel, error) {
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("no labelled samples")
+	}
+	if opts.LearningRate <= 0 || opts.Iterations <= 0 {
+		opts = DefaultFitOptions()
+	}
+
+	names := FeatureNames()
+	weights := make([]float64, len(names))
+	intercept := 0.0
+	n := float64(len(samples))
+
+	x := make([][]float64, len(samples))
+	y := make([]float64, len(samples))
+	for i, s := range samples {
+		x[i] = make([]float64, len(names))
+		for j, name := range names {
+			x[i][j] = s.Features[name]
+		}
+		if s.Label {
+			y[i] = 1
+		}
+	}
+
+	for iter := 0; iter < opts.Iterations; iter++ {
+		gradW := make([]float64, len(names))
+		gradB := 0.0
+		for i := range samples {
+			logit := intercept
+			for j := range names {
+				logit += weights[j] * x[i][j]
+			}
+			diff := sigmoid(logit) - y[i]
+			gradB += diff
+			for j := range names {
+				gradW[j] += diff * x[i][j]
+			}
+		}
+		intercept -= opts.LearningRate * gradB / n
+		for j := range names {
+			weights[j] -= opts.LearningRate * (gradW[j]/n + opts.L2*weights[j])
+		}
+	}
+
+	model := &LogisticModel{
+		Version:      "logistic-v1",
+		Intercept:    intercept,
+		Coefficients: make(map[string]float64, len(names)),
+		TrainedAt:    time.Now().UTC(),
+		Samples:      len(samples),
+	}
+	for j, name := range names {
+		model.Coefficients[name] = weights[j]
+	}
+	model.Report = model.Evaluate(samples)
+	return model, nil
+}
+
+// Evaluate computes calibration metrics for the model on labelled samples
+func (m *LogisticModel) Evaluate(samples []Sample) *FitReport {
+	report := &FitReport{}
+	if len(samples) == 0 {
+		return report
+	}
+	const eps = 1e-12
+	correct := 0
+	for _, s := range samples {
+		p := m.ScoreFeatures(s.Features).Probability
+		y := 0.0
+		if s.Label {
+			y = 1
+			report.Positives++
+		} else {
+			report.Negatives++
+		}
+		report.LogLoss -= y*math.Log(p+eps) + (1-y)*math.Log(1-p+eps)
+		report.Brier += (p - y) * (p - y)
+		if (p >= 0.5) == s.Label {
+			correct++
+		}
+	}
+	n := float64(len(samples))
+	report.LogLoss /= n
+	report.Brier /= n
+	report.Accuracy = float64(correct) / n
+	return report
+}
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
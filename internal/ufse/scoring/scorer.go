@@ -0,0 +1,148 @@
+/**
+ * Pluggable Scorers
+ *
+ * Responsibility: Select between the deterministic score and a calibrated model
+ *
+ * Both scorers return a 0-100 score plus a per-feature breakdown, so every
+ * incident can explain where its score came from regardless of the model.
+ */
+
+package scoring
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/ufse/correlation"
+)
+
+// Scorer model names (selectable via config)
+const (
+	ScorerDeterministic = "deterministic"
+	ScorerLogistic      = "logistic"
+)
+
+// Contribution is one feature's share of a score
+type Contribution struct {
+	Feature      string  `json:"feature"`
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
+// ScoreResult is a frustration score with its explanation
+type ScoreResult struct {
+	Model         string         // Scorer model name
+	Score         int            // 0-100
+	Probability   float64        // Calibrated probability (logistic model only)
+	Features      Features       // Feature vector the score was computed from
+	Contributions []Contribution // Sorted by absolute contribution, largest first
+}
+
+// Scorer computes a frustration score for a correlated group
+type Scorer interface {
+	Name() string
+	Score(group correlation.CorrelatedGroup, sessionStart, sessionEnd time.Time) ScoreResult
+}
+
+// NewScorer creates the scorer selected by name. The logistic scorer loads
+// its coefficients from modelPath.
+func NewScorer(name, modelPath string) (Scorer, error) {
+	switch name {
+	case "", ScorerDeterministic:
+		return DeterministicScorer{}, nil
+	case ScorerLogistic:
+		if modelPath == "" {
+			return nil, fmt.Errorf("logistic scorer requires a model file")
+		}
+		model, err := LoadLogisticModel(modelPath)
+		if err != nil {
+			return nil, err
+		}
+		return model, nil
+	default:
+		return nil, fmt.Errorf("unknown scorer %q", name)
+	}
+}
+
+// DeterministicScorer wraps CalculateScore
+type DeterministicScorer struct{}
+
+// Name returns the scorer model name
+func (DeterministicScorer) Name() string { return ScorerDeterministic }
+
+// Score calculates the deterministic additive score and reports each
+// factor's (capped) points as its contribution
+func (DeterministicScorer) Score(group correlation.CorrelatedGroup, sessionStart, sessionEnd time.Time) ScoreResult {
+	countPoints := clamp(float64(len(group.Signals))*10.0, 50.0)
+
+	typePoints := 0.0
+	for _, signal := range group.Signals {
+		typePoints += signalWeights[signal.Type] * 20.0
+	}
+	typePoints = clamp(typePoints, 30.0)
+
+	duration := calculateStruggleDuration(group)
+	durationPoints := clamp(duration.Minutes()*2.0, 10.0)
+
+	feedbackPoints := 0.0
+	if group.HasSystemFeedback {
+		feedbackPoints = 10.0
+	}
+
+	features := ExtractFeatures(group)
+	contributions := sortContributions([]Contribution{
+		{Feature: FeatureSignalCount, Value: features[FeatureSignalCount], Weight: 10.0, Contribution: countPoints},
+		{Feature: "signal_type_weights", Value: typePoints / 20.0, Weight: 20.0, Contribution: typePoints},
+		{Feature: FeatureStruggleMinutes, Value: features[FeatureStruggleMinutes], Weight: 2.0, Contribution: durationPoints},
+		{Feature: FeatureSystemFeedback, Value: features[FeatureSystemFeedback], Weight: 10.0, Contribution: feedbackPoints},
+	})
+
+	return ScoreResult{
+		Model:         ScorerDeterministic,
+		Score:         CalculateScore(group, sessionStart, sessionEnd),
+		Features:      features,
+		Contributions: contributions,
+	}
+}
+
+// Summary renders the score breakdown for incident explanations
+func (r ScoreResult) Summary() string {
+	top := r.Contributions
+	if len(top) > 3 {
+		top = top[:3]
+	}
+	parts := make([]string, 0, len(top))
+	for _, c := range top {
+		parts = append(parts, fmt.Sprintf("%s %+.2f", c.Feature, c.Contribution))
+	}
+
+	model := fmt.Sprintf("Score %d (%s model", r.Score, r.Model)
+	if r.Model == ScorerLogistic {
+		model += fmt.Sprintf(", p=%.2f", r.Probability)
+	}
+	return model + "): top contributions " + strings.Join(parts, ", ")
+}
+
+func sortContributions(contributions []Contribution) []Contribution {
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return abs(contributions[i].Contribution) > abs(contributions[j].Contribution)
+	})
+	return contributions
+}
+
+func clamp(v, max float64) float64 {
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
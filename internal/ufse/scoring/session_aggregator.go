@@ -44,6 +44,7 @@ var DefaultSignalWeights = map[string]float64{
 	"abandonment": 0.30,
 	"confusion":   0.15, // Lower weight - often ambiguous
 	"form_loop":   0.35,
+	"input_struggle": 0.25,
 }
 
 // AggregatorConfig holds configuration for the session aggregator
@@ -13,7 +13,7 @@ import (
 
 // CandidateSignal represents a candidate signal (not yet qualified)
 type CandidateSignal struct {
-	Type      string // "rage", "blocked", "abandonment", "confusion", "form_loop"
+	Type      string // "rage", "blocked", "abandonment", "confusion", "form_loop", "input_struggle"
 	Timestamp int64  // Unix timestamp
 	Route     string
 	Details   map[string]interface{}
@@ -31,6 +31,7 @@ func DetectCandidateSignals(classified []ClassifiedEvent, session types.Session)
 	abandonmentDetector := NewRefinedAbandonmentDetector()
 	confusionDetector := NewRefinedConfusionDetector()
 	formLoopDetector := NewFormLoopDetector()
+	inputStruggleDetector := NewInputStruggleDetector()
 
 	// Detect each type of candidate signal with enhanced detection
 	candidates = append(candidates, enhancedRageDetector.DetectRageMultiTier(classified, session)...)
@@ -39,6 +40,7 @@ func DetectCandidateSignals(classified []ClassifiedEvent, session types.Session)
 	candidates = append(candidates, abandonmentDetector.DetectAbandonmentRefined(classified, session)...)
 	candidates = append(candidates, confusionDetector.DetectConfusionRefined(classified, session)...)
 	candidates = append(candidates, formLoopDetector.DetectFormLoops(classified, session)...)
+	candidates = append(candidates, inputStruggleDetector.DetectInputStruggle(classified, session)...)
 
 	return candidates
 }
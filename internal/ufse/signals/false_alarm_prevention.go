@@ -230,6 +230,10 @@ type BotPattern struct{}
 func (p *BotPattern) Matches(signal CandidateSignal, events []types.Event) bool {
 	for _, event := range events {
 		if metadata := event.Metadata; metadata != nil {
+			// Client context enriched at ingest (see internal/enrich)
+			if client, ok := metadata["client"].(map[string]interface{}); ok && client["botLikelihood"] == "high" {
+				return true
+			}
 			if ua, ok := metadata["userAgent"].(string); ok {
 				uaLower := strings.ToLower(ua)
 				botIndicators := []string{
@@ -0,0 +1,306 @@
+/**
+ * Input Field Struggle Detection
+ *
+ * Responsibility: Detect field-level struggle on form inputs
+ *
+ * Patterns (per field, per editing episode):
+ * 1. Clear-and-retype: the field is emptied and typed again repeatedly
+ * 2. Validation errors: the same field is rejected several times
+ * 3. Long dwell: the field keeps focus for a long time with many edits
+ * 4. Paste-then-delete: content is pasted and then mostly deleted within
+ *    inputPasteDeleteWindow, repeatedly
+ *
+ * Fields are keyed by target.selector, else "#"+target.id; events naming
+ * neither are skipped rather than merged into one anonymous field.
+ *
+ * Privacy: only value lengths and counts are read from metadata. Field
+ * values are never inspected, and signal details carry counts only.
+ */
+
+package signals
+
+import (
+	"log"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+const (
+	// Input struggle thresholds
+	inputEpisodeGap            = 60 * time.Second // Gap that ends an editing episode on a field
+	inputMinClearRetypes       = 2                // Clear-and-retype cycles to trigger
+	inputMinValidationErrors   = 3                // Validation errors on one field to trigger
+	inputLongDwell             = 30 * time.Second // Focus duration considered long
+	inputLongDwellMinEdits     = 20               // Edits during a long dwell to trigger
+	inputMinPasteDeletes       = 2                // Paste-then-delete cycles to trigger
+	inputPasteDeleteWindow     = 10 * time.Second // Max time between paste and delete
+	inputMinClearedValueLength = 3                // Min length before a clear counts as a retype
+)
+
+// InputStruggleDetector detects field-level struggle on form inputs
+type InputStruggleDetector struct {
+	falseAlarmPreventer *FalseAlarmPreventer
+}
+
+// NewInputStruggleDetector creates a new input struggle detector
+func NewInputStruggleDetector() *InputStruggleDetector {
+	return &InputStruggleDetector{
+		falseAlarmPreventer: NewFalseAlarmPreventer(),
+	}
+}
+
+// fieldStats holds privacy-safe counters for one editing episode on a field
+type fieldStats struct {
+	start            ClassifiedEvent
+	clearRetypes     int
+	validationErrors int
+	edits            int
+	pasteDeletes     int
+	maxDwell         time.Duration
+}
+
+// DetectInputStruggle detects input struggle signals
+func (d *InputStruggleDetector) DetectInputStruggle(classified []ClassifiedEvent, session types.Session) []CandidateSignal {
+	candidates := make([]CandidateSignal, 0)
+
+	for selector, fieldEvents := range groupFieldEvents(classified) {
+		for _, episode := range splitEpisodes(fieldEvents) {
+			stats := collectFieldStats(episode)
+			struggleType, ok := stats.struggleType()
+			if !ok {
+				continue
+			}
+
+			candidate := CandidateSignal{
+				Type:      "input_struggle",
+				Timestamp: stats.start.Timestamp.Unix(),
+				Route:     stats.start.Route,
+				Details: map[string]interface{}{
+					"struggle_type":          struggleType,
+					"target_id":              selector,
+					"field_selector":         selector,
+					"action_type":            "input",
+					"clear_retype_count":     stats.clearRetypes,
+					"validation_error_count": stats.validationErrors,
+					"edit_count":             stats.edits,
+					"paste_delete_count":     stats.pasteDeletes,
+					"dwell_seconds":          stats.maxDwell.Seconds(),
+					"signal_strength":        stats.strength(),
+				},
+			}
+
+			if isFalse, reason := d.falseAlarmPreventer.IsFalseAlarm(candidate, session, convertToEvents(classified)); isFalse {
+				log.Printf("[Input Struggle Detection] False alarm prevented: %s", reason)
+				continue
+			}
+
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+// groupFieldEvents groups input-related events by field selector
+func groupFieldEvents(classified []ClassifiedEvent) map[string][]ClassifiedEvent {
+	groups := make(map[string][]ClassifiedEvent)
+	for _, event := range classified {
+		if !isInputEvent(event) {
+			continue
+		}
+		selector := fieldSelector(event)
+		if selector == "" {
+			continue
+		}
+		groups[selector] = append(groups[selector], event)
+	}
+	return groups
+}
+
+// splitEpisodes splits a field's events into episodes separated by idle gaps
+func splitEpisodes(events []ClassifiedEvent) [][]ClassifiedEvent {
+	episodes := make([][]ClassifiedEvent, 0)
+	current := make([]ClassifiedEvent, 0)
+	for i, event := range events {
+		if i > 0 && event.Timestamp.Sub(events[i-1].Timestamp) > inputEpisodeGap {
+			episodes = append(episodes, current)
+			current = make([]ClassifiedEvent, 0)
+		}
+		current = append(current, event)
+	}
+	if len(current) > 0 {
+		episodes = append(episodes, current)
+	}
+	return episodes
+}
+
+// collectFieldStats computes struggle counters for one episode
+func collectFieldStats(episode []ClassifiedEvent) fieldStats {
+	stats := fieldStats{start: episode[0]}
+
+	lastLength := -1
+	peakLength := 0 // longest value since the field was last empty
+	cleared := false
+	var focusedAt time.Time
+	var pastedAt time.Time
+	pastedLength := 0
+	pastePeak := -1 // longest value since the paste
+
+	for _, event := range episode {
+		length, hasLength := valueLength(event)
+
+		switch event.Event.EventType {
+		case "input_focus":
+			focusedAt = event.Timestamp
+		case "input_blur":
+			if !focusedAt.IsZero() {
+				if dwell := event.Timestamp.Sub(focusedAt); dwell > stats.maxDwell {
+					stats.maxDwell = dwell
+				}
+				focusedAt = time.Time{}
+			}
+			if edits, ok := metadataInt(event.Event.Metadata, "editCount"); ok {
+				stats.edits += edits
+			}
+		case "input":
+			stats.edits++
+		case "input_paste", "paste":
+			pastedAt, pastePeak = event.Timestamp, -1
+			pastedLength = 0
+			if n, ok := metadataInt(event.Event.Metadata, "pastedLength"); ok {
+				pastedLength = n
+			}
+		}
+
+		if isFieldValidationError(event) {
+			stats.validationErrors++
+		}
+
+		if hasLength {
+			// Clear-and-retype: emptied after meaningful content, then typed
+			// again. Only the step to empty counts, so deleting one character
+			// at a time is a single clear
+			if length == 0 {
+				if lastLength > 0 && peakLength >= inputMinClearedValueLength {
+					cleared = true
+				}
+				peakLength = 0
+			} else {
+				if cleared {
+					stats.clearRetypes++
+					cleared = false
+				}
+				if length > peakLength {
+					peakLength = length
+				}
+			}
+
+			// Paste-then-delete: most of the pasted content removed within
+			// the window, measured from the longest value since the paste
+			if !pastedAt.IsZero() {
+				if event.Timestamp.Sub(pastedAt) > inputPasteDeleteWindow {
+					pastedAt, pastePeak = time.Time{}, -1
+				} else {
+					if length > pastePeak {
+						pastePeak = length
+					}
+					if pastedLength > 0 && (pastePeak-length)*2 >= pastedLength {
+						stats.pasteDeletes++
+						pastedAt, pastePeak = time.Time{}, -1
+					}
+				}
+			}
+			lastLength = length
+		}
+	}
+
+	return stats
+}
+
+// struggleType returns the strongest struggle pattern, if any threshold was met
+func (s fieldStats) struggleType() (string, bool) {
+	switch {
+	case s.validationErrors >= inputMinValidationErrors:
+		return "repeated_validation_errors", true
+	case s.clearRetypes >= inputMinClearRetypes:
+		return "clear_and_retype", true
+	case s.pasteDeletes >= inputMinPasteDeletes:
+		return "paste_then_delete", true
+	case s.maxDwell >= inputLongDwell && s.edits >= inputLongDwellMinEdits:
+		return "long_dwell_many_edits", true
+	default:
+		return "", false
+	}
+}
+
+// strength calculates signal strength (0-1) from the episode counters
+func (s fieldStats) strength() float64 {
+	strength := float64(s.validationErrors)*0.15 +
+		float64(s.clearRetypes)*0.2 +
+		float64(s.pasteDeletes)*0.2 +
+		float64(s.edits)/100.0
+	if strength > 1.0 {
+		return 1.0
+	}
+	return strength
+}
+
+// isInputEvent checks if event relates to a form field
+func isInputEvent(event ClassifiedEvent) bool {
+	switch event.Event.EventType {
+	case "input", "input_focus", "input_blur", "input_paste", "paste", "input_invalid", "validation_error":
+		return true
+	case "error":
+		return isFieldValidationError(event)
+	default:
+		return false
+	}
+}
+
+// isFieldValidationError checks if event is a validation error on a field
+func isFieldValidationError(event ClassifiedEvent) bool {
+	switch event.Event.EventType {
+	case "input_invalid", "validation_error":
+		return true
+	case "error":
+		if metadata := event.Event.Metadata; metadata != nil {
+			if errorType, ok := metadata["errorType"].(string); ok && errorType == "validation" {
+				return event.Event.Target.Selector != "" || event.Event.Target.ID != ""
+			}
+		}
+	}
+	return false
+}
+
+// fieldSelector returns a stable selector naming the field
+func fieldSelector(event ClassifiedEvent) string {
+	target := event.Event.Target
+	if target.Selector != "" {
+		return target.Selector
+	}
+	if target.ID != "" {
+		return "#" + target.ID
+	}
+	return ""
+}
+
+// valueLength reads the privacy-safe value length from event metadata
+func valueLength(event ClassifiedEvent) (int, bool) {
+	return metadataInt(event.Event.Metadata, "valueLength")
+}
+
+// metadataInt reads a numeric metadata field decoded from JSON
+func metadataInt(metadata map[string]interface{}, key string) (int, bool) {
+	if metadata == nil {
+		return 0, false
+	}
+	switch v := metadata[key].(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -112,6 +112,11 @@ func hasCauseEffectRelationship(candidate CandidateSignal, classified []Classifi
 		return true // Confusion is self-evident from pattern
 	}
 
+	// For input struggle, repeated edits/errors on one field are the effect
+	if candidate.Type == "input_struggle" {
+		return true
+	}
+
 	return false
 }
 
@@ -39,6 +39,7 @@ const (
 	ReasonManualSuppression    SuppressionReason = "manual_suppression"
 	ReasonExplanationFailed    SuppressionReason = "explanation_failed"
 	ReasonCorrelationFailed    SuppressionReason = "correlation_failed"
+	ReasonTicketWontFix        SuppressionReason = "ticket_wont_fix"
 )
 
 // SuppressionEvent records a suppression decision
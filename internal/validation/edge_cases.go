@@ -16,6 +16,7 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/your-org/frustration-engine/internal/enrich"
 	"github.com/your-org/frustration-engine/internal/types"
 )
 
@@ -51,38 +52,33 @@ func NewEdgeCaseValidator() *EdgeCaseValidator {
 	}
 }
 
-// ValidateEventComprehensive performs comprehensive edge case validation
-func (v *EdgeCaseValidator) ValidateEventComprehensive(event types.Event) (bool, []string) {
-	var errors []string
-
-	// 1. Basic structure validation
-	if ok, errs := v.validateBasicStructure(event); !ok {
-		errors = append(errors, errs...)
-	}
-
-	// 2. Data quality validation
-	if ok, errs := v.validateDataQuality(event); !ok {
-		errors = append(errors, errs...)
-	}
-
-	// 3. Timing validation
-	if ok, errs := v.validateTiming(event); !ok {
-		errors = append(errors, errs...)
-	}
+// edgeCaseCheck is one group of edge case checks and the reason code its
+// failures are reported under
+type edgeCaseCheck struct {
+	code  string
+	check func(types.Event) (bool, []string)
+}
 
-	// 4. Size validation
-	if ok, errs := v.validateSize(event); !ok {
-		errors = append(errors, errs...)
+// checks returns the edge case checks in the order they run
+func (v *EdgeCaseValidator) checks() []edgeCaseCheck {
+	return []edgeCaseCheck{
+		{ReasonMissingField, v.validateBasicStructure}, // 1. Basic structure
+		{ReasonDataQuality, v.validateDataQuality},     // 2. Data quality
+		{ReasonTimestampOutOfRange, v.validateTiming},  // 3. Timing
+		{ReasonTooLarge, v.validateSize},               // 4. Size
+		{ReasonUnsafeContent, v.validateSecurity},      // 5. Security
+		{ReasonUnknownEventType, v.validateContent},    // 6. Content
 	}
+}
 
-	// 5. Security validation
-	if ok, errs := v.validateSecurity(event); !ok {
-		errors = append(errors, errs...)
-	}
+// ValidateEventComprehensive performs comprehensive edge case validation
+func (v *EdgeCaseValidator) ValidateEventComprehensive(event types.Event) (bool, []string) {
+	var errors []string
 
-	// 6. Content validation
-	if ok, errs := v.validateContent(event); !ok {
-		errors = append(errors, errs...)
+	for _, c := range v.checks() {
+		if ok, errs := c.check(event); !ok {
+			errors = append(errors, errs...)
+		}
 	}
 
 	return len(errors) == 0, errors
@@ -157,7 +153,7 @@ func (v *EdgeCaseValidator) validateTiming(event types.Event) (bool, []string) {
 	}
 
 	// Parse timestamp
-	timestamp, err := time.Parse(time.RFC3339, event.Timestamp)
+	timestamp, err := parseTimestamp(event.Timestamp)
 	if err != nil {
 		errors = append(errors, fmt.Sprintf("invalid timestamp format: %v", err))
 		return false, errors
@@ -245,18 +241,8 @@ func (v *EdgeCaseValidator) validateContent(event types.Event) (bool, []string)
 		}
 	}
 
-	// Event type validation
-	validEventTypes := map[string]bool{
-		"click":      true,
-		"scroll":     true,
-		"input":      true,
-		"form":       true,
-		"error":      true,
-		"network":    true,
-		"navigation": true,
-	}
-
-	if !validEventTypes[event.EventType] {
+	// Event type validation (shared with schema validation)
+	if !allowedEventTypes[event.EventType] {
 		errors = append(errors, fmt.Sprintf("invalid eventType: %s", event.EventType))
 	}
 
@@ -272,7 +258,13 @@ func (v *EdgeCaseValidator) validateMetadata(metadata map[string]interface{}, de
 		return errors
 	}
 
-	if len(metadata) > MaxMetadataKeys {
+	// Keys the server sets at ingest (client context) don't count against
+	// the client's key budget
+	keys := len(metadata)
+	if _, ok := metadata[enrich.ClientKey]; ok && depth == 0 {
+		keys--
+	}
+	if keys > MaxMetadataKeys {
 		errors = append(errors, "metadata key count exceeds limit")
 		return errors
 	}
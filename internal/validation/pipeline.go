@@ -0,0 +1,148 @@
+/**
+ * Validation Pipeline
+ *
+ * Responsibility: Compose schema, edge case and privacy validation into a
+ * single per-event pass with stable reason codes
+ *
+ * Stages run in order and the first failing stage rejects the event:
+ * 1. Schema (ValidateEvent): required fields, event type, timestamp format
+ * 2. Edge cases (EdgeCaseValidator): control characters, metadata limits,
+ *    timing window, size, unsafe content
+ * 3. Privacy (ValidatePrivacy): PII keys and patterns
+ *
+ * With a Redactor the privacy stage redacts PII in accepted events instead
+ * of rejecting them (see redaction.go)
+ *
+ * A batch over MaxBatchSize is rejected as a whole, with one error per event
+ */
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// Reason codes reported in ValidationError.Code, also used as metric labels
+const (
+	ReasonMissingField        = "missing_field"
+	ReasonUnknownEventType    = "unknown_event_type"
+	ReasonInvalidTimestamp    = "invalid_timestamp"
+	ReasonTimestampOutOfRange = "timestamp_out_of_range"
+	ReasonDataQuality         = "data_quality"
+	ReasonTooLarge            = "too_large"
+	ReasonUnsafeContent       = "unsafe_content"
+	ReasonPII                 = "pii"
+	ReasonBatchTooLarge       = "batch_too_large"
+	ReasonUnsupportedSchema   = "unsupported_schema_version"
+)
+
+// Pipeline validates events through every stage
+type Pipeline struct {
+	edgeCases *EdgeCaseValidator
+	redactor  *Redactor
+}
+
+// BatchResult is the outcome of validating a batch
+type BatchResult struct {
+	Valid      []types.Event
+	ValidIndex []int                   // position in the batch of each valid event
+	Errors     []types.ValidationError // one per rejected event
+	Redactions map[string]int          // redacted values by rule
+}
+
+// NewPipeline creates a validation pipeline that rejects events with PII
+func NewPipeline() *Pipeline {
+	return &Pipeline{edgeCases: NewEdgeCaseValidator()}
+}
+
+// SetRedactor redacts PII instead of rejecting the event (nil rejects)
+func (p *Pipeline) SetRedactor(r *Redactor) {
+	p.redactor = r
+}
+
+// Validate returns the events that passed every stage and one error per
+// rejected event, indexed by position in events. With a redactor, events are
+// redacted for projectID in place
+func (p *Pipeline) Validate(projectID string, events []types.Event) BatchResult {
+	result := BatchResult{
+		Valid:  make([]types.Event, 0, len(events)),
+		Errors: make([]types.ValidationError, 0),
+	}
+
+	// Every event is redacted first, rejected ones included, since they may
+	// be kept for inspection (e.g. a dead letter queue)
+	if p.redactor != nil {
+		for i := range events {
+			for rule, n := range p.redactor.Redact(projectID, &events[i]) {
+				if result.Redactions == nil {
+					result.Redactions = make(map[string]int)
+				}
+				result.Redactions[rule] += n
+			}
+		}
+	}
+
+	if len(events) > MaxBatchSize {
+		for i := range events {
+			result.Errors = append(result.Errors, types.ValidationError{
+				Field:   "events",
+				Reason:  fmt.Sprintf("batch size exceeds maximum: %d", MaxBatchSize),
+				Code:    ReasonBatchTooLarge,
+				EventID: i,
+			})
+		}
+		return result
+	}
+
+	for i := range events {
+		if err := p.ValidateEvent(events[i], i); err != nil {
+			result.Errors = append(result.Errors, *err)
+			continue
+		}
+		result.Valid = append(result.Valid, events[i])
+		result.ValidIndex = append(result.ValidIndex, i)
+	}
+
+	return result
+}
+
+// ValidateEvent runs a single event through the stages. The privacy stage
+// is skipped when the pipeline redacts instead
+func (p *Pipeline) ValidateEvent(event types.Event, index int) *types.ValidationError {
+	if err := ValidateEvent(event, index); err != nil {
+		return err
+	}
+
+	for _, c := range p.edgeCases.checks() {
+		if ok, errs := c.check(event); !ok {
+			return &types.ValidationError{
+				Field:   edgeCaseField(c.code),
+				Reason:  strings.Join(errs, "; "),
+				Code:    c.code,
+				EventID: index,
+			}
+		}
+	}
+
+	if p.redactor != nil {
+		return nil
+	}
+	return validateEventPrivacy(event, index)
+}
+
+// edgeCaseField names the field an edge case check looks at
+func edgeCaseField(code string) string {
+	switch code {
+	case ReasonTimestampOutOfRange:
+		return "timestamp"
+	case ReasonUnknownEventType:
+		return "eventType"
+	case ReasonUnsafeContent:
+		return "route"
+	default:
+		return ""
+	}
+}
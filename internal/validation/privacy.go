@@ -33,23 +33,8 @@ func ValidatePrivacy(events []types.Event) ([]types.Event, []types.ValidationErr
 	errors := make([]types.ValidationError, 0)
 
 	for i, event := range events {
-		// Check metadata for disallowed keys
-		if hasDisallowedKeys(event.Metadata) {
-			errors = append(errors, types.ValidationError{
-				Field:   "metadata",
-				Reason:  "contains disallowed PII keys",
-				EventID: i,
-			})
-			continue // Drop event with PII
-		}
-
-		// Check for PII patterns in string values
-		if hasPIIPatterns(event) {
-			errors = append(errors, types.ValidationError{
-				Field:   "metadata",
-				Reason:  "contains PII patterns",
-				EventID: i,
-			})
+		if err := validateEventPrivacy(event, i); err != nil {
+			errors = append(errors, *err)
 			continue // Drop event with PII
 		}
 
@@ -59,6 +44,31 @@ func ValidatePrivacy(events []types.Event) ([]types.Event, []types.ValidationErr
 	return validEvents, errors
 }
 
+// validateEventPrivacy checks a single event for PII
+func validateEventPrivacy(event types.Event, index int) *types.ValidationError {
+	// Check metadata for disallowed keys
+	if hasDisallowedKeys(event.Metadata) {
+		return &types.ValidationError{
+			Field:   "metadata",
+			Reason:  "contains disallowed PII keys",
+			Code:    ReasonPII,
+			EventID: index,
+		}
+	}
+
+	// Check for PII patterns in string values
+	if hasPIIPatterns(event) {
+		return &types.ValidationError{
+			Field:   "metadata",
+			Reason:  "contains PII patterns",
+			Code:    ReasonPII,
+			EventID: index,
+		}
+	}
+
+	return nil
+}
+
 // hasDisallowedKeys checks if metadata contains disallowed keys
 func hasDisallowedKeys(metadata map[string]interface{}) bool {
 	if metadata == nil {
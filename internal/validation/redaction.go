@@ -0,0 +1,365 @@
+/**
+ * PII Redaction
+ *
+ * Responsibility: Redact PII from event metadata and selectors instead of
+ * dropping the whole event
+ *
+ * Built-in rules: pii_key (disallowed metadata keys), email, card_number
+ * (Luhn-checked, with separators or a known card prefix), phone, ip, jwt.
+ * Custom rules add named regexes. The ip rule skips user agent, release and
+ * version keys, whose dotted version numbers look like addresses
+ *
+ * Only string values are scanned: numeric metadata and the event route are
+ * left as they are (routes are normalized by route templates instead)
+ *
+ * Actions:
+ * - mask: replace the match with [REDACTED:<rule>]
+ * - hash: replace the match with [<rule>:<hmac>], keyed by a per-project
+ *   salt so the same value correlates within a project but not across them
+ * - drop: remove the field (metadata key, array element or selector)
+ * - off: disable the rule
+ *
+ * Config file (JSON):
+ *
+ *	{
+ *	  "action": "mask",
+ *	  "rules": {"ip": "hash", "phone": "off"},
+ *	  "custom": [{"name": "order_id", "pattern": "ORD-[0-9]{8}", "action": "hash"}],
+ *	  "salts": {"*": "default-salt", "web": "web-salt"}
+ *	}
+ *
+ * Events are redacted in place. Hashing needs a "*" salt so that projects
+ * without their own salt are still keyed
+ */
+
+package validation
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// RedactionAction is what happens to a value matched by a rule
+type RedactionAction string
+
+const (
+	RedactMask RedactionAction = "mask"
+	RedactHash RedactionAction = "hash"
+	RedactDrop RedactionAction = "drop"
+	RedactOff  RedactionAction = "off"
+)
+
+// Built-in redaction rule names
+const (
+	RulePIIKey     = "pii_key"
+	RuleEmail      = "email"
+	RuleCardNumber = "card_number"
+	RulePhone      = "phone"
+	RuleIP         = "ip"
+	RuleJWT        = "jwt"
+)
+
+// RedactionConfig is the redaction config file format
+type RedactionConfig struct {
+	Action RedactionAction            `json:"action,omitempty"` // default for every rule (mask)
+	Rules  map[string]RedactionAction `json:"rules,omitempty"`  // per-rule override
+	Custom []CustomRedactionRule      `json:"custom,omitempty"`
+	Salts  map[string]string          `json:"salts,omitempty"` // project ID (or "*") to hash salt
+}
+
+// CustomRedactionRule redacts matches of a regex
+type CustomRedactionRule struct {
+	Name    string          `json:"name"`
+	Pattern string          `json:"pattern"`
+	Action  RedactionAction `json:"action,omitempty"`
+}
+
+// Redactor applies redaction rules to events
+type Redactor struct {
+	keyAction RedactionAction
+	rules     []redactionRule
+	salts     map[string]string
+}
+
+type redactionRule struct {
+	name   string
+	re     *regexp.Regexp
+	valid  func(match string) bool // optional check on each match
+	skip   map[string]bool         // lowercase metadata keys the rule ignores
+	action RedactionAction
+}
+
+// Built-in patterns. Card numbers and IPs are confirmed by a check on the
+// match, JWTs are three base64url segments starting with a JSON header
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	cardPattern  = regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`)
+	phonePattern = regexp.MustCompile(`(?:\+\d{1,3}[ .\-]?)?(?:\(\d{3}\)|\b\d{3})[ .\-]\d{3}[ .\-]\d{4}\b|\+\d{10,14}\b`)
+	ipPattern    = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b|\b(?:[0-9A-Fa-f]{1,4}:){2,7}[0-9A-Fa-f]{1,4}\b`)
+	jwtPattern   = regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+)
+
+// versionKeys hold versions such as "Chrome/120.0.0.0" or "1.2.3.4", not
+// IP addresses
+var versionKeys = map[string]bool{
+	"useragent":      true,
+	"user_agent":     true,
+	"release":        true,
+	"version":        true,
+	"appversion":     true,
+	"browserversion": true,
+	"osversion":      true,
+	"sdkversion":     true,
+}
+
+// cardPrefixes are the issuer prefixes of the major card brands, with the
+// lengths their numbers come in
+var cardPrefixes = []struct {
+	lo, hi         int // prefix range, inclusive
+	minLen, maxLen int
+}{
+	{4, 4, 13, 19},       // Visa
+	{34, 34, 15, 15},     // American Express
+	{37, 37, 15, 15},     // American Express
+	{51, 55, 16, 16},     // Mastercard
+	{2221, 2720, 16, 16}, // Mastercard
+	{300, 305, 14, 19},   // Diners Club
+	{36, 36, 14, 19},     // Diners Club
+	{38, 39, 14, 19},     // Diners Club
+	{3528, 3589, 16, 19}, // JCB
+	{6011, 6011, 16, 19}, // Discover
+	{644, 649, 16, 19},   // Discover
+	{65, 65, 16, 19},     // Discover
+	{62, 62, 16, 19},     // UnionPay
+}
+
+// LoadRedactionConfigFile reads a redaction config file and builds a redactor
+func LoadRedactionConfigFile(path string) (*Redactor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read redaction config: %w", err)
+	}
+	var cfg RedactionConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse redaction config: %w", err)
+	}
+	return NewRedactor(cfg)
+}
+
+// NewRedactor builds a redactor; the zero config masks every built-in rule
+func NewRedactor(cfg RedactionConfig) (*Redactor, error) {
+	if cfg.Action == "" {
+		cfg.Action = RedactMask
+	}
+	action := func(rule string, override RedactionAction) (RedactionAction, error) {
+		if override == "" {
+			override = cfg.Rules[rule]
+		}
+		if override == "" {
+			override = cfg.Action
+		}
+		switch override {
+		case RedactMask, RedactDrop, RedactOff:
+		case RedactHash:
+			if cfg.Salts["*"] == "" {
+				return "", fmt.Errorf("redaction rule %s hashes but no \"*\" salt is configured", rule)
+			}
+		default:
+			return "", fmt.Errorf("redaction rule %s: unknown action %q", rule, override)
+		}
+		return override, nil
+	}
+
+	r := &Redactor{salts: cfg.Salts}
+	var err error
+	if r.keyAction, err = action(RulePIIKey, ""); err != nil {
+		return nil, err
+	}
+
+	builtIn := []redactionRule{
+		{name: RuleJWT, re: jwtPattern},
+		{name: RuleEmail, re: emailPattern},
+		{name: RuleCardNumber, re: cardPattern, valid: cardNumberValid},
+		{name: RuleIP, re: ipPattern, valid: func(s string) bool { return net.ParseIP(s) != nil }, skip: versionKeys},
+		{name: RulePhone, re: phonePattern},
+	}
+	for _, rule := range builtIn {
+		if rule.action, err = action(rule.name, ""); err != nil {
+			return nil, err
+		}
+		r.rules = append(r.rules, rule)
+	}
+
+	for i, custom := range cfg.Custom {
+		if custom.Name == "" {
+			return nil, fmt.Errorf("custom redaction rule %d has no name", i)
+		}
+		re, err := regexp.Compile(custom.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("custom redaction rule %s: %w", custom.Name, err)
+		}
+		rule := redactionRule{name: custom.Name, re: re}
+		if rule.action, err = action(custom.Name, custom.Action); err != nil {
+			return nil, err
+		}
+		r.rules = append(r.rules, rule)
+	}
+	return r, nil
+}
+
+// Redact redacts an event's metadata and target selector in place and
+// returns the number of redacted values per rule
+func (r *Redactor) Redact(projectID string, event *types.Event) map[string]int {
+	counts := make(map[string]int)
+	salt := r.salts[projectID]
+	if salt == "" {
+		salt = r.salts["*"]
+	}
+
+	if event.Metadata != nil {
+		event.Metadata = r.redactMap(event.Metadata, salt, counts)
+	}
+	if event.Target.Selector != "" {
+		if selector, keep := r.redactString(event.Target.Selector, "", salt, counts); keep {
+			event.Target.Selector = selector
+		} else {
+			event.Target.Selector = ""
+		}
+	}
+
+	if len(counts) == 0 {
+		return nil
+	}
+	return counts
+}
+
+// redactMap redacts metadata values, recursing into maps and arrays
+func (r *Redactor) redactMap(metadata map[string]interface{}, salt string, counts map[string]int) map[string]interface{} {
+	for key, value := range metadata {
+		if r.keyAction != RedactOff && disallowedKeys[toLower(key)] {
+			counts[RulePIIKey]++
+			if r.keyAction == RedactDrop {
+				delete(metadata, key)
+			} else {
+				metadata[key] = r.replacement(RulePIIKey, r.keyAction, fmt.Sprint(value), salt)
+			}
+			continue
+		}
+		if redacted, keep := r.redactValue(value, toLower(key), salt, counts); keep {
+			metadata[key] = redacted
+		} else {
+			delete(metadata, key)
+		}
+	}
+	return metadata
+}
+
+// redactValue redacts a metadata value under key (array elements take their
+// array's key); keep is false when it is dropped
+func (r *Redactor) redactValue(value interface{}, key, salt string, counts map[string]int) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		return r.redactString(v, key, salt, counts)
+	case map[string]interface{}:
+		return r.redactMap(v, salt, counts), true
+	case []interface{}:
+		kept := v[:0]
+		for _, item := range v {
+			if redacted, keep := r.redactValue(item, key, salt, counts); keep {
+				kept = append(kept, redacted)
+			}
+		}
+		return kept, true
+	default:
+		return value, true
+	}
+}
+
+// redactString applies every rule to a string found under key (lowercase,
+// "" for the selector); keep is false when a matching rule drops the field
+func (r *Redactor) redactString(s, key, salt string, counts map[string]int) (string, bool) {
+	for _, rule := range r.rules {
+		if rule.action == RedactOff || rule.skip[key] {
+			continue
+		}
+		matched := false
+		s = rule.re.ReplaceAllStringFunc(s, func(match string) string {
+			if rule.valid != nil && !rule.valid(match) {
+				return match
+			}
+			matched = true
+			counts[rule.name]++
+			return r.replacement(rule.name, rule.action, match, salt)
+		})
+		if matched && rule.action == RedactDrop {
+			return "", false
+		}
+	}
+	return s, true
+}
+
+// replacement is what a redacted value is replaced with
+func (r *Redactor) replacement(rule string, action RedactionAction, value, salt string) string {
+	if action == RedactHash {
+		mac := hmac.New(sha256.New, []byte(salt))
+		mac.Write([]byte(value))
+		return fmt.Sprintf("[%s:%s]", rule, hex.EncodeToString(mac.Sum(nil))[:16])
+	}
+	return fmt.Sprintf("[REDACTED:%s]", rule)
+}
+
+// cardNumberValid checks a card number candidate: Luhn-valid, and written
+// with separators or starting with a known card prefix, so bare numbers
+// such as unix millisecond timestamps are not taken for cards
+func cardNumberValid(s string) bool {
+	if !luhnValid(s) {
+		return false
+	}
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			digits = append(digits, s[i])
+		}
+	}
+	if len(digits) < len(s) {
+		return true
+	}
+	for _, p := range cardPrefixes {
+		n := len(strconv.Itoa(p.hi))
+		prefix, _ := strconv.Atoi(string(digits[:n]))
+		if prefix >= p.lo && prefix <= p.hi && len(digits) >= p.minLen && len(digits) <= p.maxLen {
+			return true
+		}
+	}
+	return false
+}
+
+// luhnValid checks a card number candidate (separators allowed)
+func luhnValid(s string) bool {
+	sum, digits := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return digits >= 13 && digits <= 19 && sum%10 == 0
+}
@@ -9,22 +9,47 @@ package validation
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/your-org/frustration-engine/internal/types"
+	pkgtypes "github.com/your-org/frustration-engine/pkg/types"
 )
 
-// Allowed event types
+// Allowed event types (everything the SDK emits and the engine classifies)
 var allowedEventTypes = map[string]bool{
-	"click":       true,
-	"scroll":      true,
-	"input":       true,
-	"form_submit": true,
-	"navigation":  true,
-	"error":       true,
-	"network":     true,
-	"performance": true,
-	"loading":     true,
+	"click":               true,
+	"scroll":              true,
+	"input":               true,
+	"input_focus":         true,
+	"input_blur":          true,
+	"input_paste":         true,
+	"input_invalid":       true,
+	"form":                true,
+	"form_submit":         true,
+	"validation_error":    true,
+	"navigation":          true,
+	"route_change":        true,
+	"error":               true,
+	"unhandled_rejection": true,
+	"network":             true,
+	"network_error":       true,
+	"network_success":     true,
+	"slow_response":       true,
+	"performance":         true,
+	"long_task":           true,
+	"loading":             true,
+}
+
+// EventTypes returns the allowed event types, sorted
+func EventTypes() []string {
+	eventTypes := make([]string, 0, len(allowedEventTypes))
+	for eventType := range allowedEventTypes {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	return eventTypes
 }
 
 // MaxEventSize is the maximum size of a single event in bytes
@@ -38,11 +63,23 @@ const MaxPayloadSize = 500 * 1024 // 500KB
 
 // ValidateEvent validates a single event
 func ValidateEvent(event types.Event, index int) *types.ValidationError {
+	// Check schema version first, since newer versions may add event types
+	// (older versions are upcast before validation)
+	if event.SchemaVersion < 0 || event.SchemaVersion > pkgtypes.CurrentSchemaVersion {
+		return &types.ValidationError{
+			Field:   "schemaVersion",
+			Reason:  fmt.Sprintf("unsupported schema version %d (latest is %d)", event.SchemaVersion, pkgtypes.CurrentSchemaVersion),
+			Code:    ReasonUnsupportedSchema,
+			EventID: index,
+		}
+	}
+
 	// Check event type
 	if event.EventType == "" {
 		return &types.ValidationError{
 			Field:   "eventType",
 			Reason:  "missing required field",
+			Code:    ReasonMissingField,
 			EventID: index,
 		}
 	}
@@ -51,6 +88,7 @@ func ValidateEvent(event types.Event, index int) *types.ValidationError {
 		return &types.ValidationError{
 			Field:   "eventType",
 			Reason:  fmt.Sprintf("unknown event type: %s", event.EventType),
+			Code:    ReasonUnknownEventType,
 			EventID: index,
 		}
 	}
@@ -60,15 +98,17 @@ func ValidateEvent(event types.Event, index int) *types.ValidationError {
 		return &types.ValidationError{
 			Field:   "timestamp",
 			Reason:  "missing required field",
+			Code:    ReasonMissingField,
 			EventID: index,
 		}
 	}
 
-	// Validate timestamp format (ISO 8601)
-	if _, err := time.Parse(time.RFC3339, event.Timestamp); err != nil {
+	// Validate timestamp format (ISO 8601 or unix millis)
+	if _, err := parseTimestamp(event.Timestamp); err != nil {
 		return &types.ValidationError{
 			Field:   "timestamp",
 			Reason:  "invalid timestamp format",
+			Code:    ReasonInvalidTimestamp,
 			EventID: index,
 		}
 	}
@@ -78,6 +118,7 @@ func ValidateEvent(event types.Event, index int) *types.ValidationError {
 		return &types.ValidationError{
 			Field:   "sessionId",
 			Reason:  "missing required field",
+			Code:    ReasonMissingField,
 			EventID: index,
 		}
 	}
@@ -87,6 +128,7 @@ func ValidateEvent(event types.Event, index int) *types.ValidationError {
 		return &types.ValidationError{
 			Field:   "route",
 			Reason:  "missing required field",
+			Code:    ReasonMissingField,
 			EventID: index,
 		}
 	}
@@ -96,6 +138,7 @@ func ValidateEvent(event types.Event, index int) *types.ValidationError {
 		return &types.ValidationError{
 			Field:   "target.type",
 			Reason:  "missing required field",
+			Code:    ReasonMissingField,
 			EventID: index,
 		}
 	}
@@ -115,6 +158,7 @@ func ValidateBatch(events []types.Event) ([]types.Event, []types.ValidationError
 			{
 				Field:  "events",
 				Reason: fmt.Sprintf("batch size exceeds maximum: %d", MaxBatchSize),
+				Code:   ReasonBatchTooLarge,
 			},
 		}
 	}
@@ -131,3 +175,16 @@ func ValidateBatch(events []types.Event) ([]types.Event, []types.ValidationError
 
 	return validEvents, errors
 }
+
+// parseTimestamp accepts RFC 3339 (with optional fractional seconds) or unix
+// milliseconds, the same formats the detection engine reads
+func parseTimestamp(raw string) (time.Time, error) {
+	ts, err := time.Parse(time.RFC3339Nano, raw)
+	if err == nil {
+		return ts, nil
+	}
+	if millis, perr := strconv.ParseInt(raw, 10, 64); perr == nil {
+		return time.UnixMilli(millis).UTC(), nil
+	}
+	return time.Time{}, err
+}
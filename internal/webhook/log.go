@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Delivery statuses.
+const (
+	DeliveryFailed   = "failed"
+	DeliveryReplayed = "replayed"
+)
+
+// maxLoggedDeliveries caps the delivery log; the oldest entries are dropped
+// first.
+const maxLoggedDeliveries = 1000
+
+// Delivery is a logged webhook delivery.
+type Delivery struct {
+	ID             string          `json:"id"`
+	URL            string          `json:"url"`
+	Event          string          `json:"event"`
+	IdempotencyKey string          `json:"idempotencyKey,omitempty"`
+	Body           json.RawMessage `json:"body"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	StatusCode     int             `json:"statusCode,omitempty"`
+	LastError      string          `json:"lastError,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	LastAttemptAt  time.Time       `json:"lastAttemptAt"`
+}
+
+// DeliveryLog stores failed deliveries for inspection and replay.
+type DeliveryLog interface {
+	// Record inserts or replaces a delivery by ID.
+	Record(delivery Delivery) error
+	Get(id string) (Delivery, bool, error)
+	// List returns deliveries, newest first. An empty status returns all.
+	List(status string) ([]Delivery, error)
+}
+
+// MemoryLog is an in-memory DeliveryLog. It is lost on restart.
+type MemoryLog struct {
+	mu         sync.RWMutex
+	deliveries map[string]Delivery
+}
+
+// NewMemoryLog creates an empty in-memory delivery log.
+func NewMemoryLog() *MemoryLog {
+	return &MemoryLog{deliveries: make(map[string]Delivery)}
+}
+
+// Record inserts or replaces a delivery.
+func (l *MemoryLog) Record(delivery Delivery) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.deliveries[delivery.ID] = delivery
+	l.prune()
+	return nil
+}
+
+// Get returns a delivery by ID.
+func (l *MemoryLog) Get(id string) (Delivery, bool, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	d, ok := l.deliveries[id]
+	return d, ok, nil
+}
+
+// List returns deliveries with the given status (or all), newest first.
+func (l *MemoryLog) List(status string) ([]Delivery, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	out := make([]Delivery, 0, len(l.deliveries))
+	for _, d := range l.deliveries {
+		if status == "" || d.Status == status {
+			out = append(out, d)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+// prune drops the oldest deliveries beyond maxLoggedDeliveries. Callers
+// must hold the write lock.
+func (l *MemoryLog) prune() {
+	if len(l.deliveries) <= maxLoggedDeliveries {
+		return
+	}
+	all := make([]Delivery, 0, len(l.deliveries))
+	for _, d := range l.deliveries {
+		all = append(all, d)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.Before(all[j].CreatedAt) })
+	for _, d := range all[:len(all)-maxLoggedDeliveries] {
+		delete(l.deliveries, d.ID)
+	}
+}
+
+// FileLog is a DeliveryLog persisted as a JSON file, so failed deliveries
+// survive restarts. The file is rewritten atomically on every change.
+type FileLog struct {
+	path string
+	mem  *MemoryLog
+	mu   sync.Mutex // serializes writes to path
+}
+
+// NewFileLog opens (or creates) a delivery log at path.
+func NewFileLog(path string) (*FileLog, error) {
+	l := &FileLog{path: path, mem: NewMemoryLog()}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read delivery log: %w", err)
+	}
+	var deliveries []Delivery
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &deliveries); err != nil {
+			return nil, fmt.Errorf("parse delivery log: %w", err)
+		}
+	}
+	for _, d := range deliveries {
+		l.mem.deliveries[d.ID] = d
+	}
+	return l, nil
+}
+
+// Record inserts or replaces a delivery and persists the log.
+func (l *FileLog) Record(delivery Delivery) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mem.Record(delivery)
+	return l.flush()
+}
+
+// Get returns a delivery by ID.
+func (l *FileLog) Get(id string) (Delivery, bool, error) {
+	return l.mem.Get(id)
+}
+
+// List returns deliveries with the given status (or all), newest first.
+func (l *FileLog) List(status string) ([]Delivery, error) {
+	return l.mem.List(status)
+}
+
+// flush writes the log to a temp file and renames it over path.
+func (l *FileLog) flush() error {
+	deliveries, _ := l.mem.List("")
+	data, err := json.MarshalIndent(deliveries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write delivery log: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write delivery log: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write delivery log: %w", err)
+	}
+	return os.Rename(tmp.Name(), l.path)
+}
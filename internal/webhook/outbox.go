@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+)
+
+// Outbox exposes the delivery log shared by several notifiers, replaying
+// each delivery through the notifier of its endpoint (and so its secret).
+type Outbox struct {
+	log       DeliveryLog
+	notifiers map[string]*Notifier // by URL
+}
+
+// NewOutbox creates an outbox over a delivery log and the notifiers that
+// write to it.
+func NewOutbox(log DeliveryLog, notifiers ...*Notifier) *Outbox {
+	o := &Outbox{log: log, notifiers: make(map[string]*Notifier, len(notifiers))}
+	for _, n := range notifiers {
+		o.notifiers[n.URL()] = n
+	}
+	return o
+}
+
+// Deliveries lists logged deliveries, newest first, optionally filtered by
+// status.
+func (o *Outbox) Deliveries(status string) ([]Delivery, error) {
+	return o.log.List(status)
+}
+
+// Replay re-sends a logged delivery. Deliveries to an endpoint that is no
+// longer configured cannot be replayed.
+func (o *Outbox) Replay(ctx context.Context, deliveryID string) (*Response, error) {
+	delivery, ok, err := o.log.Get(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: delivery %s", ErrNotFound, deliveryID)
+	}
+	n := o.notifiers[delivery.URL]
+	if n == nil {
+		return nil, fmt.Errorf("%w: no notifier for %s", ErrPermanent, delivery.URL)
+	}
+	return n.Replay(ctx, deliveryID)
+}
@@ -0,0 +1,318 @@
+// Package webhook delivers signed incident payloads to arbitrary HTTP
+// endpoints (triage bots, automation tools).
+//
+// Every delivery is a POST of a versioned JSON Payload. The body is signed
+// with HMAC-SHA256 over "<timestamp>.<body>" and the signature is sent in
+// X-HawkEye-Signature alongside X-HawkEye-Timestamp, so receivers can reject
+// forged and replayed requests with Verify. Transient failures are retried
+// with resilience.Retry when Config.Retry asks for it (callers that retry on
+// their own, like the ticket exporter, leave it off); deliveries that still
+// fail are written to a DeliveryLog where they can be inspected and replayed
+// (see Outbox).
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/your-org/frustration-engine/internal/resilience"
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+// PayloadVersion is the version of the Payload schema. It changes only when
+// fields are removed or change meaning; new fields may be added at any time.
+const PayloadVersion = "1"
+
+// Event types.
+const (
+	EventIncidentDetected = "incident.detected"
+	EventTicketRequested  = "ticket.requested"
+)
+
+// Request headers.
+const (
+	HeaderSignature   = "X-HawkEye-Signature"
+	HeaderTimestamp   = "X-HawkEye-Timestamp"
+	HeaderEvent       = "X-HawkEye-Event"
+	HeaderDelivery    = "X-HawkEye-Delivery"
+	HeaderIdempotency = "Idempotency-Key"
+)
+
+var (
+	// ErrRetryable marks delivery failures worth retrying (network, 408, 429, 5xx).
+	ErrRetryable = errors.New("retryable webhook delivery failure")
+	// ErrPermanent marks delivery failures that retrying cannot fix (other 4xx).
+	ErrPermanent = errors.New("permanent webhook delivery failure")
+	// ErrBadSignature is returned by Verify for unsigned, forged or stale requests.
+	ErrBadSignature = errors.New("invalid webhook signature")
+	// ErrNotFound is returned when replaying a delivery that is not logged.
+	ErrNotFound = errors.New("webhook delivery not found")
+)
+
+// Payload is the JSON body of every webhook delivery.
+type Payload struct {
+	Version        string          `json:"version"`
+	Event          string          `json:"event"`
+	DeliveryID     string          `json:"deliveryId"`
+	IdempotencyKey string          `json:"idempotencyKey,omitempty"`
+	SentAt         time.Time       `json:"sentAt"`
+	Incident       IncidentPayload `json:"incident"`
+	Ticket         *TicketPayload  `json:"ticket,omitempty"`
+}
+
+// IncidentPayload is the incident as exposed to webhook receivers.
+type IncidentPayload struct {
+	IncidentID          string    `json:"incidentId"`
+	SessionID           string    `json:"sessionId"`
+	ProjectID           string    `json:"projectId"`
+	IssueID             string    `json:"issueId,omitempty"`
+	FrustrationScore    int       `json:"frustrationScore"`
+	ConfidenceScore     float64   `json:"confidenceScore"`
+	ConfidenceLevel     string    `json:"confidenceLevel"`
+	SeverityType        string    `json:"severityType"`
+	PrimaryFailurePoint string    `json:"primaryFailurePoint"`
+	TriggeringSignals   []string  `json:"triggeringSignals"`
+	Explanation         string    `json:"explanation"`
+	Timestamp           time.Time `json:"timestamp"`
+}
+
+// TicketPayload is the formatted ticket, for receivers that file tickets.
+type TicketPayload struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Labels      []string          `json:"labels"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// NewPayload builds a payload for an incident.
+func NewPayload(event string, incident types.Incident) Payload {
+	return Payload{
+		Version:    PayloadVersion,
+		Event:      event,
+		DeliveryID: uuid.New().String(),
+		SentAt:     time.Now().UTC(),
+		Incident: IncidentPayload{
+			IncidentID:          incident.IncidentID,
+			SessionID:           incident.SessionID,
+			ProjectID:           incident.ProjectID,
+			IssueID:             incident.IssueID,
+			FrustrationScore:    incident.FrustrationScore,
+			ConfidenceScore:     incident.ConfidenceScore,
+			ConfidenceLevel:     incident.ConfidenceLevel,
+			SeverityType:        incident.SeverityType,
+			PrimaryFailurePoint: incident.PrimaryFailurePoint,
+			TriggeringSignals:   incident.TriggeringSignals,
+			Explanation:         incident.Explanation,
+			Timestamp:           incident.Timestamp,
+		},
+	}
+}
+
+// Sign returns the signature header value for a body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a received request's signature and rejects timestamps more
+// than tolerance away from now. Receivers should call it before trusting a
+// payload.
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	timestamp, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing or malformed timestamp", ErrBadSignature)
+	}
+	age := time.Since(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrBadSignature)
+	}
+	expected := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(header.Get(HeaderSignature))) {
+		return fmt.Errorf("%w: signature mismatch", ErrBadSignature)
+	}
+	return nil
+}
+
+// Config configures a Notifier.
+type Config struct {
+	URL     string
+	Secret  string
+	Timeout time.Duration
+	// Retry retries transient failures within a Send. The zero value sends
+	// once.
+	Retry resilience.RetryConfig
+	// Log records failed deliveries for inspection and replay. Nil keeps
+	// them in memory only; use a FileLog to keep them across restarts.
+	Log DeliveryLog
+}
+
+// Response is a receiver's reply to a successful delivery.
+type Response struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Notifier POSTs signed payloads to a single endpoint.
+type Notifier struct {
+	url    string
+	secret string
+	client *http.Client
+	retry  resilience.RetryConfig
+	log    DeliveryLog
+	now    func() time.Time
+}
+
+// NewNotifier creates a webhook notifier.
+func NewNotifier(cfg Config) *Notifier {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	// Only transient failures are retried
+	cfg.Retry.RetryableErrors = []error{ErrRetryable}
+	if cfg.Log == nil {
+		cfg.Log = NewMemoryLog()
+	}
+	return &Notifier{
+		url:    cfg.URL,
+		secret: cfg.Secret,
+		client: &http.Client{Timeout: cfg.Timeout},
+		retry:  cfg.Retry,
+		log:    cfg.Log,
+		now:    time.Now,
+	}
+}
+
+// Notify sends an incident.detected payload for an incident.
+func (n *Notifier) Notify(ctx context.Context, incident types.Incident) (*Response, error) {
+	return n.Send(ctx, NewPayload(EventIncidentDetected, incident))
+}
+
+// Send delivers a payload, retrying transient failures if configured. A
+// delivery that still fails is recorded in the delivery log.
+func (n *Notifier) Send(ctx context.Context, payload Payload) (*Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: marshal payload: %v", ErrPermanent, err)
+	}
+
+	delivery := Delivery{
+		ID:             payload.DeliveryID,
+		URL:            n.url,
+		Event:          payload.Event,
+		IdempotencyKey: payload.IdempotencyKey,
+		Body:           body,
+		CreatedAt:      n.now().UTC(),
+	}
+	resp, err := n.deliver(ctx, &delivery)
+	if err != nil {
+		delivery.Status = DeliveryFailed
+		if logErr := n.log.Record(delivery); logErr != nil {
+			log.Printf("[webhook] failed to record delivery %s: %v", delivery.ID, logErr)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Replay re-sends a logged delivery with a fresh timestamp and signature.
+// The body and delivery ID are unchanged so receivers can deduplicate.
+func (n *Notifier) Replay(ctx context.Context, deliveryID string) (*Response, error) {
+	delivery, ok, err := n.log.Get(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, deliveryID)
+	}
+
+	resp, sendErr := n.deliver(ctx, &delivery)
+	if sendErr == nil {
+		delivery.Status = DeliveryReplayed
+		delivery.LastError = ""
+	}
+	if err := n.log.Record(delivery); err != nil {
+		log.Printf("[webhook] failed to update delivery %s: %v", delivery.ID, err)
+	}
+	return resp, sendErr
+}
+
+// Deliveries lists logged deliveries, optionally filtered by status.
+func (n *Notifier) Deliveries(status string) ([]Delivery, error) {
+	return n.log.List(status)
+}
+
+// URL returns the endpoint the notifier delivers to.
+func (n *Notifier) URL() string {
+	return n.url
+}
+
+// deliver POSTs a delivery's body (with retries if configured), updating its
+// attempt bookkeeping.
+func (n *Notifier) deliver(ctx context.Context, delivery *Delivery) (*Response, error) {
+	var resp *Response
+	err := resilience.Retry(ctx, func() error {
+		delivery.Attempts++
+		delivery.LastAttemptAt = n.now().UTC()
+		r, statusCode, err := n.post(ctx, delivery)
+		delivery.StatusCode = statusCode
+		if err != nil {
+			delivery.LastError = err.Error()
+			return err
+		}
+		resp = r
+		return nil
+	}, n.retry)
+	return resp, err
+}
+
+// post performs a single signed POST.
+func (n *Notifier) post(ctx context.Context, delivery *Delivery) (*Response, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrPermanent, err)
+	}
+	timestamp := n.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "HawkEye-Webhook/"+PayloadVersion)
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	if n.secret != "" {
+		req.Header.Set(HeaderSignature, Sign(n.secret, timestamp, delivery.Body))
+	}
+	if delivery.IdempotencyKey != "" {
+		req.Header.Set(HeaderIdempotency, delivery.IdempotencyKey)
+	}
+
+	httpResp, err := n.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrRetryable, err)
+	}
+	defer httpResp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(httpResp.Body, 64*1024))
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		kind := ErrPermanent
+		if httpResp.StatusCode == http.StatusRequestTimeout || httpResp.StatusCode == http.StatusTooManyRequests || httpResp.StatusCode >= 500 {
+			kind = ErrRetryable
+		}
+		return nil, httpResp.StatusCode, fmt.Errorf("%w: HTTP %d", kind, httpResp.StatusCode)
+	}
+	return &Response{StatusCode: httpResp.StatusCode, Body: body}, httpResp.StatusCode, nil
+}
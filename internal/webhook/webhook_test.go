@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/your-org/frustration-engine/internal/resilience"
+	"github.com/your-org/frustration-engine/internal/types"
+)
+
+func fastRetry() resilience.RetryConfig {
+	return resilience.RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffMultiplier: 1}
+}
+
+func TestNotify_SignsVersionedPayload(t *testing.T) {
+	var verifyErr error
+	var payload Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verifyErr = Verify("s3cret", r.Header, body, time.Minute)
+		json.Unmarshal(body, &payload)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	n := NewNotifier(Config{URL: server.URL, Secret: "s3cret", Retry: fastRetry()})
+	resp, err := n.Notify(context.Background(), types.Incident{IncidentID: "inc-1", ConfidenceScore: 81})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("StatusCode = %d", resp.StatusCode)
+	}
+	if verifyErr != nil {
+		t.Errorf("receiver failed to verify signature: %v", verifyErr)
+	}
+	if payload.Version != PayloadVersion || payload.Event != EventIncidentDetected || payload.Incident.IncidentID != "inc-1" {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestVerify_RejectsForgedAndStale(t *testing.T) {
+	body := []byte(`{"event":"incident.detected"}`)
+	now := time.Now().Unix()
+
+	header := http.Header{}
+	header.Set(HeaderTimestamp, "1")
+	header.Set(HeaderSignature, Sign("s3cret", 1, body))
+	if err := Verify("s3cret", header, body, time.Minute); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("stale timestamp: err = %v", err)
+	}
+
+	header.Set(HeaderTimestamp, strconv.FormatInt(now, 10))
+	header.Set(HeaderSignature, Sign("other", now, body))
+	if err := Verify("s3cret", header, body, time.Minute); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("wrong secret: err = %v", err)
+	}
+}
+
+func TestSend_RetriesThenLogsAndReplays(t *testing.T) {
+	var calls, failing int32 = 0, 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	logPath := filepath.Join(t.TempDir(), "deliveries.json")
+	fileLog, err := NewFileLog(logPath)
+	if err != nil {
+		t.Fatalf("NewFileLog: %v", err)
+	}
+	n := NewNotifier(Config{URL: server.URL, Secret: "s", Retry: fastRetry(), Log: fileLog})
+
+	_, err = n.Notify(context.Background(), types.Incident{IncidentID: "inc-1"})
+	if !errors.Is(err, ErrRetryable) {
+		t.Fatalf("expected retryable failure, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3 (1 + 2 retries)", calls)
+	}
+
+	// The failure survives a restart
+	reopened, err := NewFileLog(logPath)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	failed, _ := reopened.List(DeliveryFailed)
+	if len(failed) != 1 || failed[0].Attempts != 3 || failed[0].StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("failed deliveries = %+v", failed)
+	}
+
+	atomic.StoreInt32(&failing, 0)
+	n = NewNotifier(Config{URL: server.URL, Secret: "s", Retry: fastRetry(), Log: reopened})
+	if _, err := n.Replay(context.Background(), failed[0].ID); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if remaining, _ := n.Deliveries(DeliveryFailed); len(remaining) != 0 {
+		t.Errorf("expected no failed deliveries after replay, got %d", len(remaining))
+	}
+}
+
+func TestSend_PermanentFailureIsNotRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	n := NewNotifier(Config{URL: server.URL, Retry: fastRetry()})
+	_, err := n.Notify(context.Background(), types.Incident{})
+	if !errors.Is(err, ErrPermanent) || calls != 1 {
+		t.Errorf("err = %v after %d calls, want permanent after 1", err, calls)
+	}
+}
+
+func TestSend_ZeroRetrySendsOnce(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// Callers that retry on their own leave Retry unset
+	n := NewNotifier(Config{URL: server.URL})
+	if _, err := n.Notify(context.Background(), types.Incident{}); !errors.Is(err, ErrRetryable) || calls != 1 {
+		t.Errorf("err = %v after %d calls, want retryable after 1", err, calls)
+	}
+}
+
+func TestOutbox_ReplaysThroughDeliveringNotifier(t *testing.T) {
+	var failing int32 = 1
+	var secrets []string
+	newServer := func(secret string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if atomic.LoadInt32(&failing) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			if Verify(secret, r.Header, body, time.Minute) == nil {
+				secrets = append(secrets, secret)
+			}
+		}))
+	}
+	exportServer, incidentServer := newServer("export"), newServer("incident")
+	defer exportServer.Close()
+	defer incidentServer.Close()
+
+	deliveries := NewMemoryLog()
+	exportHook := NewNotifier(Config{URL: exportServer.URL, Secret: "export", Log: deliveries})
+	incidentHook := NewNotifier(Config{URL: incidentServer.URL, Secret: "incident", Log: deliveries})
+	outbox := NewOutbox(deliveries, exportHook, incidentHook)
+
+	incidentHook.Notify(context.Background(), types.Incident{IncidentID: "inc-1"})
+	failed, _ := outbox.Deliveries(DeliveryFailed)
+	if len(failed) != 1 {
+		t.Fatalf("failed deliveries = %d, want 1", len(failed))
+	}
+
+	atomic.StoreInt32(&failing, 0)
+	if _, err := outbox.Replay(context.Background(), failed[0].ID); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if len(secrets) != 1 || secrets[0] != "incident" {
+		t.Errorf("replay verified with %v, want the incident secret", secrets)
+	}
+	if _, err := outbox.Replay(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing delivery: err = %v, want ErrNotFound", err)
+	}
+}
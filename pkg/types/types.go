@@ -4,6 +4,11 @@ package types
 
 import "time"
 
+// CurrentSchemaVersion is the event schema version the server produces and
+// the latest it accepts. Older versions are upcast at ingest; see
+// internal/schema and GET /v1/schema.
+const CurrentSchemaVersion = 2
+
 // Event represents a normalized event from the SDK.
 type Event struct {
 	EventType      string                 `json:"eventType"`
@@ -14,6 +19,9 @@ type Event struct {
 	Metadata       map[string]interface{} `json:"metadata"`
 	Environment    string                 `json:"environment,omitempty"`
 	IdempotencyKey string                 `json:"idempotencyKey,omitempty"`
+	// SchemaVersion is the event schema version; 0 means an unversioned
+	// payload from an SDK that predates versioning (version 1).
+	SchemaVersion int `json:"schemaVersion,omitempty"`
 }
 
 // EventTarget represents the target of an event.
@@ -34,9 +42,63 @@ type IngestRequest struct {
 
 // IngestResponse represents the API response for event ingestion.
 type IngestResponse struct {
-	Success   bool   `json:"success"`
-	Processed int    `json:"processed,omitempty"`
-	Message   string `json:"message,omitempty"`
+	Success    bool             `json:"success"`
+	Processed  int              `json:"processed,omitempty"`
+	Message    string           `json:"message,omitempty"`
+	Rejected   []EventRejection `json:"rejected,omitempty"`
+	Redactions map[string]int   `json:"redactions,omitempty"` // redacted values by rule
+	Error      *IngestError     `json:"error,omitempty"`
+}
+
+// IngestError describes why a whole batch was refused.
+type IngestError struct {
+	Code  string `json:"code"`            // e.g. payload_too_large, batch_too_large
+	Limit int64  `json:"limit,omitempty"` // the limit exceeded, in bytes or events
+}
+
+// LineError reports an NDJSON line that was not ingested.
+type LineError struct {
+	Line   int    `json:"line"` // 1-based
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// StreamIngestResponse summarizes an NDJSON ingestion stream. Lines is the
+// number of lines handled; a stream stopped early (e.g. by a quota) can be
+// resumed after that line.
+type StreamIngestResponse struct {
+	Success         bool           `json:"success"`
+	Lines           int            `json:"lines"`
+	Processed       int            `json:"processed"`
+	Rejected        int            `json:"rejected"`
+	DeadLettered    int            `json:"deadLettered,omitempty"`
+	Throttled       int            `json:"throttled,omitempty"` // chunks held back by backpressure
+	Errors          []LineError    `json:"errors,omitempty"`    // the first errors, in line order
+	ErrorCounts     map[string]int `json:"errorCounts,omitempty"`
+	ErrorsTruncated bool           `json:"errorsTruncated,omitempty"`
+	Redactions      map[string]int `json:"redactions,omitempty"`
+	Message         string         `json:"message,omitempty"`
+	Error           *IngestError   `json:"error,omitempty"`
+}
+
+// DeadLetterReplayRequest selects dead-lettered events to replay: by ID, or
+// by a filter (at least one of ProjectID, Reason, Since or Until).
+type DeadLetterReplayRequest struct {
+	IDs       []string  `json:"ids,omitempty"`
+	ProjectID string    `json:"projectId,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Since     time.Time `json:"since,omitempty"`
+	Until     time.Time `json:"until,omitempty"`
+	Limit     int       `json:"limit,omitempty"`
+}
+
+// EventRejection explains why an event in an ingest batch was dropped.
+type EventRejection struct {
+	Index  int    `json:"index"` // position in the request's events array
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"` // stable code, e.g. "missing_field"
+	Detail string `json:"detail,omitempty"`
 }
 
 // Session represents a completed user session.
@@ -68,26 +130,56 @@ const (
 
 // Incident represents a detected frustration incident.
 type Incident struct {
-	IncidentID          string         `json:"incidentId"`
-	SessionID           string         `json:"sessionId"`
-	ProjectID           string         `json:"projectId"`
-	FrustrationScore    int            `json:"frustrationScore"`
-	ConfidenceLevel     string         `json:"confidenceLevel"`
-	TriggeringSignals   []string       `json:"triggeringSignals"`
-	PrimaryFailurePoint string         `json:"primaryFailurePoint"`
-	SeverityType        string         `json:"severityType"`
-	Timestamp           time.Time      `json:"timestamp"`
-	Explanation         string         `json:"explanation"`
-	SignalDetails       []SignalDetail `json:"signalDetails"`
-	Status              string         `json:"status"`
-	ConfidenceScore     float64        `json:"confidenceScore"`
-	Suppressed          bool           `json:"suppressed"`
-	ExternalTicketID    string         `json:"externalTicketId,omitempty"`
-	ExternalSystem      string         `json:"externalSystem,omitempty"`
-	ExportedAt          *time.Time     `json:"exportedAt,omitempty"`
-	ExportFailed        bool           `json:"exportFailed"`
-	CreatedAt           time.Time      `json:"createdAt"`
-	UpdatedAt           time.Time      `json:"updatedAt"`
+	IncidentID          string              `json:"incidentId"`
+	SessionID           string              `json:"sessionId"`
+	ProjectID           string              `json:"projectId"`
+	FrustrationScore    int                 `json:"frustrationScore"`
+	ConfidenceLevel     string              `json:"confidenceLevel"`
+	TriggeringSignals   []string            `json:"triggeringSignals"`
+	PrimaryFailurePoint string              `json:"primaryFailurePoint"`
+	SeverityType        string              `json:"severityType"`
+	Timestamp           time.Time           `json:"timestamp"`
+	Explanation         string              `json:"explanation"`
+	SignalDetails       []SignalDetail      `json:"signalDetails"`
+	Status              string              `json:"status"`
+	ConfidenceScore     float64             `json:"confidenceScore"`
+	Suppressed          bool                `json:"suppressed"`
+	ExternalTicketID    string              `json:"externalTicketId,omitempty"`
+	ExternalSystem      string              `json:"externalSystem,omitempty"`
+	ExportedAt          *time.Time          `json:"exportedAt,omitempty"`
+	ExportFailed        bool                `json:"exportFailed"`
+	IssueID             string              `json:"issueId,omitempty"`
+	Regression          bool                `json:"regression,omitempty"`
+	ErrorFingerprint    string              `json:"errorFingerprint,omitempty"`
+	UserID              string              `json:"userId,omitempty"`
+	ScoreModel          string              `json:"scoreModel,omitempty"`
+	ScoreFeatures       map[string]float64  `json:"scoreFeatures,omitempty"`
+	ScoreContributions  []ScoreContribution `json:"scoreContributions,omitempty"`
+	Client              *ClientContext      `json:"client,omitempty"`
+	CreatedAt           time.Time           `json:"createdAt"`
+	UpdatedAt           time.Time           `json:"updatedAt"`
+}
+
+// ClientContext describes the client behind a session, derived at ingest
+// from its user agent, IP address and release headers (see internal/enrich).
+type ClientContext struct {
+	Browser        string `json:"browser,omitempty"`
+	BrowserVersion string `json:"browserVersion,omitempty"` // major version
+	OS             string `json:"os,omitempty"`
+	OSVersion      string `json:"osVersion,omitempty"`
+	DeviceClass    string `json:"deviceClass,omitempty"`   // desktop, mobile, tablet, bot or unknown
+	BotLikelihood  string `json:"botLikelihood,omitempty"` // high, medium or low
+	Release        string `json:"release,omitempty"`
+	SDKVersion     string `json:"sdkVersion,omitempty"`
+	Country        string `json:"country,omitempty"` // ISO 3166-1 alpha-2
+}
+
+// ScoreContribution is one feature's share of an incident's frustration score.
+type ScoreContribution struct {
+	Feature      string  `json:"feature"`
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
 }
 
 // SignalDetail provides details about a detected signal.
@@ -105,8 +197,16 @@ type QueryRequest struct {
 	MinConfidence float64 `json:"minConfidence,omitempty"`
 	Suppressed    *bool   `json:"suppressed,omitempty"`
 	Exported      *bool   `json:"exported,omitempty"`
+	ExportFailed  *bool   `json:"exportFailed,omitempty"`
 	Limit         int     `json:"limit,omitempty"`
 	Offset        int     `json:"offset,omitempty"`
+	// Client filters match the incident's ClientContext, ignoring case.
+	Browser       string `json:"browser,omitempty"`
+	OS            string `json:"os,omitempty"`
+	DeviceClass   string `json:"deviceClass,omitempty"`
+	BotLikelihood string `json:"botLikelihood,omitempty"`
+	Release       string `json:"release,omitempty"`
+	Country       string `json:"country,omitempty"`
 }
 
 // QueryResponse represents a query response.
@@ -119,3 +219,68 @@ type QueryResponse struct {
 
 // Filter is an alias for QueryRequest used by the IncidentStore interface.
 type Filter = QueryRequest
+
+// Issue groups incidents from many sessions that share a fingerprint
+// (normalized route, failure point, signal types, error fingerprint).
+type Issue struct {
+	IssueID             string     `json:"issueId"`
+	ProjectID           string     `json:"projectId"`
+	Fingerprint         string     `json:"fingerprint"`
+	Route               string     `json:"route"`
+	PrimaryFailurePoint string     `json:"primaryFailurePoint"`
+	SignalTypes         []string   `json:"signalTypes"`
+	ErrorFingerprint    string     `json:"errorFingerprint,omitempty"`
+	SeverityType        string     `json:"severityType"`
+	IncidentCount       int        `json:"incidentCount"`
+	AffectedSessions    int        `json:"affectedSessions"`
+	AffectedUsers       int        `json:"affectedUsers"`
+	ExampleSessionIDs   []string   `json:"exampleSessionIds"`
+	MaxFrustrationScore int        `json:"maxFrustrationScore"`
+	MaxConfidenceScore  float64    `json:"maxConfidenceScore"`
+	FirstSeen           time.Time  `json:"firstSeen"`
+	LastSeen            time.Time  `json:"lastSeen"`
+	WindowCount         int        `json:"windowCount"`
+	PreviousWindowCount int        `json:"previousWindowCount"`
+	Trend               string     `json:"trend"`
+	Status              string     `json:"status"`
+	ExternalTicketID    string     `json:"externalTicketId,omitempty"`
+	ExternalSystem      string     `json:"externalSystem,omitempty"`
+	ExportedAt          *time.Time `json:"exportedAt,omitempty"`
+	ResolvedAt          *time.Time `json:"resolvedAt,omitempty"`
+	RegressedAt         *time.Time `json:"regressedAt,omitempty"`
+	RegressionCount     int        `json:"regressionCount,omitempty"`
+}
+
+// Issue trend constants, comparing the current rolling window to the previous one.
+const (
+	IssueTrendNew     = "new"
+	IssueTrendRising  = "rising"
+	IssueTrendStable  = "stable"
+	IssueTrendFalling = "falling"
+)
+
+// Issue status constants. A resolved issue whose fingerprint recurs after
+// the regression quiet period becomes regressed.
+const (
+	IssueStatusOpen      = "open"
+	IssueStatusResolved  = "resolved"
+	IssueStatusRegressed = "regressed"
+)
+
+// IssueQuery represents a query for issues.
+type IssueQuery struct {
+	ProjectID   string `json:"projectId,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Trend       string `json:"trend,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Limit       int    `json:"limit,omitempty"`
+	Offset      int    `json:"offset,omitempty"`
+}
+
+// IssueQueryResponse represents an issue query response.
+type IssueQueryResponse struct {
+	Issues []Issue `json:"issues"`
+	Total  int     `json:"total"`
+	Limit  int     `json:"limit"`
+	Offset int     `json:"offset"`
+}
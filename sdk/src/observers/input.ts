@@ -1,6 +1,7 @@
 /**
  * Input Event Observer
- * Captures focus, blur, and form submission events
+ * Captures focus, blur, edit, paste, validation and form submission events.
+ * Only value lengths and counts are recorded, never field values (privacy).
  */
 
 import { SessionManager } from '../core/session';
@@ -13,6 +14,9 @@ export class InputObserver {
   private focusHandler: ((e: FocusEvent) => void) | null = null;
   private blurHandler: ((e: FocusEvent) => void) | null = null;
   private submitHandler: ((e: SubmitEvent) => void) | null = null;
+  private inputHandler: ((e: globalThis.Event) => void) | null = null;
+  private pasteHandler: ((e: ClipboardEvent) => void) | null = null;
+  private invalidHandler: ((e: globalThis.Event) => void) | null = null;
 
   constructor(sessionManager: SessionManager, eventQueue: EventQueue) {
     this.sessionManager = sessionManager;
@@ -26,56 +30,22 @@ export class InputObserver {
 
     // Focus events
     this.focusHandler = (e: FocusEvent) => {
-      const target = e.target as HTMLElement;
-      if (!target || (target.tagName !== 'INPUT' && target.tagName !== 'TEXTAREA')) {
+      const input = this.fieldTarget(e.target);
+      if (!input) {
         return;
       }
-
-      const event: Event = {
-        eventType: 'input_focus',
-        timestamp: new Date().toISOString(),
-        sessionId: this.sessionManager.getSessionId(),
-        route: this.sessionManager.getCurrentRoute(),
-        target: {
-          type: 'input',
-          id: target.id || undefined,
-          tagName: target.tagName.toLowerCase(),
-        },
-        metadata: {
-          inputType: (target as HTMLInputElement).type || 'text',
-        },
-      };
-
-      this.eventQueue.add(event);
+      this.eventQueue.add(this.fieldEvent('input_focus', input, {}));
     };
 
     // Blur events
     this.blurHandler = (e: FocusEvent) => {
-      const target = e.target as HTMLElement;
-      if (!target || (target.tagName !== 'INPUT' && target.tagName !== 'TEXTAREA')) {
+      const input = this.fieldTarget(e.target);
+      if (!input) {
         return;
       }
-
-      const input = target as HTMLInputElement;
-      const valueLength = input.value?.length || 0;
-
-      const event: Event = {
-        eventType: 'input_blur',
-        timestamp: new Date().toISOString(),
-        sessionId: this.sessionManager.getSessionId(),
-        route: this.sessionManager.getCurrentRoute(),
-        target: {
-          type: 'input',
-          id: target.id || undefined,
-          tagName: target.tagName.toLowerCase(),
-        },
-        metadata: {
-          inputType: input.type || 'text',
-          valueLength, // Only length, not actual value (privacy)
-        },
-      };
-
-      this.eventQueue.add(event);
+      this.eventQueue.add(this.fieldEvent('input_blur', input, {
+        valueLength: input.value?.length || 0, // Only length, not actual value (privacy)
+      }));
     };
 
     // Form submission
@@ -102,9 +72,113 @@ export class InputObserver {
       this.eventQueue.add(event);
     };
 
+    // Edits (length only)
+    this.inputHandler = (e: globalThis.Event) => {
+      const input = this.fieldTarget(e.target);
+      if (!input) {
+        return;
+      }
+      this.eventQueue.add(this.fieldEvent('input', input, {
+        editType: (e as InputEvent).inputType || 'unknown',
+        valueLength: input.value?.length || 0,
+      }));
+    };
+
+    // Paste (pasted length only)
+    this.pasteHandler = (e: ClipboardEvent) => {
+      const input = this.fieldTarget(e.target);
+      if (!input) {
+        return;
+      }
+      this.eventQueue.add(this.fieldEvent('input_paste', input, {
+        pastedLength: e.clipboardData?.getData('text')?.length || 0,
+      }));
+    };
+
+    // Native constraint validation failures
+    this.invalidHandler = (e: globalThis.Event) => {
+      const input = this.fieldTarget(e.target);
+      if (!input) {
+        return;
+      }
+      this.eventQueue.add(this.fieldEvent('input_invalid', input, {
+        valueLength: input.value?.length || 0,
+      }));
+    };
+
     document.addEventListener('focus', this.focusHandler, true);
     document.addEventListener('blur', this.blurHandler, true);
     document.addEventListener('submit', this.submitHandler, true);
+    document.addEventListener('input', this.inputHandler, true);
+    document.addEventListener('paste', this.pasteHandler, true);
+    document.addEventListener('invalid', this.invalidHandler, true);
+  }
+
+  private fieldTarget(target: globalThis.EventTarget | null): HTMLInputElement | null {
+    const el = target as HTMLElement | null;
+    if (!el || (el.tagName !== 'INPUT' && el.tagName !== 'TEXTAREA')) {
+      return null;
+    }
+    return el as HTMLInputElement;
+  }
+
+  private fieldEvent(eventType: string, input: HTMLInputElement, metadata: Record<string, any>): Event {
+    return {
+      eventType,
+      timestamp: new Date().toISOString(),
+      sessionId: this.sessionManager.getSessionId(),
+      route: this.sessionManager.getCurrentRoute(),
+      target: {
+        type: 'input',
+        id: input.id || undefined,
+        selector: this.fieldSelector(input),
+        tagName: input.tagName.toLowerCase(),
+      },
+      metadata: {
+        inputType: input.type || 'text',
+        ...metadata,
+      },
+    };
+  }
+
+  /**
+   * Stable CSS selector naming a field, so the server can tell fields apart:
+   * its id, else its name attribute (within its form), else an
+   * nth-of-type path from the nearest ancestor with an id.
+   */
+  private fieldSelector(input: HTMLInputElement): string {
+    if (input.id) {
+      return `#${cssEscape(input.id)}`;
+    }
+    const tag = input.tagName.toLowerCase();
+    const name = input.getAttribute('name');
+    if (name) {
+      const form = input.form;
+      const scope = form ? this.elementPath(form) + ' ' : '';
+      return `${scope}${tag}[name="${cssEscape(name)}"]`;
+    }
+    return this.elementPath(input);
+  }
+
+  private elementPath(el: Element): string {
+    const parts: string[] = [];
+    let node: Element | null = el;
+    while (node && node.tagName !== 'BODY' && node.tagName !== 'HTML') {
+      if (node.id) {
+        parts.unshift(`#${cssEscape(node.id)}`);
+        break;
+      }
+      const tag = node.tagName.toLowerCase();
+      let index = 1;
+      for (let sib = node.previousElementSibling; sib; sib = sib.previousElementSibling) {
+        if (sib.tagName === node.tagName) {
+          index++;
+        }
+      }
+      parts.unshift(`${tag}:nth-of-type(${index})`);
+      node = node.parentElement;
+    }
+    return parts.join(' > ');
   }
 
   stop(): void {
@@ -120,5 +194,24 @@ export class InputObserver {
       document.removeEventListener('submit', this.submitHandler, true);
       this.submitHandler = null;
     }
+    if (this.inputHandler) {
+      document.removeEventListener('input', this.inputHandler, true);
+      this.inputHandler = null;
+    }
+    if (this.pasteHandler) {
+      document.removeEventListener('paste', this.pasteHandler, true);
+      this.pasteHandler = null;
+    }
+    if (this.invalidHandler) {
+      document.removeEventListener('invalid', this.invalidHandler, true);
+      this.invalidHandler = null;
+    }
+  }
+}
+
+function cssEscape(value: string): string {
+  if (typeof CSS !== 'undefined' && typeof CSS.escape === 'function') {
+    return CSS.escape(value);
   }
+  return value.replace(/["\\#.:[\]\s]/g, '\\$&');
 }
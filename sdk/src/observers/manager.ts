@@ -77,11 +77,17 @@ export class ObserverManager {
       navigationObserver.start();
       this.observers.push(navigationObserver);
 
-      // Handle page unload
-      window.addEventListener('beforeunload', () => {
-        this.eventQueue.flush().catch(() => {
-          // Ignore errors during unload
-        });
+      // Handle page unload: a pending fetch is cancelled when the tab
+      // closes, so the last events go out through a keepalive fetch (or
+      // sendBeacon). pagehide and visibilitychange also fire on mobile,
+      // where beforeunload often doesn't
+      window.addEventListener('pagehide', () => {
+        this.eventQueue.flushOnUnload();
+      });
+      document.addEventListener('visibilitychange', () => {
+        if (document.visibilityState === 'hidden') {
+          this.eventQueue.flushOnUnload();
+        }
       });
     } catch (error) {
       if (this.config.isDebugEnabled()) {
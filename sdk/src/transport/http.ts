@@ -18,11 +18,7 @@ export class HTTPTransport {
    * Send events to ingestion API
    */
   async send(request: IngestRequest): Promise<IngestResponse> {
-    const url = this.ingestionUrl.endsWith('/v1/events')
-      ? this.ingestionUrl
-      : `${this.ingestionUrl}/v1/events`;
-
-    const response = await fetch(url, {
+    const response = await fetch(this.eventsUrl(), {
       method: 'POST',
       headers: {
         'Content-Type': 'application/json',
@@ -38,6 +34,57 @@ export class HTTPTransport {
     return response.json();
   }
 
+  /**
+   * Send events while the page unloads. A keepalive fetch survives the
+   * unload and goes to /v1/events with the API key header, like any other
+   * batch. Browsers without keepalive, or that refuse the request (e.g. over
+   * the 64 KB keepalive budget), fall back to sendBeacon. sendBeacon cannot
+   * set headers, so that batch goes to the beacon route as text/plain with
+   * the API key in the query string; the server only accepts it from origins
+   * in --beacon-origins. Returns false if nothing could queue the request.
+   */
+  sendBeacon(request: IngestRequest): boolean {
+    const body = JSON.stringify(request);
+
+    if (typeof fetch === 'function' && typeof Request !== 'undefined' && 'keepalive' in Request.prototype) {
+      fetch(this.eventsUrl(), {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+          'X-API-Key': this.apiKey,
+        },
+        body,
+        keepalive: true,
+      }).catch(() => {
+        this.sendBeaconRequest(body);
+      });
+      return true;
+    }
+    return this.sendBeaconRequest(body);
+  }
+
+  /**
+   * Queue a body on the beacon route with navigator.sendBeacon
+   */
+  private sendBeaconRequest(body: string): boolean {
+    if (typeof navigator === 'undefined' || typeof navigator.sendBeacon !== 'function') {
+      return false;
+    }
+    const base = this.ingestionUrl.replace(/\/v1\/events\/?$/, '').replace(/\/$/, '');
+    const url = `${base}/v1/events/beacon?api_key=${encodeURIComponent(this.apiKey)}`;
+    const blob = new Blob([body], { type: 'text/plain;charset=UTF-8' });
+    return navigator.sendBeacon(url, blob);
+  }
+
+  /**
+   * URL of the ingestion endpoint
+   */
+  private eventsUrl(): string {
+    return this.ingestionUrl.endsWith('/v1/events')
+      ? this.ingestionUrl
+      : `${this.ingestionUrl}/v1/events`;
+  }
+
   /**
    * Get API key (for queue)
    */
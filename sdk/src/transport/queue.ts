@@ -3,7 +3,7 @@
  * Batches events and sends them periodically
  */
 
-import { Event, IngestRequest } from '../types';
+import { Event, IngestRequest, SCHEMA_VERSION } from '../types';
 import { HTTPTransport } from './http';
 
 export class EventQueue {
@@ -48,11 +48,12 @@ export class EventQueue {
    * Enriches event with environment and idempotency key
    */
   add(event: Event): void {
-    // Enrich event with environment and idempotency key
+    // Enrich event with environment, idempotency key and schema version
     const enrichedEvent: Event = {
       ...event,
       environment: this.environment,
       idempotencyKey: this.generateIdempotencyKey(event.timestamp),
+      schemaVersion: SCHEMA_VERSION,
     };
 
     this.queue.push(enrichedEvent);
@@ -93,6 +94,32 @@ export class EventQueue {
     }
   }
 
+  /**
+   * Flush queue as the page unloads (keepalive fetch or sendBeacon). This is
+   * synchronous: there is no later chance to retry.
+   */
+  flushOnUnload(): void {
+    if (this.queue.length === 0) {
+      return;
+    }
+
+    const events = [...this.queue];
+    this.queue = [];
+
+    const request: IngestRequest = {
+      api_key: this.transport.getApiKey(),
+      sdk_version: '1.0.0',
+      events,
+    };
+
+    if (!this.transport.sendBeacon(request)) {
+      this.queue.unshift(...events);
+      if (this.debugEnabled) {
+        console.error('HawkEye SDK: Failed to send events on unload');
+      }
+    }
+  }
+
   /**
    * Start batch timer
    */
@@ -2,6 +2,11 @@
  * Type definitions for HawkEye Observer SDK
  */
 
+/**
+ * Event schema version this SDK emits (see GET /v1/schema on the server)
+ */
+export const SCHEMA_VERSION = 2;
+
 export interface EventTarget {
   type: string;
   id?: string;
@@ -18,6 +23,7 @@ export interface Event {
   metadata?: Record<string, any>;
   environment?: string;        // "production", "staging", "development"
   idempotencyKey?: string;     // Unique key to prevent duplicate signal processing
+  schemaVersion?: number;      // Absent in payloads from SDKs before versioning (version 1)
 }
 
 export interface IngestRequest {